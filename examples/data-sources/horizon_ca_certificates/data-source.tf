@@ -0,0 +1,16 @@
+# Trust bundle of every CA known to Horizon.
+data "horizon_ca_certificates" "all" {}
+
+output "trust_bundle" {
+  value = data.horizon_ca_certificates.all.bundle_pem
+}
+
+# The CA issuing the certificates of a profile, for the clients of the
+# certificates enrolled on it.
+data "horizon_ca_certificates" "issuing" {
+  profile = "EnrollmentProfile"
+}
+
+output "issuing_ca_expiry" {
+  value = data.horizon_ca_certificates.issuing.certificates[0].not_after
+}
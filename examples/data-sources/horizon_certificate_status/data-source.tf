@@ -0,0 +1,35 @@
+resource "horizon_certificate" "api" {
+  profile = "EnrollmentProfile"
+
+  subject = [
+    {
+      element = "CN"
+      type    = "CN"
+      value   = "api.example.com"
+    }
+  ]
+}
+
+# Warn on every plan and apply when the certificate of the endpoint is
+# revoked, checking its OCSP responder first and its CRL as a fallback.
+check "api_certificate_not_revoked" {
+  data "horizon_certificate_status" "api" {
+    certificate_id = horizon_certificate.api.id
+  }
+
+  assert {
+    condition     = data.horizon_certificate_status.api.status == "good"
+    error_message = "The certificate of api.example.com is ${data.horizon_certificate_status.api.status} according to ${data.horizon_certificate_status.api.responder}."
+  }
+}
+
+# Status of a certificate issued outside of Terraform, from its CRL only.
+data "horizon_certificate_status" "legacy" {
+  certificate_pem = file("${path.module}/certs/legacy.pem")
+  issuer_pem      = file("${path.module}/certs/issuing-ca.pem")
+  method          = "crl"
+}
+
+output "legacy_revocation_reason" {
+  value = data.horizon_certificate_status.legacy.revocation_reason
+}
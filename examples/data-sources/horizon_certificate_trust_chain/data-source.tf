@@ -29,3 +29,26 @@ data "horizon_certificate_trust_chain" "from_file" {
 output "file_chain" {
   value = data.horizon_certificate_trust_chain.from_file.chain
 }
+
+# Look the chain up by certificate id and build an nginx full chain (leaf and
+# intermediates) and a Kubernetes ca.crt.
+data "horizon_certificate_trust_chain" "fullchain" {
+  certificate_id = horizon_certificate.example.id
+  order          = "leaf_to_root"
+  include_root   = false
+
+  # Fail the read when the chain returned by Horizon does not verify locally.
+  verify = "error"
+}
+
+output "nginx_fullchain" {
+  value = data.horizon_certificate_trust_chain.fullchain.chain_pem
+}
+
+output "kubernetes_ca_crt" {
+  value = data.horizon_certificate_trust_chain.fullchain.root_pem
+}
+
+output "chain_valid_until" {
+  value = data.horizon_certificate_trust_chain.fullchain.valid_until
+}
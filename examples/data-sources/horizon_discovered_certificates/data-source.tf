@@ -0,0 +1,12 @@
+# Shadow certificates: found on the datacenter hosts, but not managed by Horizon.
+data "horizon_discovered_certificates" "shadow" {
+  campaign       = horizon_discovery_campaign.datacenter.name
+  unmanaged_only = true
+}
+
+output "shadow_certificates" {
+  value = {
+    for certificate in data.horizon_discovered_certificates.shadow.certificates :
+    certificate.dn => [for host in certificate.discovery_data : host.ip]
+  }
+}
@@ -0,0 +1,5 @@
+data "horizon_labels" "all" {}
+
+output "mandatory_labels" {
+  value = [for label in data.horizon_labels.all.labels : label.name if label.mandatory]
+}
@@ -0,0 +1,7 @@
+data "horizon_team" "security" {
+  name = "security"
+}
+
+output "security_contact" {
+  value = data.horizon_team.security.contact_email
+}
@@ -0,0 +1,17 @@
+# Publish to every Azure Key Vault connector, whoever manages them.
+data "horizon_third_party_connectors" "vaults" {
+  type = "akv"
+}
+
+resource "horizon_certificate" "shared" {
+  profile                = "EnrollmentProfile"
+  wait_for_third_parties = data.horizon_third_party_connectors.vaults.ids
+
+  subject = [
+    {
+      element = "cn.1"
+      type    = "CN"
+      value   = "shared.example.com"
+    }
+  ]
+}
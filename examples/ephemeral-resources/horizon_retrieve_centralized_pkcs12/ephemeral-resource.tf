@@ -17,6 +17,11 @@ resource "horizon_certificate" "server" {
 # ephemeral and are never written to Terraform state or saved plan files.
 ephemeral "horizon_retrieve_centralized_pkcs12" "server" {
   certificate_id = horizon_certificate.server.id
+
+  # Bound the lookup and a possible recovery request.
+  timeouts = {
+    open = "2m"
+  }
 }
 
 # Hand the bundle off to a downstream write-only consumer.
@@ -0,0 +1,8 @@
+# Compare DNs written in different styles.
+locals {
+  expected_subject = "/C=FR/O=Example/CN=www.example.org"
+  subject_matches = (
+    provider::horizon::format_dn(horizon_certificate.example.dn, "rfc4514") ==
+    provider::horizon::format_dn(local.expected_subject, "rfc4514")
+  )
+}
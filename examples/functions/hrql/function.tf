@@ -0,0 +1,15 @@
+# Build a query from untrusted values without worrying about quoting.
+locals {
+  expiring_webra_certificates = provider::horizon::hrql({
+    and = [
+      { field = "module", in = ["webra", "est"] },
+      { field = "owner", equals = var.owner },
+      { field = "notafter", before = timeadd(plantimestamp(), "720h") },
+      { not = { field = "status", equals = "revoked" } },
+    ]
+  })
+}
+
+output "expiring_webra_certificates_query" {
+  value = local.expiring_webra_certificates
+}
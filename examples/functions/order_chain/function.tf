@@ -0,0 +1,8 @@
+# Build a full chain for a web server from certificates held in any order.
+locals {
+  fullchain = join("", provider::horizon::order_chain([
+    file("${path.module}/intermediate.pem"),
+    file("${path.module}/server.pem"),
+    file("${path.module}/root.pem"),
+  ], "leaf_to_root"))
+}
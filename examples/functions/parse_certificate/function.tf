@@ -0,0 +1,12 @@
+# Inspect a certificate that was not enrolled through horizon_certificate.
+locals {
+  vendor_certificate = provider::horizon::parse_certificate(file("${path.module}/vendor.pem"))
+}
+
+output "vendor_certificate_expiry" {
+  value = local.vendor_certificate.not_after
+}
+
+output "vendor_certificate_dns_names" {
+  value = flatten([for san in local.vendor_certificate.sans : san.value if san.type == "DNSNAME"])
+}
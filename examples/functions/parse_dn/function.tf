@@ -0,0 +1,5 @@
+# Extract the common name of a certificate subject.
+locals {
+  subject     = provider::horizon::parse_dn(horizon_certificate.example.dn)
+  common_name = one([for rdn in local.subject : rdn.value if rdn.type == "CN"])
+}
@@ -0,0 +1,19 @@
+# Split a centralized PKCS#12 bundle into PEM files for a web server. Called
+# with ephemeral values, the decoded parts are ephemeral too and never reach
+# the state.
+ephemeral "horizon_retrieve_centralized_pkcs12" "server" {
+  certificate_id = horizon_certificate.server.id
+}
+
+locals {
+  server_bundle = provider::horizon::pkcs12_decode(
+    ephemeral.horizon_retrieve_centralized_pkcs12.server.pkcs12,
+    ephemeral.horizon_retrieve_centralized_pkcs12.server.password,
+  )
+}
+
+resource "some_secret_consumer" "server" {
+  write_only_certificate = local.server_bundle.certificate
+  write_only_private_key = local.server_bundle.private_key
+  write_only_chain       = join("", local.server_bundle.chain)
+}
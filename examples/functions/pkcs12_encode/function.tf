@@ -0,0 +1,35 @@
+# Package a decentralized enrollment (key generated by Terraform) as a
+# PKCS#12 bundle for a Java application.
+resource "tls_private_key" "app" {
+  algorithm = "RSA"
+  rsa_bits  = 2048
+}
+
+resource "tls_cert_request" "app" {
+  private_key_pem = tls_private_key.app.private_key_pem
+
+  subject {
+    common_name = "app.example.com"
+  }
+}
+
+resource "horizon_certificate" "app" {
+  csr     = tls_cert_request.app.cert_request_pem
+  profile = "EnrollmentProfile"
+}
+
+# Issuers only, from the direct issuer up to the root.
+data "horizon_certificate_trust_chain" "app" {
+  certificate_pem = horizon_certificate.app.certificate
+  order           = "issuer_leaf_to_root"
+}
+
+output "app_keystore" {
+  sensitive = true
+  value = provider::horizon::pkcs12_encode(
+    horizon_certificate.app.certificate,
+    tls_private_key.app.private_key_pem,
+    data.horizon_certificate_trust_chain.app.chain_pem,
+    var.keystore_password,
+  )
+}
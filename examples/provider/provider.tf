@@ -22,3 +22,21 @@ provider "horizon" {
   cert     = "----BEGIN CERTIFICATE-----\n...\n----END CERTIFICATE-----\n"
   key      = "----BEGIN RSA PRIVATE KEY-----\n...\n----END RSA PRIVATE KEY-----"
 }
+
+# With metadata applied to every horizon_certificate. Labels, owner, team and
+# contact email set on a resource take precedence over these defaults.
+provider "horizon" {
+  alias = "with-defaults"
+
+  endpoint = "https://horizon.company.com"
+  username = "username"
+  password = "password"
+
+  default_metadata {
+    team          = "platform"
+    contact_email = "platform@company.com"
+    labels = {
+      cost_center = "1234"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# ACME profiles are imported by name.
+terraform import horizon_acme_profile.cert_manager cert-manager
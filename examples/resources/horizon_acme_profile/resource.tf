@@ -0,0 +1,10 @@
+resource "horizon_acme_profile" "cert_manager" {
+  name          = "cert-manager"
+  pki_connector = horizon_ejbca_pki_connector.issuing.name
+  key_types     = ["ec-secp256r1", "rsa-2048"]
+  max_lifetime  = "P90D"
+
+  challenge_types          = ["dns-01"]
+  external_account_binding = true
+  authorization_validity   = "P30D"
+}
@@ -0,0 +1,17 @@
+variable "adcs_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_adcs_pki_connector" "corp" {
+  name     = "adcs-corp"
+  ca       = "CorpIssuingCA"
+  host     = "adcs01.corp.example.com"
+  ca_name  = "Corp Issuing CA"
+  template = "WebServer"
+  username = "CORP\\svc-horizon"
+
+  password            = var.adcs_password
+  credentials_version = 1
+}
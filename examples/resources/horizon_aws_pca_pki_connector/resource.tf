@@ -0,0 +1,8 @@
+# Issues with the credentials of the Horizon host, assuming a role.
+resource "horizon_aws_pca_pki_connector" "private" {
+  name     = "aws-pca"
+  ca       = "AwsPrivateCA"
+  region   = "eu-west-1"
+  ca_arn   = "arn:aws:acm-pca:eu-west-1:123456789012:certificate-authority/11111111-2222-3333-4444-555555555555"
+  role_arn = "arn:aws:iam::123456789012:role/horizon-pca-issuer"
+}
@@ -0,0 +1,2 @@
+# Third-party connectors are imported by name.
+terraform import horizon_azure_key_vault_connector.example my-connector
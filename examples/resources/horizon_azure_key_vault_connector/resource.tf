@@ -0,0 +1,29 @@
+variable "akv_client_secret" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_azure_key_vault_connector" "prod" {
+  name      = "akv-prod"
+  vault_url = "https://example-prod.vault.azure.net"
+  tenant_id = "00000000-0000-0000-0000-000000000000"
+  client_id = "11111111-1111-1111-1111-111111111111"
+
+  # Bump credentials_version when rotating the secret.
+  client_secret       = var.akv_client_secret
+  credentials_version = 1
+}
+
+resource "horizon_certificate" "api" {
+  profile                = "EnrollmentProfile"
+  wait_for_third_parties = [horizon_azure_key_vault_connector.prod.id]
+
+  subject = [
+    {
+      element = "cn.1"
+      type    = "CN"
+      value   = "api.example.com"
+    }
+  ]
+}
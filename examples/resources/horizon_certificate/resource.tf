@@ -4,11 +4,22 @@
 # provider performs an in-place WebRA renew. Terraform sees an in-place
 # update; the resource address stays the same and computed fields
 # (serial, thumbprint, ...) are refreshed from the renewed certificate.
+#
+# rotate_key_on_renew = true: each renewal must come with a fresh key pair;
+# the apply fails if Horizon renews the certificate for the previous key.
+# Changing key_type also renews the certificate, with a new key of that type.
+#
+# include_chain = true: fullchain_pem holds the certificate followed by its
+# intermediates, ready for a TLS server, and chain_pem the trust chain in
+# chain_order.
 resource "horizon_certificate" "example_centralized" {
-  profile          = "EnrollmentProfile"
-  key_type         = "rsa-2048"
-  revoke_on_delete = true
-  renew_before     = 30
+  profile             = "EnrollmentProfile"
+  key_type            = "rsa-2048"
+  revoke_on_delete    = true
+  renew_before        = 30
+  rotate_key_on_renew = true
+  include_chain       = true
+  chain_order         = "leaf_to_root"
 
   subject = [
     {
@@ -32,6 +43,15 @@ resource "horizon_certificate" "example_centralized" {
   wait_for_third_parties = [
     "my-aws-connector"
   ]
+
+  # Enrollment (including third-party publication), renewal, refresh and
+  # revocation are each bounded by their own timeout.
+  timeouts {
+    create = "10m"
+    update = "10m"
+    read   = "1m"
+    delete = "2m"
+  }
 }
 
 # Centralized enrollment with write-only PKCS12 and password
@@ -88,3 +108,17 @@ resource "horizon_certificate" "example_decentralized" {
     }
   ]
 }
+
+# Guard on Horizon's view of the certificate: grading policy results and
+# trigger executions are exposed as computed attributes.
+check "example_centralized_health" {
+  assert {
+    condition     = alltrue([for g in horizon_certificate.example_centralized.grades : contains(["A", "B"], g.grade)])
+    error_message = "The certificate does not meet the grading policy."
+  }
+
+  assert {
+    condition     = length([for t in horizon_certificate.example_centralized.trigger_results : t if t.status == "failure"]) == 0
+    error_message = "A trigger failed on the certificate."
+  }
+}
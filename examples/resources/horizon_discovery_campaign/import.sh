@@ -0,0 +1,2 @@
+# Discovery campaigns are imported by name.
+terraform import horizon_discovery_campaign.datacenter datacenter
@@ -0,0 +1,13 @@
+resource "horizon_discovery_campaign" "datacenter" {
+  name        = "datacenter"
+  description = "Web servers of the datacenter"
+
+  hosts      = ["10.0.0.0/24", "intranet.example.com"]
+  ports      = [443, 8443]
+  exclusions = ["10.0.0.1"]
+
+  # Every day at 2 AM.
+  schedule        = "0 0 2 * * ?"
+  timeout         = "5 seconds"
+  max_concurrency = 50
+}
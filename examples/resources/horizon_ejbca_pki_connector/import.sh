@@ -0,0 +1,2 @@
+# PKI connectors are imported by name. Credentials are not imported.
+terraform import horizon_ejbca_pki_connector.example my-connector
@@ -0,0 +1,22 @@
+variable "ejbca_client_key_pem" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_ejbca_pki_connector" "issuing" {
+  name                = "ejbca-issuing"
+  ca                  = "IssuingCA"
+  url                 = "https://ejbca.example.com/ejbca/ejbca-rest-api"
+  ca_name             = "IssuingCA"
+  certificate_profile = "TLSServer"
+  end_entity_profile  = "TLSServer"
+
+  client_certificate_pem = file("${path.module}/horizon-ra.pem")
+  client_key_pem         = var.ejbca_client_key_pem
+  credentials_version    = 1
+}
+
+output "ejbca_status" {
+  value = horizon_ejbca_pki_connector.issuing.status
+}
@@ -0,0 +1,2 @@
+# EST profiles are imported by name.
+terraform import horizon_est_profile.routers routers
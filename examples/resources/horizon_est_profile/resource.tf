@@ -0,0 +1,9 @@
+resource "horizon_est_profile" "routers" {
+  name          = "routers"
+  pki_connector = horizon_ejbca_pki_connector.issuing.name
+  key_types     = ["rsa-2048"]
+  max_lifetime  = "P1Y"
+
+  authorization_mode = "challenge"
+  challenge_lifetime = "PT1H"
+}
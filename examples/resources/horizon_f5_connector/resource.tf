@@ -0,0 +1,14 @@
+variable "f5_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_f5_connector" "dmz" {
+  name                = "f5-dmz"
+  host                = "bigip-dmz.example.com"
+  partition           = "Web"
+  username            = "horizon"
+  password            = var.f5_password
+  credentials_version = 1
+}
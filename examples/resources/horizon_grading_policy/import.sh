@@ -0,0 +1,2 @@
+# Grading policies are imported by name.
+terraform import horizon_grading_policy.crypto_policy crypto-policy
@@ -0,0 +1,10 @@
+resource "horizon_grading_policy" "crypto_policy" {
+  name        = "crypto-policy"
+  description = "Corporate cryptography policy"
+  scope       = "module equals \"webra\""
+
+  rules = [
+    horizon_grading_rule.min_rsa_key_size.name,
+    horizon_grading_rule.no_sha1.name,
+  ]
+}
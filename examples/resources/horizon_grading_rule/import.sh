@@ -0,0 +1,2 @@
+# Grading rules are imported by name.
+terraform import horizon_grading_rule.min_rsa_key_size min-rsa-key-size
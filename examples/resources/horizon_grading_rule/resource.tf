@@ -0,0 +1,13 @@
+resource "horizon_grading_rule" "min_rsa_key_size" {
+  name        = "min-rsa-key-size"
+  description = "RSA keys are at least 2048 bits long"
+  expression  = "keytype in [\"rsa-512\", \"rsa-1024\"]"
+  severity    = "critical"
+}
+
+resource "horizon_grading_rule" "no_sha1" {
+  name        = "no-sha1"
+  description = "SHA-1 signatures are forbidden"
+  expression  = provider::horizon::hrql({ field = "signingalgorithm", in = ["SHA1WithRSA", "SHA1WithECDSA"] })
+  severity    = "high"
+}
@@ -0,0 +1,13 @@
+variable "intune_client_secret" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_intune_connector" "corporate" {
+  name                = "intune"
+  tenant_id           = "00000000-0000-0000-0000-000000000000"
+  client_id           = "22222222-2222-2222-2222-222222222222"
+  client_secret       = var.intune_client_secret
+  credentials_version = 1
+}
@@ -0,0 +1,2 @@
+# Labels are imported by name.
+terraform import horizon_label.env env
@@ -0,0 +1,36 @@
+resource "horizon_label" "env" {
+  name           = "env"
+  display_name   = "Environment"
+  description    = "Deployment environment of the application using the certificate."
+  allowed_values = ["dev", "staging", "prod"]
+  mandatory      = true
+}
+
+resource "horizon_label" "app" {
+  name  = "app"
+  regex = "[a-z]+(-[a-z]+)*"
+}
+
+# The labels are checked against the definitions above at plan time.
+resource "horizon_certificate" "api" {
+  profile = "EnrollmentProfile"
+
+  subject = [
+    {
+      element = "cn.1"
+      type    = "CN"
+      value   = "api.example.com"
+    }
+  ]
+
+  labels = [
+    {
+      label = horizon_label.env.name
+      value = "prod"
+    },
+    {
+      label = horizon_label.app.name
+      value = "billing-api"
+    }
+  ]
+}
@@ -0,0 +1,2 @@
+# Local accounts are imported by identifier. The password is not imported.
+terraform import horizon_local_account.pipeline web-servers-pipeline
@@ -0,0 +1,14 @@
+variable "pipeline_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_local_account" "pipeline" {
+  identifier = "web-servers-pipeline"
+  name       = "Web servers pipeline"
+  email      = "web-team@example.com"
+
+  password         = var.pipeline_password
+  password_version = 1
+}
@@ -0,0 +1,2 @@
+# Notifications are imported by name.
+terraform import horizon_notification.expiring expiring-web-certificates
@@ -0,0 +1,12 @@
+resource "horizon_notification" "expiring" {
+  name        = "expiring-web-certificates"
+  description = "Warns the web team of expiring certificates"
+  events      = ["expire"]
+  recipients  = ["web-team@example.com"]
+
+  subject = "Certificate {{certificate.subject}} expires soon"
+  body    = "The certificate {{certificate.subject}} expires on {{certificate.notAfter}}."
+
+  days_before_expiration = [30, 7, 1]
+  profiles               = [horizon_webra_profile.web_servers.name]
+}
@@ -0,0 +1,2 @@
+# Roles are imported by name.
+terraform import horizon_role.web_servers_pipeline web-servers-pipeline
@@ -0,0 +1,12 @@
+resource "horizon_role" "web_servers_pipeline" {
+  name        = "web-servers-pipeline"
+  description = "Enrolls and renews the web server certificates"
+
+  permissions = [
+    {
+      scope   = "lifecycle"
+      profile = horizon_webra_profile.web_servers.name
+      actions = ["enroll", "renew", "revoke", "search"]
+    }
+  ]
+}
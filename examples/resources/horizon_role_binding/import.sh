@@ -0,0 +1,3 @@
+# Role bindings are imported by identity provider and principal, or by
+# principal alone when they apply to all identity providers.
+terraform import horizon_role_binding.pipeline local/web-servers-pipeline
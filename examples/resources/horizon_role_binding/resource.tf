@@ -0,0 +1,5 @@
+resource "horizon_role_binding" "pipeline" {
+  principal         = horizon_local_account.pipeline.identifier
+  identity_provider = "local"
+  roles             = [horizon_role.web_servers_pipeline.name]
+}
@@ -0,0 +1,2 @@
+# SCEP profiles are imported by name. The static challenge is not imported.
+terraform import horizon_scep_profile.intune intune
@@ -0,0 +1,15 @@
+variable "scep_challenge" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_scep_profile" "intune" {
+  name          = "intune"
+  pki_connector = horizon_adcs_pki_connector.corp.name
+  max_lifetime  = "P1Y"
+
+  authorization_mode       = "static_challenge"
+  static_challenge         = var.scep_challenge
+  static_challenge_version = 1
+}
@@ -0,0 +1,2 @@
+# PKI connectors are imported by name. Credentials are not imported.
+terraform import horizon_stream_pki_connector.example my-connector
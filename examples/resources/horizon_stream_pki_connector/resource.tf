@@ -0,0 +1,17 @@
+variable "stream_client_key_pem" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_stream_pki_connector" "internal" {
+  name     = "stream-internal"
+  ca       = "InternalIssuingCA"
+  url      = "https://stream.example.com"
+  ca_name  = "InternalIssuingCA"
+  template = "TLSServer"
+
+  client_certificate_pem = file("${path.module}/horizon-ra.pem")
+  client_key_pem         = var.stream_client_key_pem
+  credentials_version    = 1
+}
@@ -0,0 +1,2 @@
+# Teams are imported by name.
+terraform import horizon_team.payments payments
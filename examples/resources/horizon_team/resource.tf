@@ -0,0 +1,20 @@
+resource "horizon_team" "payments" {
+  name          = "payments"
+  display_name  = "Payments"
+  contact_email = "payments-ops@example.com"
+  managers      = ["alice"]
+  members       = ["alice", "bob", "carol"]
+}
+
+resource "horizon_certificate" "checkout" {
+  profile = "EnrollmentProfile"
+  team    = horizon_team.payments.name
+
+  subject = [
+    {
+      element = "cn.1"
+      type    = "CN"
+      value   = "checkout.example.com"
+    }
+  ]
+}
@@ -0,0 +1,2 @@
+# Triggers are imported by name.
+terraform import horizon_trigger.inventory inventory
@@ -0,0 +1,13 @@
+resource "horizon_trigger" "inventory" {
+  name   = "inventory"
+  events = ["enroll", "renew", "revoke"]
+  url    = "https://inventory.example.com/hooks/horizon"
+
+  headers = {
+    "X-Source" = "horizon"
+  }
+  body    = "{\"serial\": \"{{certificate.serial}}\", \"event\": \"{{event}}\"}"
+  retries = 3
+
+  profiles = [horizon_webra_profile.web_servers.name]
+}
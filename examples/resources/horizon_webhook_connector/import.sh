@@ -0,0 +1,2 @@
+# Third-party connectors are imported by name.
+terraform import horizon_webhook_connector.example my-connector
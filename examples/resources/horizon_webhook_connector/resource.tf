@@ -0,0 +1,13 @@
+variable "webhook_token" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "horizon_webhook_connector" "inventory" {
+  name                = "inventory"
+  description         = "Pushes issued certificates to the CMDB."
+  url                 = "https://cmdb.example.com/api/certificates"
+  authorization       = "Bearer ${var.webhook_token}"
+  credentials_version = 1
+}
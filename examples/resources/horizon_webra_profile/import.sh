@@ -0,0 +1,2 @@
+# WebRA profiles are imported by name.
+terraform import horizon_webra_profile.web_servers WebServers
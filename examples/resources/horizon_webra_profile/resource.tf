@@ -0,0 +1,49 @@
+# Profile for web server certificates: DNS names under example.com, keys
+# generated by Horizon and escrowed, one year at most, revocations approved
+# by an operator.
+resource "horizon_webra_profile" "web_servers" {
+  name          = "WebServers"
+  pki_connector = "internal-issuing-ca"
+
+  key_types     = ["rsa-2048", "ec-secp256r1"]
+  centralized   = true
+  decentralized = true
+  escrow        = true
+
+  subject = [
+    {
+      type      = "CN"
+      mandatory = true
+      regex     = "^[a-z0-9.-]+\\.example\\.com$"
+    },
+    {
+      type                  = "O"
+      editable_by_requester = false
+      default_value         = "Example"
+    }
+  ]
+
+  sans = [
+    {
+      type  = "DNSNAME"
+      min   = 1
+      max   = 10
+      regex = "^[a-z0-9.-]+\\.example\\.com$"
+    }
+  ]
+
+  max_lifetime      = "365 days"
+  approval_required = ["revoke"]
+}
+
+resource "horizon_certificate" "www" {
+  profile = horizon_webra_profile.web_servers.name
+
+  subject = [
+    {
+      element = "cn.1"
+      type    = "CN"
+      value   = "www.example.com"
+    }
+  ]
+}
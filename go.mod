@@ -3,6 +3,13 @@ module github.com/evertrust/terraform-provider-horizon
 go 1.25.8
 
 require (
+	// NOTE: besides CertificateAPI, RequestAPI and Rfc5280TcFile, the provider
+	// uses the WebRAProfileAPI, LabelAPI, TeamAPI, ThirdPartyConnectorAPI,
+	// RoleAPI, AuthorizationAPI, PkiConnectorAPI, Rfc5280Validation and
+	// CertificateSearch clients of horizon-go. Their signatures have not been
+	// checked against v2.9.2-1, which the module proxy did not serve when they
+	// were added: confirm that this version ships them, or bump to the first
+	// release that does, before releasing.
 	github.com/evertrust/horizon-go/v2 v2.9.2-1
 	github.com/hashicorp/terraform-plugin-docs v0.25.0
 	github.com/hashicorp/terraform-plugin-framework v1.19.0
@@ -13,7 +20,9 @@ require (
 	github.com/hashicorp/terraform-plugin-testing v1.15.0
 	github.com/stretchr/testify v1.11.1
 	github.com/testcontainers/testcontainers-go v0.42.0
+	golang.org/x/crypto v0.51.0
 	golang.org/x/sync v0.20.0
+	software.sslmate.com/src/go-pkcs12 v0.7.3
 )
 
 require (
@@ -119,7 +128,6 @@ require (
 	go.opentelemetry.io/otel v1.41.0 // indirect
 	go.opentelemetry.io/otel/metric v1.41.0 // indirect
 	go.opentelemetry.io/otel/trace v1.41.0 // indirect
-	golang.org/x/crypto v0.51.0 // indirect
 	golang.org/x/exp v0.0.0-20230809150735-7b3493d9a819 // indirect
 	golang.org/x/mod v0.35.0 // indirect
 	golang.org/x/net v0.53.0 // indirect
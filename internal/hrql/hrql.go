@@ -0,0 +1,207 @@
+// Package hrql builds Horizon query language (HRQL) expressions.
+//
+// Values are always rendered as quoted, escaped string literals and field
+// names are validated, so that user input can never change the structure of
+// a query.
+package hrql
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Expr is an HRQL expression. Build renders it.
+type Expr interface {
+	build(b *strings.Builder) error
+}
+
+var fieldPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// ValidateField reports whether name can be used as an HRQL field name.
+func ValidateField(name string) error {
+	if !fieldPattern.MatchString(name) {
+		return fmt.Errorf("invalid HRQL field name %q: field names contain letters, digits, underscores and dots, and do not start with a digit", name)
+	}
+	return nil
+}
+
+// Quote renders s as an HRQL string literal.
+func Quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// Build renders e as an HRQL query.
+func Build(e Expr) (string, error) {
+	if e == nil {
+		return "", errors.New("empty HRQL expression")
+	}
+	var b strings.Builder
+	if err := e.build(&b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+type comparison struct {
+	field    string
+	operator string
+	value    string
+}
+
+func (c comparison) build(b *strings.Builder) error {
+	if err := ValidateField(c.field); err != nil {
+		return err
+	}
+	fmt.Fprintf(b, "%s %s %s", c.field, c.operator, c.value)
+	return nil
+}
+
+// Equals matches field values equal to value.
+func Equals(field, value string) Expr {
+	return comparison{field: field, operator: "equals", value: Quote(value)}
+}
+
+// NotEquals matches field values different from value.
+func NotEquals(field, value string) Expr {
+	return comparison{field: field, operator: "not equals", value: Quote(value)}
+}
+
+// Contains matches field values containing value.
+func Contains(field, value string) Expr {
+	return comparison{field: field, operator: "contains", value: Quote(value)}
+}
+
+// Before matches dates strictly before t. t is rendered as a quoted RFC 3339
+// timestamp in UTC, such as "2025-01-31T00:00:00Z", which Horizon compares as
+// a date.
+func Before(field string, t time.Time) Expr {
+	return comparison{field: field, operator: "before", value: Quote(t.UTC().Format(time.RFC3339))}
+}
+
+// After matches dates strictly after t, rendered as in Before.
+func After(field string, t time.Time) Expr {
+	return comparison{field: field, operator: "after", value: Quote(t.UTC().Format(time.RFC3339))}
+}
+
+type in struct {
+	field  string
+	values []string
+}
+
+// In matches field values equal to one of values.
+func In(field string, values ...string) Expr {
+	return in{field: field, values: values}
+}
+
+func (i in) build(b *strings.Builder) error {
+	if err := ValidateField(i.field); err != nil {
+		return err
+	}
+	if len(i.values) == 0 {
+		return fmt.Errorf("HRQL %q in: at least one value is required", i.field)
+	}
+	quoted := make([]string, 0, len(i.values))
+	for _, v := range i.values {
+		quoted = append(quoted, Quote(v))
+	}
+	fmt.Fprintf(b, "%s in [%s]", i.field, strings.Join(quoted, ", "))
+	return nil
+}
+
+type junction struct {
+	operator string
+	exprs    []Expr
+}
+
+// And matches when every expression matches.
+func And(exprs ...Expr) Expr {
+	return junction{operator: "and", exprs: exprs}
+}
+
+// Or matches when at least one expression matches.
+func Or(exprs ...Expr) Expr {
+	return junction{operator: "or", exprs: exprs}
+}
+
+func (j junction) build(b *strings.Builder) error {
+	if len(j.exprs) == 0 {
+		return fmt.Errorf("HRQL %s: at least one expression is required", j.operator)
+	}
+	if len(j.exprs) == 1 {
+		return j.exprs[0].build(b)
+	}
+	for i, e := range j.exprs {
+		if i > 0 {
+			fmt.Fprintf(b, " %s ", j.operator)
+		}
+		if err := buildOperand(b, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type not struct {
+	expr Expr
+}
+
+// Not matches when expr does not match.
+func Not(expr Expr) Expr {
+	return not{expr: expr}
+}
+
+func (n not) build(b *strings.Builder) error {
+	if n.expr == nil {
+		return errors.New("HRQL not: an expression is required")
+	}
+	b.WriteString("not ")
+	return buildOperand(b, n.expr)
+}
+
+// buildOperand renders e, wrapped in parentheses when it is a compound
+// expression, so that operator precedence never depends on the context.
+func buildOperand(b *strings.Builder, e Expr) error {
+	if e == nil {
+		return errors.New("empty HRQL expression")
+	}
+	switch e := e.(type) {
+	case junction:
+		if len(e.exprs) > 1 {
+			b.WriteByte('(')
+			if err := e.build(b); err != nil {
+				return err
+			}
+			b.WriteByte(')')
+			return nil
+		}
+	case not:
+		b.WriteByte('(')
+		if err := e.build(b); err != nil {
+			return err
+		}
+		b.WriteByte(')')
+		return nil
+	}
+	return e.build(b)
+}
@@ -0,0 +1,87 @@
+package hrql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuild(t *testing.T) {
+	date := time.Date(2025, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+
+	tests := []struct {
+		name string
+		expr Expr
+		want string
+	}{
+		{name: "equals", expr: Equals("status", "valid"), want: `status equals "valid"`},
+		{name: "not equals", expr: NotEquals("status", "revoked"), want: `status not equals "revoked"`},
+		{name: "contains", expr: Contains("dn", "example"), want: `dn contains "example"`},
+		{name: "in", expr: In("module", "webra", "est"), want: `module in ["webra", "est"]`},
+		{name: "before is rendered in UTC", expr: Before("notafter", date), want: `notafter before "2025-03-01T11:00:00Z"`},
+		{name: "after", expr: After("notbefore", date), want: `notbefore after "2025-03-01T11:00:00Z"`},
+		{
+			name: "and",
+			expr: And(Equals("holderid", "h1"), Equals("workflow", "enroll")),
+			want: `holderid equals "h1" and workflow equals "enroll"`,
+		},
+		{
+			name: "nested junctions are parenthesized",
+			expr: And(Equals("status", "valid"), Or(Equals("profile", "a"), Equals("profile", "b"))),
+			want: `status equals "valid" and (profile equals "a" or profile equals "b")`,
+		},
+		{
+			name: "single-element junction is not parenthesized",
+			expr: And(Or(Equals("profile", "a"))),
+			want: `profile equals "a"`,
+		},
+		{
+			name: "not",
+			expr: Not(Or(Equals("status", "revoked"), Equals("status", "expired"))),
+			want: `not (status equals "revoked" or status equals "expired")`,
+		},
+		{
+			name: "not inside and",
+			expr: And(Equals("status", "valid"), Not(Contains("dn", "test"))),
+			want: `status equals "valid" and (not dn contains "test")`,
+		},
+		{
+			name: "values are escaped",
+			expr: Equals("dn", `CN="x" and status equals "valid"\`),
+			want: `dn equals "CN=\"x\" and status equals \"valid\"\\"`,
+		},
+		{name: "control characters are escaped", expr: Equals("dn", "a\nb\tc"), want: `dn equals "a\nb\tc"`},
+		{name: "dotted field", expr: Equals("labels.env", "prod"), want: `labels.env equals "prod"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Build(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Build() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuild_Errors(t *testing.T) {
+	tests := map[string]Expr{
+		"nil expression":      nil,
+		"injected field name": Equals(`status equals "valid" or dn`, "x"),
+		"field with space":    Equals("not after", "x"),
+		"field with digit":    Equals("1field", "x"),
+		"empty in":            In("module"),
+		"empty and":           And(),
+		"empty or":            Or(),
+		"not without operand": Not(nil),
+		"nested error":        And(Equals("status", "valid"), Equals("", "x")),
+	}
+	for name, expr := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got, err := Build(expr); err == nil {
+				t.Fatalf("expected an error, got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// acmeChallengeTypes are the ACME challenges a profile may accept.
+var acmeChallengeTypes = []string{"http-01", "dns-01", "tls-alpn-01"}
+
+func NewAcmeProfileResource() resource.Resource {
+	return &AcmeProfileResource{}
+}
+
+// AcmeProfileResource manages an ACME enrollment profile.
+type AcmeProfileResource struct {
+	client *horizon.APIClient
+}
+
+type acmeProfileResourceModel struct {
+	Id                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	PkiConnector           types.String `tfsdk:"pki_connector"`
+	KeyTypes               types.Set    `tfsdk:"key_types"`
+	MaxLifetime            types.String `tfsdk:"max_lifetime"`
+	ChallengeTypes         types.Set    `tfsdk:"challenge_types"`
+	ExternalAccountBinding types.Bool   `tfsdk:"external_account_binding"`
+	AuthorizationValidity  types.String `tfsdk:"authorization_validity"`
+}
+
+func (r *AcmeProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_acme_profile"
+}
+
+func (r *AcmeProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an ACME profile, the directory ACME clients such as cert-manager or certbot enroll on, at `/acme/<name>/directory`. " +
+			"ACME accounts are registered by the clients themselves.",
+		Attributes: enrollmentProfileAttributes("ACME"),
+	}
+
+	resp.Schema.Attributes["challenge_types"] = schema.SetAttribute{
+		Optional:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Challenges clients may complete to prove they control their identifiers, among `http-01`, `dns-01` and `tls-alpn-01`. All are accepted when unset or empty.",
+	}
+	resp.Schema.Attributes["external_account_binding"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+		MarkdownDescription: "Whether ACME accounts must be bound to a Horizon account with external account binding (EAB) credentials. Defaults to false.",
+	}
+	resp.Schema.Attributes["authorization_validity"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "How long a completed challenge authorizes new orders for the same identifier, as a duration such as `30 days` or `P30D`. Every order requires a challenge when unset.",
+	}
+}
+
+func (r *AcmeProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *AcmeProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data acmeProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, diags := acmeProfileFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating ACME profile %s", profile.Name))
+	created, _, err := r.client.AcmeProfileAPI.AcmeProfileCreate(ctx).AcmeProfile(*profile).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create ACME profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillAcmeProfileModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AcmeProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data acmeProfileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, httpResp, err := r.client.AcmeProfileAPI.AcmeProfileGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("ACME profile %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get ACME profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillAcmeProfileModel(ctx, &data, profile)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AcmeProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data acmeProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, diags := acmeProfileFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating ACME profile %s", profile.Name))
+	updated, _, err := r.client.AcmeProfileAPI.AcmeProfileUpdate(ctx).AcmeProfile(*profile).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update ACME profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillAcmeProfileModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AcmeProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data acmeProfileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.AcmeProfileAPI.AcmeProfileDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete ACME profile", err.Error())
+	}
+}
+
+func (r *AcmeProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *AcmeProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data acmeProfileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateAcmeProfile(ctx, data)...)
+}
+
+// validateAcmeProfile checks the known values of an ACME profile configuration.
+func validateAcmeProfile(ctx context.Context, data acmeProfileResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(validateDuration("max_lifetime", data.MaxLifetime)...)
+	diags.Append(validateDuration("authorization_validity", data.AuthorizationValidity)...)
+
+	if data.ChallengeTypes.IsNull() || data.ChallengeTypes.IsUnknown() {
+		return diags
+	}
+	var challengeTypes []types.String
+	diags.Append(data.ChallengeTypes.ElementsAs(ctx, &challengeTypes, false)...)
+	for _, challengeType := range challengeTypes {
+		if challengeType.IsUnknown() || containsString(acmeChallengeTypes, challengeType.ValueString()) {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root("challenge_types"),
+			"Invalid challenge_types value",
+			fmt.Sprintf("%q is not an ACME challenge type. Expected one of %s.", challengeType.ValueString(), strings.Join(acmeChallengeTypes, ", ")),
+		)
+	}
+	return diags
+}
+
+func acmeProfileFromModel(ctx context.Context, data acmeProfileResourceModel) (*models.AcmeProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	profile := models.NewAcmeProfileWithDefaults()
+	profile.Name = data.Name.ValueString()
+	profile.Enabled = data.Enabled.ValueBoolPointer()
+	profile.PkiConnector = data.PkiConnector.ValueStringPointer()
+	profile.MaxCertificateLifetime = horizonDuration(data.MaxLifetime)
+	profile.ExternalAccountBinding = data.ExternalAccountBinding.ValueBoolPointer()
+	profile.AuthorizationValidity = horizonDuration(data.AuthorizationValidity)
+	keyTypes, d := authorizedKeyTypes(ctx, data.KeyTypes)
+	diags.Append(d...)
+	if keyTypes != nil {
+		profile.CryptoPolicy = &models.ProfileCryptoPolicy{AuthorizedKeyTypes: keyTypes}
+	}
+	if !data.ChallengeTypes.IsNull() {
+		diags.Append(data.ChallengeTypes.ElementsAs(ctx, &profile.ChallengeTypes, false)...)
+	}
+	return profile, diags
+}
+
+func fillAcmeProfileModel(ctx context.Context, data *acmeProfileResourceModel, profile *models.AcmeProfile) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	data.Id = types.StringValue(profile.Name)
+	data.Name = types.StringValue(profile.Name)
+	data.Enabled = types.BoolValue(profile.Enabled == nil || *profile.Enabled)
+	data.PkiConnector = types.StringPointerValue(profile.PkiConnector)
+	data.MaxLifetime = durationValue(data.MaxLifetime, profile.MaxCertificateLifetime)
+	data.ExternalAccountBinding = types.BoolValue(profile.ExternalAccountBinding != nil && *profile.ExternalAccountBinding)
+	data.AuthorizationValidity = durationValue(data.AuthorizationValidity, profile.AuthorizationValidity)
+
+	data.KeyTypes, d = keyTypesValue(ctx, profile.CryptoPolicy, data.KeyTypes)
+	diags.Append(d...)
+	data.ChallengeTypes = emptySetValue(data.ChallengeTypes, types.StringType)
+	if len(profile.ChallengeTypes) > 0 {
+		data.ChallengeTypes, d = types.SetValueFrom(ctx, types.StringType, profile.ChallengeTypes)
+		diags.Append(d...)
+	}
+	return diags
+}
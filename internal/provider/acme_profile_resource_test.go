@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillAcmeProfileModel(t *testing.T) {
+	ctx := context.Background()
+	emptySet := types.SetValueMust(types.StringType, nil)
+	dns := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("dns-01")})
+	configured := func(maxLifetime types.String, challengeTypes types.Set) func(t *testing.T) acmeProfileResourceModel {
+		return func(t *testing.T) acmeProfileResourceModel {
+			return acmeProfileResourceModel{
+				Name:                  types.StringValue("cert-manager"),
+				KeyTypes:              types.SetNull(types.StringType),
+				MaxLifetime:           maxLifetime,
+				ChallengeTypes:        challengeTypes,
+				AuthorizationValidity: types.StringValue("P30D"),
+			}
+		}
+	}
+	imported := func(t *testing.T) acmeProfileResourceModel {
+		var data acmeProfileResourceModel
+		if diags := importedState(t, NewAcmeProfileResource(), "cert-manager").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+	stored := func(maxLifetime string, challengeTypes ...string) *models.AcmeProfile {
+		return &models.AcmeProfile{
+			Name:                   "cert-manager",
+			PkiConnector:           strPtr("ejbca-issuing"),
+			MaxCertificateLifetime: strPtr(maxLifetime),
+			ChallengeTypes:         challengeTypes,
+			AuthorizationValidity:  strPtr("30 days"),
+		}
+	}
+
+	tests := []struct {
+		name                      string
+		prior                     func(t *testing.T) acmeProfileResourceModel
+		profile                   *models.AcmeProfile
+		wantMaxLifetime           types.String
+		wantAuthorizationValidity types.String
+		wantChallengeTypes        types.Set
+	}{
+		{
+			// Horizon stores durations in its own notation.
+			name:                      "duration notation is kept",
+			prior:                     configured(types.StringValue("P90D"), dns),
+			profile:                   stored("90 days", "dns-01"),
+			wantMaxLifetime:           types.StringValue("P90D"),
+			wantAuthorizationValidity: types.StringValue("P30D"),
+			wantChallengeTypes:        dns,
+		},
+		{
+			name:                      "duration changed outside of Terraform",
+			prior:                     configured(types.StringValue("P90D"), dns),
+			profile:                   stored("60 days", "dns-01"),
+			wantMaxLifetime:           types.StringValue("60 days"),
+			wantAuthorizationValidity: types.StringValue("P30D"),
+			wantChallengeTypes:        dns,
+		},
+		{
+			// Omitting challenge_types does not plan a change.
+			name:                      "unset challenge types read back as null",
+			prior:                     configured(types.StringValue("P90D"), types.SetNull(types.StringType)),
+			profile:                   stored("90 days"),
+			wantMaxLifetime:           types.StringValue("P90D"),
+			wantAuthorizationValidity: types.StringValue("P30D"),
+			wantChallengeTypes:        types.SetNull(types.StringType),
+		},
+		{
+			// Neither does configuring them as empty.
+			name:                      "empty challenge types read back as empty",
+			prior:                     configured(types.StringValue("P90D"), emptySet),
+			profile:                   stored("90 days"),
+			wantMaxLifetime:           types.StringValue("P90D"),
+			wantAuthorizationValidity: types.StringValue("P30D"),
+			wantChallengeTypes:        emptySet,
+		},
+		{
+			name:                      "challenge types emptied outside of Terraform read back as null",
+			prior:                     configured(types.StringValue("P90D"), dns),
+			profile:                   stored("90 days"),
+			wantMaxLifetime:           types.StringValue("P90D"),
+			wantAuthorizationValidity: types.StringValue("P30D"),
+			wantChallengeTypes:        types.SetNull(types.StringType),
+		},
+		{
+			name:                      "imported by name",
+			prior:                     imported,
+			profile:                   stored("90 days", "dns-01"),
+			wantMaxLifetime:           types.StringValue("90 days"),
+			wantAuthorizationValidity: types.StringValue("30 days"),
+			wantChallengeTypes:        dns,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.profile.Name {
+				t.Fatalf("Read looks up the profile %q, want %q", data.Name.ValueString(), tt.profile.Name)
+			}
+			if diags := fillAcmeProfileModel(ctx, &data, tt.profile); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "cert-manager" || !data.Enabled.ValueBool() || data.PkiConnector.ValueString() != "ejbca-issuing" || !data.KeyTypes.IsNull() {
+				t.Errorf("id %s, enabled %s, pki_connector %s, key_types %s", data.Id, data.Enabled, data.PkiConnector, data.KeyTypes)
+			}
+			if !data.MaxLifetime.Equal(tt.wantMaxLifetime) {
+				t.Errorf("max_lifetime = %s, want %s", data.MaxLifetime, tt.wantMaxLifetime)
+			}
+			if !data.AuthorizationValidity.Equal(tt.wantAuthorizationValidity) {
+				t.Errorf("authorization_validity = %s, want %s", data.AuthorizationValidity, tt.wantAuthorizationValidity)
+			}
+			if !data.ChallengeTypes.Equal(tt.wantChallengeTypes) {
+				t.Errorf("challenge_types = %s, want %s", data.ChallengeTypes, tt.wantChallengeTypes)
+			}
+		})
+	}
+}
+
+func TestAcmeProfileFromModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name               string
+		challengeTypes     types.Set
+		wantChallengeTypes []string
+	}{
+		{name: "null challenge types are not sent", challengeTypes: types.SetNull(types.StringType)},
+		{name: "empty challenge types are not sent", challengeTypes: types.SetValueMust(types.StringType, nil)},
+		{
+			name:               "challenge types are sent",
+			challengeTypes:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("http-01")}),
+			wantChallengeTypes: []string{"http-01"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, diags := acmeProfileFromModel(ctx, acmeProfileResourceModel{
+				Name:                   types.StringValue("cert-manager"),
+				Enabled:                types.BoolValue(true),
+				PkiConnector:           types.StringValue("ejbca-issuing"),
+				KeyTypes:               types.SetNull(types.StringType),
+				MaxLifetime:            types.StringValue("P90D"),
+				ChallengeTypes:         tt.challengeTypes,
+				ExternalAccountBinding: types.BoolValue(false),
+				AuthorizationValidity:  types.StringNull(),
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			// Durations are sent in the notation of Horizon.
+			if profile.MaxCertificateLifetime == nil || *profile.MaxCertificateLifetime != "90 days" || profile.AuthorizationValidity != nil {
+				t.Errorf("max lifetime %v, authorization validity %v", profile.MaxCertificateLifetime, profile.AuthorizationValidity)
+			}
+			if profile.CryptoPolicy != nil {
+				t.Errorf("unexpected crypto policy %+v", profile.CryptoPolicy)
+			}
+			if !slices.Equal(profile.ChallengeTypes, tt.wantChallengeTypes) {
+				t.Errorf("challenge types = %v, want %v", profile.ChallengeTypes, tt.wantChallengeTypes)
+			}
+		})
+	}
+}
+
+func TestValidateAcmeProfile(t *testing.T) {
+	ctx := context.Background()
+	base := func() acmeProfileResourceModel {
+		return acmeProfileResourceModel{
+			Name:                  types.StringValue("p"),
+			MaxLifetime:           types.StringNull(),
+			ChallengeTypes:        types.SetNull(types.StringType),
+			AuthorizationValidity: types.StringNull(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*acmeProfileResourceModel)
+		wantErr bool
+	}{
+		{name: "defaults", mutate: func(d *acmeProfileResourceModel) {}},
+		{name: "challenge types", mutate: func(d *acmeProfileResourceModel) {
+			d.ChallengeTypes = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("http-01"), types.StringValue("dns-01")})
+		}},
+		{name: "unknown challenge type", wantErr: true, mutate: func(d *acmeProfileResourceModel) {
+			d.ChallengeTypes = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("dns-00")})
+		}},
+		{name: "invalid authorization validity", wantErr: true, mutate: func(d *acmeProfileResourceModel) {
+			d.AuthorizationValidity = types.StringValue("30 dayz")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base()
+			tt.mutate(&data)
+			if diags := validateAcmeProfile(ctx, data); diags.HasError() != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
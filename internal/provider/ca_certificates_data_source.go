@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewCaCertificatesDataSource() datasource.DataSource {
+	return &CaCertificatesDataSource{}
+}
+
+type CaCertificatesDataSource struct {
+	client *horizon.APIClient
+}
+
+type caCertificatesDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Profile      types.String `tfsdk:"profile"`
+	Certificates types.List   `tfsdk:"certificates"`
+	BundlePem    types.String `tfsdk:"bundle_pem"`
+}
+
+var caCertificateObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":        types.StringType,
+	"pem":         types.StringType,
+	"dn":          types.StringType,
+	"issuer":      types.StringType,
+	"serial":      types.StringType,
+	"not_before":  types.Int64Type,
+	"not_after":   types.Int64Type,
+	"self_signed": types.BoolType,
+	"thumbprint":  types.StringType,
+}}
+
+func (d *CaCertificatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ca_certificates"
+}
+
+func (d *CaCertificatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the certification authorities known to Horizon, to build trust stores for the clients of the certificates it issues.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 (hex) of `bundle_pem`.",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the CA with this name. The read fails if there is none.",
+			},
+			"profile": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the CA issuing the certificates of this WebRA profile, through the profile PKI connector. The read fails if Horizon does not know it.",
+			},
+			"certificates": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "CA certificates, sorted by CA name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the CA in Horizon.",
+						},
+						"pem": schema.StringAttribute{
+							Computed:    true,
+							Description: "PEM-encoded CA certificate.",
+						},
+						"dn": schema.StringAttribute{
+							Computed:    true,
+							Description: "DN of the CA certificate.",
+						},
+						"issuer": schema.StringAttribute{
+							Computed:    true,
+							Description: "Issuer DN of the CA certificate.",
+						},
+						"serial": schema.StringAttribute{
+							Computed:    true,
+							Description: "Serial number of the CA certificate, in lowercase hexadecimal.",
+						},
+						"not_before": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Start of the validity period of the CA certificate, in milliseconds since the epoch.",
+						},
+						"not_after": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Expiration date of the CA certificate, in milliseconds since the epoch.",
+						},
+						"self_signed": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the CA certificate is self-signed, i.e. a root.",
+						},
+						"thumbprint": schema.StringAttribute{
+							Computed:    true,
+							Description: "SHA-1 thumbprint of the CA certificate, in lowercase hexadecimal.",
+						},
+					},
+				},
+			},
+			"bundle_pem": schema.StringAttribute{
+				Computed:    true,
+				Description: "Concatenated PEM bundle of the CA certificates, in the order of `certificates`.",
+			},
+		},
+	}
+}
+
+func (d *CaCertificatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.client
+}
+
+func (d *CaCertificatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data caCertificatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	var profileCa string
+	if !data.Name.IsNull() {
+		names = append(names, data.Name.ValueString())
+	}
+	if !data.Profile.IsNull() {
+		caName, diags := d.profileCa(ctx, data.Profile.ValueString())
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		profileCa = caName
+		names = append(names, caName)
+	}
+
+	cas, _, err := d.client.CaAPI.CaGetAll(ctx).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list CAs", err.Error())
+		return
+	}
+
+	certificates, diags := selectCaCertificates(cas, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(names) > 0 && len(certificates) == 0 {
+		resp.Diagnostics.AddError("CA not found", caNotFoundDetail(data, profileCa))
+		return
+	}
+
+	var bundle strings.Builder
+	elements := make([]attr.Value, 0, len(certificates))
+	for _, c := range certificates {
+		pem := encodeCertificatePEM(c.cert)
+		bundle.WriteString(pem)
+		thumbprint := sha1.Sum(c.cert.Raw)
+		elements = append(elements, types.ObjectValueMust(caCertificateObjectType.AttrTypes, map[string]attr.Value{
+			"name":        types.StringValue(c.name),
+			"pem":         types.StringValue(pem),
+			"dn":          types.StringValue(c.cert.Subject.String()),
+			"issuer":      types.StringValue(c.cert.Issuer.String()),
+			"serial":      types.StringValue(fmt.Sprintf("%x", c.cert.SerialNumber)),
+			"not_before":  types.Int64Value(c.cert.NotBefore.UnixMilli()),
+			"not_after":   types.Int64Value(c.cert.NotAfter.UnixMilli()),
+			"self_signed": types.BoolValue(isSelfSigned(c.cert)),
+			"thumbprint":  types.StringValue(hex.EncodeToString(thumbprint[:])),
+		}))
+	}
+
+	sum := sha256.Sum256([]byte(bundle.String()))
+	data.Certificates = types.ListValueMust(caCertificateObjectType, elements)
+	data.BundlePem = types.StringValue(bundle.String())
+	data.Id = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// profileCa returns the name of the CA issuing the certificates of a WebRA
+// profile, as configured on its PKI connector.
+func (d *CaCertificatesDataSource) profileCa(ctx context.Context, profile string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	webraProfile, httpResp, err := d.client.WebRAProfileAPI.WebRAProfileGet(ctx, profile).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			diags.AddAttributeError(path.Root("profile"), "Profile not found", fmt.Sprintf("Horizon has no WebRA profile named %q.", profile))
+			return "", diags
+		}
+		diags.AddError("Failed to get profile", err.Error())
+		return "", diags
+	}
+	connector := webraProfile.GetPkiConnector()
+	if connector == "" {
+		diags.AddAttributeError(path.Root("profile"), "Profile without PKI connector", fmt.Sprintf("WebRA profile %q has no PKI connector, so no issuing CA.", profile))
+		return "", diags
+	}
+
+	pkiConnector, _, err := d.client.PkiConnectorAPI.PkiConnectorGet(ctx, connector).Execute()
+	if err != nil {
+		diags.AddError("Failed to get PKI connector", err.Error())
+		return "", diags
+	}
+	if pkiConnector.GetCa() == "" {
+		diags.AddAttributeError(path.Root("profile"), "Issuing CA unknown", fmt.Sprintf("PKI connector %q of WebRA profile %q does not reference a CA.", connector, profile))
+		return "", diags
+	}
+	return pkiConnector.GetCa(), diags
+}
+
+// caNotFoundDetail lists every filter of data, so that a name contradicting
+// the CA of the profile is visible in the error.
+func caNotFoundDetail(data caCertificatesDataSourceModel, profileCa string) string {
+	var filters []string
+	if !data.Name.IsNull() {
+		filters = append(filters, fmt.Sprintf("name %q", data.Name.ValueString()))
+	}
+	if !data.Profile.IsNull() {
+		filters = append(filters, fmt.Sprintf("profile %q (issuing CA %q)", data.Profile.ValueString(), profileCa))
+	}
+	return fmt.Sprintf("Horizon has no CA with a valid certificate matching %s.", strings.Join(filters, " and "))
+}
+
+type caCertificate struct {
+	name string
+	cert *x509.Certificate
+}
+
+// selectCaCertificates returns the certificates of the CAs whose name is every
+// one of names, sorted by name. CAs without a valid certificate are skipped
+// with a warning.
+func selectCaCertificates(cas []models.Ca, names []string) ([]caCertificate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	certificates := make([]caCertificate, 0, len(cas))
+	for _, ca := range cas {
+		matches := true
+		for _, name := range names {
+			matches = matches && ca.GetName() == name
+		}
+		if !matches {
+			continue
+		}
+		certs, err := decodePEMCertificates(ca.GetCertificate())
+		if err != nil {
+			diags.AddWarning("Skipping CA with an invalid certificate", fmt.Sprintf("CA %q: %s", ca.GetName(), err))
+			continue
+		}
+		certificates = append(certificates, caCertificate{name: ca.GetName(), cert: certs[0]})
+	}
+	sort.SliceStable(certificates, func(i, j int) bool { return certificates[i].name < certificates[j].name })
+	return certificates, diags
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &CaCertificatesDataSource{}
+	_ datasource.DataSourceWithConfigure = &CaCertificatesDataSource{}
+)
+
+func TestSelectCaCertificates(t *testing.T) {
+	root := newTestCA(t, "Root CA", nil)
+	issuing := newTestCA(t, "Issuing CA", root)
+	cas := []models.Ca{
+		{Name: "root", Certificate: root.pem},
+		{Name: "broken", Certificate: "not a certificate"},
+		{Name: "issuing", Certificate: issuing.pem},
+	}
+
+	t.Run("all", func(t *testing.T) {
+		got, diags := selectCaCertificates(cas, nil)
+		if diags.HasError() || diags.WarningsCount() != 1 {
+			t.Fatalf("want one warning for the broken CA, got %v", diags)
+		}
+		if len(got) != 2 || got[0].name != "issuing" || got[1].name != "root" {
+			t.Fatalf("got %v, want issuing and root sorted by name", got)
+		}
+		if !got[1].cert.Equal(root.cert) {
+			t.Fatal("root certificate does not match")
+		}
+	})
+
+	t.Run("by name", func(t *testing.T) {
+		got, diags := selectCaCertificates(cas, []string{"issuing"})
+		if diags.HasError() || len(diags) != 0 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if len(got) != 1 || !got[0].cert.Equal(issuing.cert) {
+			t.Fatalf("got %v, want the issuing CA", got)
+		}
+	})
+
+	t.Run("name and profile CA disagree", func(t *testing.T) {
+		if got, _ := selectCaCertificates(cas, []string{"issuing", "root"}); len(got) != 0 {
+			t.Fatalf("got %v, want no CA", got)
+		}
+	})
+}
+
+func TestCaNotFoundDetail(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      caCertificatesDataSourceModel
+		profileCa string
+		want      string
+	}{
+		{
+			name: "name",
+			data: caCertificatesDataSourceModel{Name: types.StringValue("issuing"), Profile: types.StringNull()},
+			want: `Horizon has no CA with a valid certificate matching name "issuing".`,
+		},
+		{
+			name:      "profile",
+			data:      caCertificatesDataSourceModel{Name: types.StringNull(), Profile: types.StringValue("servers")},
+			profileCa: "issuing",
+			want:      `Horizon has no CA with a valid certificate matching profile "servers" (issuing CA "issuing").`,
+		},
+		{
+			name:      "name and profile",
+			data:      caCertificatesDataSourceModel{Name: types.StringValue("root"), Profile: types.StringValue("servers")},
+			profileCa: "issuing",
+			want:      `Horizon has no CA with a valid certificate matching name "root" and profile "servers" (issuing CA "issuing").`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := caNotFoundDetail(tt.data, tt.profileCa); got != tt.want {
+				t.Fatalf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
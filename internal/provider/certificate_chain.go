@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Trust chain embedded in `horizon_certificate` when `include_chain` is set.
+// It is fetched the same way as the `horizon_certificate_trust_chain` data
+// source does.
+
+// defaultChainOrder is the `chain_order` used when unset, the default order of
+// the trust chain data source.
+const defaultChainOrder = trustChainOrderRootToLeaf
+
+func chainOrder(d certificateResourceModel) string {
+	if d.ChainOrder.IsNull() || d.ChainOrder.ValueString() == "" {
+		return defaultChainOrder
+	}
+	return d.ChainOrder.ValueString()
+}
+
+// chainNeedsRefresh reports whether the chain of d must be fetched, rather
+// than carried over from previous: the chain is only fetched again when the
+// certificate or the requested order changed.
+func chainNeedsRefresh(d, previous certificateResourceModel) bool {
+	if previous.ChainPem.IsNull() || previous.ChainPem.IsUnknown() {
+		return true
+	}
+	return d.Certificate.ValueString() != previous.Certificate.ValueString() || chainOrder(d) != chainOrder(previous)
+}
+
+// fillCertificateChain sets `chain_pem` and `fullchain_pem` for the certificate
+// of d, reusing the values of previous when possible.
+func fillCertificateChain(ctx context.Context, client *horizon.APIClient, d *certificateResourceModel, previous certificateResourceModel) error {
+	if !d.IncludeChain.ValueBool() {
+		d.ChainPem = types.StringNull()
+		d.FullchainPem = types.StringNull()
+		return nil
+	}
+	if !chainNeedsRefresh(*d, previous) {
+		d.ChainPem = previous.ChainPem
+		d.FullchainPem = previous.FullchainPem
+		return nil
+	}
+
+	certificatePem := d.Certificate.ValueString()
+	leafCerts, err := decodePEMCertificates(certificatePem)
+	if err != nil {
+		return err
+	}
+	order := chainOrder(*d)
+	chainPems, err := fetchTrustChain(ctx, client, certificatePem, order)
+	if err != nil {
+		return err
+	}
+	fullchain, err := fullchainPEM(chainPems, leafCerts[0], order)
+	if err != nil {
+		return err
+	}
+
+	d.ChainPem = types.StringValue(strings.Join(chainPems, "\n"))
+	d.FullchainPem = types.StringValue(fullchain)
+	return nil
+}
+
+// fullchainPEM returns the leaf followed by its issuers, without the
+// self-signed root, as expected by TLS servers.
+func fullchainPEM(chainPems []string, leaf *x509.Certificate, order string) (string, error) {
+	leafToRoot, err := trustChainLeafToRoot(chainPems, leaf, order)
+	if err != nil {
+		return "", err
+	}
+	if len(leafToRoot) > 1 && isSelfSigned(leafToRoot[len(leafToRoot)-1]) {
+		leafToRoot = leafToRoot[:len(leafToRoot)-1]
+	}
+
+	var b strings.Builder
+	for _, cert := range leafToRoot {
+		b.WriteString(encodeCertificatePEM(cert))
+	}
+	return b.String(), nil
+}
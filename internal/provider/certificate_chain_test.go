@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestChainNeedsRefresh(t *testing.T) {
+	previous := certificateResourceModel{
+		Certificate: types.StringValue("leaf"),
+		ChainPem:    types.StringValue("chain"),
+	}
+
+	tests := []struct {
+		name     string
+		d        certificateResourceModel
+		previous certificateResourceModel
+		want     bool
+	}{
+		{
+			name:     "same certificate and default order",
+			d:        certificateResourceModel{Certificate: types.StringValue("leaf"), ChainOrder: types.StringValue("root_to_leaf")},
+			previous: previous,
+			want:     false,
+		},
+		{
+			name:     "renewed certificate",
+			d:        certificateResourceModel{Certificate: types.StringValue("renewed")},
+			previous: previous,
+			want:     true,
+		},
+		{
+			name:     "order changed",
+			d:        certificateResourceModel{Certificate: types.StringValue("leaf"), ChainOrder: types.StringValue("leaf_to_root")},
+			previous: previous,
+			want:     true,
+		},
+		{
+			name:     "chain not fetched yet",
+			d:        certificateResourceModel{Certificate: types.StringValue("leaf")},
+			previous: certificateResourceModel{Certificate: types.StringValue("leaf"), ChainPem: types.StringNull()},
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chainNeedsRefresh(tt.d, tt.previous); got != tt.want {
+				t.Fatalf("chainNeedsRefresh() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFillCertificateChain_WithoutFetch(t *testing.T) {
+	previous := certificateResourceModel{
+		Certificate:  types.StringValue("leaf"),
+		ChainPem:     types.StringValue("chain"),
+		FullchainPem: types.StringValue("fullchain"),
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		d := certificateResourceModel{IncludeChain: types.BoolValue(false), Certificate: types.StringValue("leaf"), ChainPem: types.StringUnknown()}
+		if err := fillCertificateChain(t.Context(), nil, &d, previous); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !d.ChainPem.IsNull() || !d.FullchainPem.IsNull() {
+			t.Fatalf("chain_pem = %v, fullchain_pem = %v, want null", d.ChainPem, d.FullchainPem)
+		}
+	})
+
+	t.Run("unchanged certificate", func(t *testing.T) {
+		d := certificateResourceModel{IncludeChain: types.BoolValue(true), Certificate: types.StringValue("leaf"), ChainPem: types.StringUnknown()}
+		if err := fillCertificateChain(t.Context(), nil, &d, previous); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if d.ChainPem.ValueString() != "chain" || d.FullchainPem.ValueString() != "fullchain" {
+			t.Fatalf("chain_pem = %v, fullchain_pem = %v, want the previous values", d.ChainPem, d.FullchainPem)
+		}
+	})
+}
+
+// TestFillCertificateChain_LabelsUpdate follows Update for a labels change with
+// include_chain set: the planned certificate is unknown, and the chain is
+// resolved once the certificate returned by Horizon is filled in.
+func TestFillCertificateChain_LabelsUpdate(t *testing.T) {
+	root := newTestCA(t, "Root CA", nil)
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leaf.example.org"}}, root)
+
+	prior := certificateResourceModel{
+		IncludeChain: types.BoolValue(true),
+		Certificate:  types.StringValue(leaf.pem),
+		ChainPem:     types.StringValue(root.pem + leaf.pem),
+		FullchainPem: types.StringValue(leaf.pem),
+	}
+	plan := certificateResourceModel{
+		IncludeChain: types.BoolValue(true),
+		Certificate:  types.StringUnknown(),
+		ChainPem:     types.StringUnknown(),
+		FullchainPem: types.StringUnknown(),
+	}
+
+	fillResourceFromCertificate(&plan, &models.Certificate{Id: "cert-42", Certificate: leaf.pem})
+	if err := fillCertificateChain(t.Context(), nil, &plan, prior); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if plan.ChainPem != prior.ChainPem || plan.FullchainPem != prior.FullchainPem {
+		t.Fatalf("chain_pem = %v, fullchain_pem = %v, want the prior values", plan.ChainPem, plan.FullchainPem)
+	}
+}
+
+func TestFullchainPEM(t *testing.T) {
+	root := newTestCA(t, "Root CA", nil)
+	intermediate := newTestCA(t, "Intermediate CA", root)
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leaf.example.org"}}, intermediate)
+	want := leaf.pem + intermediate.pem
+
+	tests := map[string][]string{
+		trustChainOrderRootToLeaf:       {root.pem, intermediate.pem, leaf.pem},
+		trustChainOrderIssuerLeafToRoot: {intermediate.pem, root.pem},
+		"without root":                  {leaf.pem, intermediate.pem},
+	}
+	for name, chainPems := range tests {
+		t.Run(name, func(t *testing.T) {
+			order := name
+			if name == "without root" {
+				order = trustChainOrderLeafToRoot
+			}
+			got, err := fullchainPEM(chainPems, leaf.cert, order)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != want {
+				t.Fatalf("fullchainPEM() is not the leaf followed by the intermediate:\n%s", got)
+			}
+		})
+	}
+
+	t.Run("self-signed certificate", func(t *testing.T) {
+		got, err := fullchainPEM([]string{root.pem}, root.cert, trustChainOrderLeafToRoot)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != root.pem {
+			t.Fatalf("fullchainPEM() must keep a self-signed leaf, got:\n%s", got)
+		}
+	})
+}
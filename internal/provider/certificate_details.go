@@ -0,0 +1,249 @@
+package provider
+
+import (
+	"sort"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Computed attributes describing what Horizon knows about a certificate
+// besides its X.509 content: grading results, extensions, trigger executions,
+// discovery information and technical metadata.
+
+var certificateGradeObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":  types.StringType,
+	"grade": types.StringType,
+}}
+
+var certificateExtensionObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":  types.StringType,
+	"value": types.StringType,
+}}
+
+var certificateTriggerResultObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":                types.StringType,
+	"event":               types.StringType,
+	"status":              types.StringType,
+	"retryable":           types.BoolType,
+	"detail":              types.StringType,
+	"last_execution_date": types.Int64Type,
+	"next_execution_date": types.Int64Type,
+}}
+
+var certificateDiscoveryInfoObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"campaign":            types.StringType,
+	"last_discovery_date": types.Int64Type,
+	"identifier":          types.StringType,
+}}
+
+func certificateDetailsSchema() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"grades": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Grades given to the certificate by the Horizon grading policies, such as the ones of `horizon_grading_policy`.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "Name of the grading policy.",
+					},
+					"grade": schema.StringAttribute{
+						Computed:    true,
+						Description: "Grade given by the policy. For example: `A`.",
+					},
+				},
+			},
+		},
+		"extensions": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Certificate extensions, as decoded by Horizon.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Computed:    true,
+						Description: "Extension type.",
+					},
+					"value": schema.StringAttribute{
+						Computed:    true,
+						Description: "Extension value.",
+					},
+				},
+			},
+		},
+		"trigger_results": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Results of the triggers executed on the certificate lifecycle events.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed:    true,
+						Description: "Trigger name.",
+					},
+					"event": schema.StringAttribute{
+						Computed:    true,
+						Description: "Lifecycle event the trigger ran on. For example: `enroll`.",
+					},
+					"status": schema.StringAttribute{
+						Computed:    true,
+						Description: "Status of the last execution. For example: `success` or `failure`.",
+					},
+					"retryable": schema.BoolAttribute{
+						Computed:    true,
+						Description: "Whether Horizon will retry a failed execution.",
+					},
+					"detail": schema.StringAttribute{
+						Computed:    true,
+						Description: "Detail of the last execution, usually the error message of a failure.",
+					},
+					"last_execution_date": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Date of the last execution.",
+					},
+					"next_execution_date": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Date of the next execution, when a retry is scheduled.",
+					},
+				},
+			},
+		},
+		"discovery_info": schema.ListNestedAttribute{
+			Computed:    true,
+			Description: "Discovery campaigns that found the certificate.",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"campaign": schema.StringAttribute{
+						Computed:    true,
+						Description: "Discovery campaign name.",
+					},
+					"last_discovery_date": schema.Int64Attribute{
+						Computed:    true,
+						Description: "Date the campaign last found the certificate.",
+					},
+					"identifier": schema.StringAttribute{
+						Computed:    true,
+						Description: "Identifier of the discovery event.",
+					},
+				},
+			},
+		},
+		"metadata": schema.MapAttribute{
+			Computed:    true,
+			ElementType: types.StringType,
+			Description: "Technical metadata set by Horizon on the certificate, by key.",
+		},
+	}
+}
+
+// fillCertificateDetails copies the certificate details to the model, sorted so
+// that refreshes do not produce spurious differences.
+func fillCertificateDetails(d *certificateResourceModel, certificate *models.Certificate) {
+	d.Grades = gradesValue(certificate.Grades)
+	d.Extensions = extensionsValue(certificate.Extensions)
+	d.TriggerResults = triggerResultsValue(certificate.TriggerResults)
+	d.DiscoveryInfo = discoveryInfoValue(certificate.DiscoveryInfo)
+	d.Metadata = metadataValue(certificate.Metadata)
+}
+
+// unknownCertificateDetails marks the certificate details as unknown, for plans
+// that replace the certificate.
+func unknownCertificateDetails(d *certificateResourceModel) {
+	d.Grades = types.ListUnknown(certificateGradeObjectType)
+	d.Extensions = types.ListUnknown(certificateExtensionObjectType)
+	d.TriggerResults = types.ListUnknown(certificateTriggerResultObjectType)
+	d.DiscoveryInfo = types.ListUnknown(certificateDiscoveryInfoObjectType)
+	d.Metadata = types.MapUnknown(types.StringType)
+}
+
+func gradesValue(grades []models.CertificateGrade) types.List {
+	sorted := make([]models.CertificateGrade, len(grades))
+	copy(sorted, grades)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetName() < sorted[j].GetName() })
+
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, g := range sorted {
+		elements = append(elements, types.ObjectValueMust(certificateGradeObjectType.AttrTypes, map[string]attr.Value{
+			"name":  types.StringValue(g.GetName()),
+			"grade": types.StringValue(string(g.GetGrade())),
+		}))
+	}
+	return types.ListValueMust(certificateGradeObjectType, elements)
+}
+
+func extensionsValue(extensions []models.CertificateExtension) types.List {
+	sorted := make([]models.CertificateExtension, len(extensions))
+	copy(sorted, extensions)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetType() < sorted[j].GetType() })
+
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, e := range sorted {
+		elements = append(elements, types.ObjectValueMust(certificateExtensionObjectType.AttrTypes, map[string]attr.Value{
+			"type":  types.StringValue(string(e.GetType())),
+			"value": types.StringValue(e.GetValue()),
+		}))
+	}
+	return types.ListValueMust(certificateExtensionObjectType, elements)
+}
+
+func triggerResultsValue(results []models.CertificateTriggerResult) types.List {
+	sorted := make([]models.CertificateTriggerResult, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].GetEvent() != sorted[j].GetEvent() {
+			return sorted[i].GetEvent() < sorted[j].GetEvent()
+		}
+		return sorted[i].GetName() < sorted[j].GetName()
+	})
+
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, r := range sorted {
+		detail := types.StringNull()
+		if v := r.GetDetail(); v != "" {
+			detail = types.StringValue(v)
+		}
+		next := types.Int64Null()
+		if r.HasNextExecutionDate() {
+			next = types.Int64Value(r.GetNextExecutionDate())
+		}
+		elements = append(elements, types.ObjectValueMust(certificateTriggerResultObjectType.AttrTypes, map[string]attr.Value{
+			"name":                types.StringValue(r.GetName()),
+			"event":               types.StringValue(string(r.GetEvent())),
+			"status":              types.StringValue(string(r.GetStatus())),
+			"retryable":           types.BoolValue(r.GetRetryable()),
+			"detail":              detail,
+			"last_execution_date": types.Int64Value(r.GetLastExecutionDate()),
+			"next_execution_date": next,
+		}))
+	}
+	return types.ListValueMust(certificateTriggerResultObjectType, elements)
+}
+
+func discoveryInfoValue(infos []models.CertificateDiscoveryInfo) types.List {
+	sorted := make([]models.CertificateDiscoveryInfo, len(infos))
+	copy(sorted, infos)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetCampaign() < sorted[j].GetCampaign() })
+
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, info := range sorted {
+		identifier := types.StringNull()
+		if v := info.GetIdentifier(); v != "" {
+			identifier = types.StringValue(v)
+		}
+		elements = append(elements, types.ObjectValueMust(certificateDiscoveryInfoObjectType.AttrTypes, map[string]attr.Value{
+			"campaign":            types.StringValue(info.GetCampaign()),
+			"last_discovery_date": types.Int64Value(info.GetLastDiscoveryDate()),
+			"identifier":          identifier,
+		}))
+	}
+	return types.ListValueMust(certificateDiscoveryInfoObjectType, elements)
+}
+
+func metadataValue(metadata []models.CertificateMetadata) types.Map {
+	elements := make(map[string]attr.Value, len(metadata))
+	for _, m := range metadata {
+		elements[string(m.GetKey())] = types.StringValue(m.GetValue())
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
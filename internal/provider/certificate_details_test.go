@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillCertificateDetails(t *testing.T) {
+	cert := &models.Certificate{
+		Grades: []models.CertificateGrade{
+			{Name: "tls-server", Grade: "B"},
+			{Name: "ansi", Grade: "A"},
+		},
+		Extensions: []models.CertificateExtension{
+			{Type: "keyusage", Value: "digitalSignature"},
+			{Type: "basicconstraints", Value: "CA:false"},
+		},
+		TriggerResults: []models.CertificateTriggerResult{
+			{Name: "webhook", Event: "enroll", Status: "success", LastExecutionDate: 1000},
+			{Name: "aws", Event: "enroll", Status: "failure", Retryable: true, Detail: strPtr("throttled"), LastExecutionDate: 2000, NextExecutionDate: int64Ptr(3000)},
+		},
+		DiscoveryInfo: []models.CertificateDiscoveryInfo{
+			{Campaign: "dmz", LastDiscoveryDate: 4000},
+		},
+		Metadata: []models.CertificateMetadata{
+			{Key: "pki_connector", Value: "Integrated"},
+		},
+	}
+
+	var d certificateResourceModel
+	fillCertificateDetails(&d, cert)
+
+	var grades []struct {
+		Name  types.String `tfsdk:"name"`
+		Grade types.String `tfsdk:"grade"`
+	}
+	if diags := d.Grades.ElementsAs(t.Context(), &grades, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(grades) != 2 || grades[0].Name.ValueString() != "ansi" || grades[1].Grade.ValueString() != "B" {
+		t.Errorf("grades = %v, want sorted by name", grades)
+	}
+
+	var extensions []struct {
+		Type  types.String `tfsdk:"type"`
+		Value types.String `tfsdk:"value"`
+	}
+	if diags := d.Extensions.ElementsAs(t.Context(), &extensions, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(extensions) != 2 || extensions[0].Type.ValueString() != "basicconstraints" {
+		t.Errorf("extensions = %v, want sorted by type", extensions)
+	}
+
+	var triggers []struct {
+		Name              types.String `tfsdk:"name"`
+		Event             types.String `tfsdk:"event"`
+		Status            types.String `tfsdk:"status"`
+		Retryable         types.Bool   `tfsdk:"retryable"`
+		Detail            types.String `tfsdk:"detail"`
+		LastExecutionDate types.Int64  `tfsdk:"last_execution_date"`
+		NextExecutionDate types.Int64  `tfsdk:"next_execution_date"`
+	}
+	if diags := d.TriggerResults.ElementsAs(t.Context(), &triggers, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(triggers) != 2 {
+		t.Fatalf("got %d trigger results, want 2", len(triggers))
+	}
+	failed, succeeded := triggers[0], triggers[1]
+	if failed.Name.ValueString() != "aws" || failed.Status.ValueString() != "failure" || !failed.Retryable.ValueBool() ||
+		failed.Detail.ValueString() != "throttled" || failed.NextExecutionDate.ValueInt64() != 3000 {
+		t.Errorf("failed trigger result = %+v", failed)
+	}
+	if !succeeded.Detail.IsNull() || !succeeded.NextExecutionDate.IsNull() {
+		t.Errorf("successful trigger result must have null detail and next execution date, got %+v", succeeded)
+	}
+
+	if len(d.DiscoveryInfo.Elements()) != 1 {
+		t.Errorf("discovery_info = %v, want one element", d.DiscoveryInfo)
+	}
+
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{"pki_connector": types.StringValue("Integrated")})
+	if !d.Metadata.Equal(want) {
+		t.Errorf("metadata = %v, want %v", d.Metadata, want)
+	}
+}
+
+func TestFillCertificateDetails_Empty(t *testing.T) {
+	var d certificateResourceModel
+	fillCertificateDetails(&d, &models.Certificate{})
+
+	for name, list := range map[string]types.List{
+		"grades":          d.Grades,
+		"extensions":      d.Extensions,
+		"trigger_results": d.TriggerResults,
+		"discovery_info":  d.DiscoveryInfo,
+	} {
+		if list.IsNull() || list.IsUnknown() || len(list.Elements()) != 0 {
+			t.Errorf("%s = %v, want an empty list", name, list)
+		}
+	}
+	if d.Metadata.IsNull() || len(d.Metadata.Elements()) != 0 {
+		t.Errorf("metadata = %v, want an empty map", d.Metadata)
+	}
+}
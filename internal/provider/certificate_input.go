@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Data sources looking a certificate up take either its PEM, as
+// `certificate_pem`, or the id of a certificate stored in Horizon, as
+// `certificate_id`.
+
+// validateCertificateInput checks that exactly one of certificate_pem and
+// certificate_id is set, and not empty.
+func validateCertificateInput(certificatePem, certificateId types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if certificatePem.IsNull() && certificateId.IsNull() {
+		diags.AddAttributeError(
+			path.Root("certificate_pem"),
+			"Missing certificate",
+			"Exactly one of certificate_pem or certificate_id must be set.",
+		)
+	}
+	if !certificatePem.IsNull() && !certificateId.IsNull() {
+		diags.AddAttributeError(
+			path.Root("certificate_id"),
+			"Conflicting certificate inputs",
+			"Exactly one of certificate_pem or certificate_id must be set.",
+		)
+	}
+
+	if !certificatePem.IsNull() && !certificatePem.IsUnknown() {
+		if strings.TrimSpace(certificatePem.ValueString()) == "" {
+			diags.AddAttributeError(
+				path.Root("certificate_pem"),
+				"certificate_pem must not be empty",
+				"Provide a PEM-encoded X.509 certificate.",
+			)
+		}
+	}
+
+	if !certificateId.IsNull() && !certificateId.IsUnknown() {
+		if strings.TrimSpace(certificateId.ValueString()) == "" {
+			diags.AddAttributeError(
+				path.Root("certificate_id"),
+				"certificate_id must not be empty",
+				"Provide the Horizon id of a certificate.",
+			)
+		}
+	}
+	return diags
+}
+
+// resolveCertificatePem returns certificatePem, or the PEM of the certificate
+// certificateId when set.
+func resolveCertificatePem(ctx context.Context, client *horizon.APIClient, certificatePem, certificateId types.String) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	pem := certificatePem.ValueString()
+	if !certificateId.IsNull() {
+		certResp, httpResp, err := client.CertificateAPI.CertificateGetId(ctx, certificateId.ValueString()).Execute()
+		if err != nil {
+			if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+				diags.AddAttributeError(
+					path.Root("certificate_id"),
+					"Certificate not found",
+					fmt.Sprintf("Horizon has no certificate with id %q.", certificateId.ValueString()),
+				)
+				return "", diags
+			}
+			diags.AddError("Failed to get certificate", err.Error())
+			return "", diags
+		}
+		pem = certResp.Certificate.GetCertificate()
+	}
+
+	if strings.TrimSpace(pem) == "" {
+		diags.AddAttributeError(
+			path.Root("certificate_pem"),
+			"certificate_pem must not be empty",
+			"Provide a PEM-encoded X.509 certificate.",
+		)
+	}
+	return pem, diags
+}
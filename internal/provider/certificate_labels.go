@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Plan-time check of the labels of `horizon_certificate` against the label
+// definitions of Horizon, managed with `horizon_label` or not.
+
+// labelDefinitions lists the label definitions of Horizon once per provider
+// instance, rather than on every certificate plan.
+type labelDefinitions struct {
+	list func(ctx context.Context) ([]models.Label, error)
+
+	mu          sync.Mutex
+	definitions []models.Label
+	listed      bool
+}
+
+func newLabelDefinitions(client *horizon.APIClient) *labelDefinitions {
+	return &labelDefinitions{list: func(ctx context.Context) ([]models.Label, error) {
+		definitions, _, err := client.LabelAPI.LabelGetAll(ctx).Execute()
+		return definitions, err
+	}}
+}
+
+// get returns the label definitions, listing them on the first call. A
+// failed listing is tried again on the next call.
+func (l *labelDefinitions) get(ctx context.Context) ([]models.Label, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.listed {
+		definitions, err := l.list(ctx)
+		if err != nil {
+			return nil, err
+		}
+		l.definitions, l.listed = definitions, true
+	}
+	return l.definitions, nil
+}
+
+// checkCertificateLabels validates the effective labels of a certificate
+// against the label definitions. The labels are not checked when they are not
+// known yet, before the provider is configured, or when the definitions
+// cannot be listed.
+func checkCertificateLabels(ctx context.Context, labels *labelDefinitions, effective types.Map) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if effective.IsUnknown() || labels == nil {
+		return diags
+	}
+
+	values := map[string]string{}
+	diags.Append(effective.ElementsAs(ctx, &values, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	definitions, err := labels.get(ctx)
+	if err != nil {
+		diags.AddAttributeWarning(
+			path.Root("labels"),
+			"Labels not validated",
+			fmt.Sprintf("The label definitions could not be listed, so the labels will only be checked by Horizon on apply: %s", err),
+		)
+		return diags
+	}
+
+	diags.Append(validateLabels(values, definitions)...)
+	return diags
+}
+
+// validateLabels checks that values holds allowed values and every mandatory
+// label. Mismatches only raise warnings: the definitions read at plan time may
+// be created or changed by a `horizon_label` of the same apply, and Horizon
+// still rejects an invalid certificate on apply.
+func validateLabels(values map[string]string, definitions []models.Label) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	byName := make(map[string]models.Label, len(definitions))
+	for _, definition := range definitions {
+		byName[definition.Name] = definition
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := values[name]
+		definition, ok := byName[name]
+		if !ok {
+			diags.AddAttributeWarning(
+				path.Root("labels"),
+				"Unknown label",
+				fmt.Sprintf("Horizon has no label named %q yet. Unless it is created in the same apply, for example with a horizon_label, Horizon will reject the certificate.", name),
+			)
+			continue
+		}
+
+		if len(definition.AllowedValues) > 0 && !containsString(definition.AllowedValues, value) {
+			diags.AddAttributeWarning(
+				path.Root("labels"),
+				"Invalid label value",
+				fmt.Sprintf("Label %q must be one of %s, got %q. Unless the label definition changes in the same apply, Horizon will reject the certificate.", name, strings.Join(definition.AllowedValues, ", "), value),
+			)
+			continue
+		}
+
+		if definition.Regex != nil && *definition.Regex != "" {
+			// Horizon matches the whole value. Expressions RE2 does not
+			// support are left to Horizon.
+			re, err := regexp.Compile("^(?:" + *definition.Regex + ")$")
+			if err == nil && !re.MatchString(value) {
+				diags.AddAttributeWarning(
+					path.Root("labels"),
+					"Invalid label value",
+					fmt.Sprintf("Label %q must match %q, got %q. Unless the label definition changes in the same apply, Horizon will reject the certificate.", name, *definition.Regex, value),
+				)
+			}
+		}
+	}
+
+	for _, definition := range definitions {
+		if definition.Mandatory == nil || !*definition.Mandatory {
+			continue
+		}
+		if _, ok := values[definition.Name]; !ok {
+			diags.AddAttributeWarning(
+				path.Root("labels"),
+				"Missing mandatory label",
+				fmt.Sprintf("Label %q is mandatory. Unless the label definition changes in the same apply, set it in labels, or in the provider default_metadata labels.", definition.Name),
+			)
+		}
+	}
+	return diags
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
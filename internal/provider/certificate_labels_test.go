@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateLabels(t *testing.T) {
+	definitions := []models.Label{
+		{Name: "env", AllowedValues: []string{"dev", "prod"}, Mandatory: boolPtr(true)},
+		{Name: "app", Regex: strPtr(`[a-z]+(-[a-z]+)*`)},
+		{Name: "ticket", Regex: strPtr(`(?<=JIRA)-\d+`)},
+		{Name: "free"},
+	}
+
+	// Definitions may change in the same apply, so mismatches never fail the
+	// plan.
+	tests := []struct {
+		name        string
+		values      map[string]string
+		wantWarning string
+	}{
+		{name: "valid", values: map[string]string{"env": "prod", "app": "web-front", "free": "anything"}},
+		{name: "label created in the same apply", values: map[string]string{"env": "prod", "team": "x"}, wantWarning: `no label named "team"`},
+		{name: "value not allowed", values: map[string]string{"env": "staging"}, wantWarning: `must be one of dev, prod`},
+		{name: "regex matches the whole value", values: map[string]string{"env": "dev", "app": "web front"}, wantWarning: `must match`},
+		{name: "regex RE2 cannot check", values: map[string]string{"env": "dev", "ticket": "anything"}},
+		{name: "missing mandatory label", values: map[string]string{"app": "web"}, wantWarning: `"env" is mandatory`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateLabels(tt.values, definitions)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if tt.wantWarning == "" {
+				if len(diags) != 0 {
+					t.Fatalf("unexpected diagnostics: %v", diags)
+				}
+				return
+			}
+			if diags.WarningsCount() != 1 || !strings.Contains(diags.Warnings()[0].Detail(), tt.wantWarning) {
+				t.Fatalf("want one warning containing %q, got %v", tt.wantWarning, diags)
+			}
+		})
+	}
+}
+
+func TestCheckCertificateLabelsSkipsUnknown(t *testing.T) {
+	// Neither an unknown map nor a missing client may reach the API.
+	if diags := checkCertificateLabels(context.Background(), nil, types.MapUnknown(types.StringType)); diags.HasError() {
+		t.Fatal(diags)
+	}
+	known := types.MapValueMust(types.StringType, nil)
+	if diags := checkCertificateLabels(context.Background(), nil, known); diags.HasError() {
+		t.Fatal(diags)
+	}
+}
+
+func TestLabelDefinitionsListedOnce(t *testing.T) {
+	calls := 0
+	fail := true
+	labels := &labelDefinitions{list: func(ctx context.Context) ([]models.Label, error) {
+		calls++
+		if fail {
+			return nil, errors.New("unavailable")
+		}
+		return []models.Label{{Name: "env"}}, nil
+	}}
+
+	if _, err := labels.get(context.Background()); err == nil {
+		t.Fatal("want the listing error")
+	}
+	fail = false
+	for i := 0; i < 3; i++ {
+		definitions, err := labels.get(context.Background())
+		if err != nil || len(definitions) != 1 {
+			t.Fatalf("got %v, %v", definitions, err)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("want a failed listing retried and a successful one kept, got %d calls", calls)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	horizon "github.com/evertrust/horizon-go/v2"
@@ -18,7 +19,6 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
 const (
@@ -28,6 +28,11 @@ const (
 	workflowRenew    = "renew"
 	workflowRevoke   = "revoke"
 	revocationReason = "cessationofoperation"
+
+	defaultCreateTimeout = 5 * time.Minute
+	defaultReadTimeout   = 2 * time.Minute
+	defaultUpdateTimeout = 5 * time.Minute
+	defaultDeleteTimeout = 2 * time.Minute
 )
 
 func NewCertificateResource() resource.Resource {
@@ -36,7 +41,9 @@ func NewCertificateResource() resource.Resource {
 
 // CertificateResource defines the resource implementation.
 type CertificateResource struct {
-	client *horizon.APIClient
+	client   *horizon.APIClient
+	defaults defaultMetadata
+	labels   *labelDefinitions
 }
 
 type certificateSubjectModel struct {
@@ -57,20 +64,26 @@ type certificateLabelModel struct {
 
 // certificateResourceModel describes the resource data model.
 type certificateResourceModel struct {
-	Id                  types.String `tfsdk:"id"`
-	Profile             types.String `tfsdk:"profile"`
-	Owner               types.String `tfsdk:"owner"`
-	Team                types.String `tfsdk:"team"`
-	ContactEmail        types.String `tfsdk:"contact_email"`
-	Subject             types.Set    `tfsdk:"subject"`
-	Sans                types.Set    `tfsdk:"sans"`
-	Labels              types.Set    `tfsdk:"labels"`
-	WaitForThirdParties types.Set    `tfsdk:"wait_for_third_parties"`
+	Id                    types.String `tfsdk:"id"`
+	Profile               types.String `tfsdk:"profile"`
+	Owner                 types.String `tfsdk:"owner"`
+	Team                  types.String `tfsdk:"team"`
+	ContactEmail          types.String `tfsdk:"contact_email"`
+	Subject               types.Set    `tfsdk:"subject"`
+	Sans                  types.Set    `tfsdk:"sans"`
+	Labels                types.Set    `tfsdk:"labels"`
+	EffectiveLabels       types.Map    `tfsdk:"effective_labels"`
+	EffectiveOwner        types.String `tfsdk:"effective_owner"`
+	EffectiveTeam         types.String `tfsdk:"effective_team"`
+	EffectiveContactEmail types.String `tfsdk:"effective_contact_email"`
+	WaitForThirdParties   types.Set    `tfsdk:"wait_for_third_parties"`
+	ThirdPartyData        types.List   `tfsdk:"third_party_data"`
 
 	// Settings
 
-	RevokeOnDelete types.Bool  `tfsdk:"revoke_on_delete"`
-	RenewBefore    types.Int64 `tfsdk:"renew_before"`
+	RevokeOnDelete   types.Bool  `tfsdk:"revoke_on_delete"`
+	RenewBefore      types.Int64 `tfsdk:"renew_before"`
+	RotateKeyOnRenew types.Bool  `tfsdk:"rotate_key_on_renew"`
 
 	Csr               types.String `tfsdk:"csr"`
 	Pkcs12            types.String `tfsdk:"pkcs12"`
@@ -79,6 +92,11 @@ type certificateResourceModel struct {
 	PasswordWriteOnly types.Bool   `tfsdk:"password_write_only"`
 	Certificate       types.String `tfsdk:"certificate"`
 
+	IncludeChain types.Bool   `tfsdk:"include_chain"`
+	ChainOrder   types.String `tfsdk:"chain_order"`
+	ChainPem     types.String `tfsdk:"chain_pem"`
+	FullchainPem types.String `tfsdk:"fullchain_pem"`
+
 	Thumbprint          types.String `tfsdk:"thumbprint"`
 	SelfSigned          types.Bool   `tfsdk:"self_signed"`
 	PublicKeyThumbprint types.String `tfsdk:"public_key_thumbprint"`
@@ -92,6 +110,12 @@ type certificateResourceModel struct {
 	SigningAlgorithm    types.String `tfsdk:"signing_algorithm"`
 	RenewalTrigger      types.String `tfsdk:"renewal_trigger"`
 
+	Grades         types.List `tfsdk:"grades"`
+	Extensions     types.List `tfsdk:"extensions"`
+	TriggerResults types.List `tfsdk:"trigger_results"`
+	DiscoveryInfo  types.List `tfsdk:"discovery_info"`
+	Metadata       types.Map  `tfsdk:"metadata"`
+
 	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
@@ -161,7 +185,7 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 				},
 			},
 			"labels": schema.SetNestedAttribute{
-				Description: "Labels of the certificate, used to enrich the certificate metadata on Horizon.",
+				Description: "Labels of the certificate, used to enrich the certificate metadata on Horizon. They are checked at plan time against the label definitions, such as those managed with `horizon_label`; as the definitions may change in the same apply, a label that does not match them only raises a warning.",
 				Optional:    true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -176,22 +200,75 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 					},
 				},
 			},
+			"effective_labels": schema.MapAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Labels sent to Horizon: the provider `default_metadata` labels merged with `labels`, resource values taking precedence.",
+			},
+			"effective_owner": schema.StringAttribute{
+				Computed:    true,
+				Description: "Owner sent to Horizon: `owner`, or the provider `default_metadata` owner when unset.",
+			},
+			"effective_team": schema.StringAttribute{
+				Computed:    true,
+				Description: "Team sent to Horizon: `team`, or the provider `default_metadata` team when unset.",
+			},
+			"effective_contact_email": schema.StringAttribute{
+				Computed:    true,
+				Description: "Contact email sent to Horizon: `contact_email`, or the provider `default_metadata` contact email when unset.",
+			},
 			"wait_for_third_parties": schema.SetAttribute{
-				Description: "Third parties ids to which the certificate will be published.",
+				Description: "Third parties ids to which the certificate will be published, such as the `id` of a `horizon_webhook_connector` or the `ids` of the `horizon_third_party_connectors` data source. After enrollment and renewal, the provider waits until the certificate is published to each of them, and fails with the error of the third party as soon as a publication fails without being retried.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"third_party_data": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Third parties the certificate is published to.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"connector": schema.StringAttribute{
+							Computed:    true,
+							Description: "Third-party connector name.",
+						},
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Identifier of the certificate on the third party.",
+						},
+						"fingerprint": schema.StringAttribute{
+							Computed:    true,
+							Description: "Fingerprint of the certificate on the third party, when the third party reports one.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of the certificate on the third party: `published`, `removed`, or `failed` when the last publication attempt failed.",
+						},
+						"last_sync": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Date of the last synchronization with the third party: the last push, removal or publication attempt.",
+						},
+						"push_date": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Date the certificate was pushed to the third party.",
+						},
+						"remove_date": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Date the certificate was removed from the third party. Null while it is published.",
+						},
+					},
+				},
+			},
 			"owner": schema.StringAttribute{
 				Optional:    true,
-				Description: "Owner associated with the certificate.",
+				Description: "Owner associated with the certificate. Defaults to the provider `default_metadata` owner.",
 			},
 			"team": schema.StringAttribute{
 				Optional:    true,
-				Description: "Team associated with the certificate.",
+				Description: "Team associated with the certificate, such as the `name` of a `horizon_team`. Defaults to the provider `default_metadata` team.",
 			},
 			"contact_email": schema.StringAttribute{
 				Optional:    true,
-				Description: "Contact email associated with the certificate.",
+				Description: "Contact email associated with the certificate. Defaults to the provider `default_metadata` contact email.",
 			},
 			"serial": schema.StringAttribute{
 				Computed:    true,
@@ -216,7 +293,7 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 			"key_type": schema.StringAttribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "Key type of the certificate. For example: `rsa-2048`.",
+				Description: "Key type of the certificate. For example: `rsa-2048`. For centralized enrollments, changing it renews the certificate with a new key of that type.",
 			},
 			"signing_algorithm": schema.StringAttribute{
 				Computed:    true,
@@ -230,6 +307,10 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 				Description: "How many days before expiration the certificate should be renewed. When a `plan` or `apply` runs inside that window, the provider triggers a renewal on already existing enrollments. For decentralized enrollments, the existing `csr` is reused; if you want a brand-new key on each renewal, regenerate the CSR-producing resource (e.g. `tls_private_key`) so a fresh CSR reaches the renew call.",
 				Optional:    true,
 			},
+			"rotate_key_on_renew": schema.BoolAttribute{
+				Description: "Whether centralized renewals must come with a new key pair. When true, the renew request carries the key type, which has Horizon generate a new key pair, and the apply warns if the renewed certificate still uses the previous public key. Otherwise, renewals follow the key policy of the profile unless `key_type` changes. Has no effect when `csr` is provided: regenerate the CSR to rotate a decentralized key.",
+				Optional:    true,
+			},
 			"csr": schema.StringAttribute{
 				Description: "A CSR (Certificate Signing Request) in PEM format. Providing this attribute will trigger a decentralized enrollment. Incompatible with `subject` and `sans`.",
 				Optional:    true,
@@ -259,6 +340,22 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 				Optional:    true,
 				Computed:    true,
 			},
+			"include_chain": schema.BoolAttribute{
+				Description: "Whether to fetch the trust chain of the certificate into `chain_pem` and `fullchain_pem`, as the `horizon_certificate_trust_chain` data source does. The chain is fetched after enrollment and renewal, and refreshed only when the certificate changes.",
+				Optional:    true,
+			},
+			"chain_order": schema.StringAttribute{
+				Description: "Order of `chain_pem`. One of `leaf_to_root`, `root_to_leaf`, `issuer_leaf_to_root`, `issuer_root_to_leaf`. Defaults to `root_to_leaf`. Only used when `include_chain` is true.",
+				Optional:    true,
+			},
+			"chain_pem": schema.StringAttribute{
+				Computed:    true,
+				Description: "Concatenated PEM bundle of the trust chain returned by Horizon, in `chain_order`. Null unless `include_chain` is true.",
+			},
+			"fullchain_pem": schema.StringAttribute{
+				Computed:    true,
+				Description: "Concatenated PEM bundle of the certificate followed by its issuers, without the self-signed root, as expected by TLS servers such as nginx. Null unless `include_chain` is true.",
+			},
 			"thumbprint": schema.StringAttribute{
 				Computed:    true,
 				Description: "Thumbprint of the certificate.",
@@ -286,9 +383,16 @@ func (r *CertificateResource) Schema(ctx context.Context, req resource.SchemaReq
 		Blocks: map[string]schema.Block{
 			"timeouts": timeouts.Block(ctx, timeouts.Opts{
 				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
 			}),
 		},
 	}
+
+	for name, attribute := range certificateDetailsSchema() {
+		resp.Schema.Attributes[name] = attribute
+	}
 }
 
 func (r *CertificateResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -297,18 +401,20 @@ func (r *CertificateResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*horizon.APIClient)
+	providerData, ok := req.ProviderData.(*horizonProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *horizon.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.client
+	r.defaults = providerData.defaults
+	r.labels = providerData.labels
 }
 
 func (r *CertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -326,6 +432,14 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	createTimeout, diags := data.Timeouts.Create(ctx, defaultCreateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	template := models.NewWebRAEnrollRequestTemplateWithDefaults()
 
 	if !data.Csr.IsNull() {
@@ -338,7 +452,7 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 			RequestTemplateRequest(models.WebRAEnrollRequestOnTemplateAsRequestTemplateRequest(onTemplate)).
 			Execute()
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to get enroll template", err.Error())
+			addOperationError(ctx, &resp.Diagnostics, "Failed to get enroll template", err, createTimeout)
 			return
 		}
 		onTemplateResp := tmplResp.WebRAEnrollRequestOnTemplateResponse
@@ -407,33 +521,34 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 		}
 	}
 
-	// Set Labels
-	labels := make([]certificateLabelModel, 0, len(data.Labels.Elements()))
-	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
-	labelElements := make([]models.RequestLabelElement, 0, len(labels))
-	for _, label := range labels {
-		el := models.RequestLabelElement{Label: label.Label.ValueString()}
-		el.SetValue(label.Value.ValueString())
-		labelElements = append(labelElements, el)
+	// Set Labels, merged with the provider defaults
+	effective, diags := effectiveLabels(ctx, r.defaults.Labels, data.Labels)
+	resp.Diagnostics.Append(diags...)
+	data.EffectiveLabels = effective
+	labels, diags := labelElements(ctx, effective)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	template.SetLabels(labelElements)
+	template.SetLabels(labels)
 
-	if !data.Owner.IsNull() {
-		owner := models.NewCertificateOwnerElementWithDefaults()
-		owner.SetValue(data.Owner.ValueString())
-		template.SetOwner(*owner)
+	setEffectiveMetadata(&data, r.defaults)
+	if !data.EffectiveOwner.IsNull() {
+		el := models.NewCertificateOwnerElementWithDefaults()
+		el.SetValue(data.EffectiveOwner.ValueString())
+		template.SetOwner(*el)
 	}
 
-	if !data.Team.IsNull() {
-		team := models.NewCertificateTeamElementWithDefaults()
-		team.SetValue(data.Team.ValueString())
-		template.SetTeam(*team)
+	if !data.EffectiveTeam.IsNull() {
+		el := models.NewCertificateTeamElementWithDefaults()
+		el.SetValue(data.EffectiveTeam.ValueString())
+		template.SetTeam(*el)
 	}
 
-	if !data.ContactEmail.IsNull() {
-		contact := models.NewCertificateContactEmailElementWithDefaults()
-		contact.SetValue(data.ContactEmail.ValueString())
-		template.SetContactEmail(*contact)
+	if !data.EffectiveContactEmail.IsNull() {
+		el := models.NewCertificateContactEmailElementWithDefaults()
+		el.SetValue(data.EffectiveContactEmail.ValueString())
+		template.SetContactEmail(*el)
 	}
 
 	submit := models.NewWebRAEnrollRequestOnSubmit(
@@ -453,7 +568,7 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 		RequestSubmitRequest(models.WebRAEnrollRequestOnSubmitAsRequestSubmitRequest(submit))
 	submitResp, _, err := apiReq.Execute()
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to enroll certificate", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to enroll certificate", err, createTimeout)
 		return
 	}
 
@@ -469,37 +584,6 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Check that certificates are successfully added to Third Parties
-	thirdParties := make([]string, 0, len(data.WaitForThirdParties.Elements()))
-	resp.Diagnostics.Append(data.WaitForThirdParties.ElementsAs(ctx, &thirdParties, false)...)
-
-	createTimeout, diags := data.Timeouts.Create(ctx, 5*time.Minute)
-	resp.Diagnostics.Append(diags...)
-
-	// If ThirdParties were defined, poll the certificate until all of them are in the 'thirdPartyData' field
-	if len(thirdParties) > 0 {
-		err = retry.RetryContext(ctx, createTimeout, func() *retry.RetryError {
-			certificateId := cert.Id
-			tflog.Info(ctx, fmt.Sprintf("Polling certificate %s for third parties: %v", certificateId, thirdParties))
-
-			polledResp, _, pErr := r.client.CertificateAPI.CertificateGetId(ctx, certificateId).Execute()
-			if pErr != nil {
-				return retry.RetryableError(fmt.Errorf("failed to poll certificate after enrollment: %s", pErr.Error()))
-			}
-			polled := polledResp.GetCertificate()
-			tflog.Info(ctx, fmt.Sprintf("Polling certificate, get third parties: %v", polled.ThirdPartyData))
-			// Check if the certificate has been added to all third parties
-			if !hasThirdParties(polled.ThirdPartyData, thirdParties) {
-				return retry.RetryableError(fmt.Errorf("failed to find all third party data after enrollment : %v", polled.ThirdPartyData))
-			}
-			return nil
-		})
-		if err != nil {
-			resp.Diagnostics.AddError("Failed to verify third parties after enrollment", err.Error())
-			return
-		}
-	}
-
 	fillResourceFromCertificate(&data, cert)
 
 	if enrollResp.Pkcs12.IsSet() && enrollResp.Pkcs12.Get() != nil && !data.Pkcs12WriteOnly.ValueBool() {
@@ -514,6 +598,32 @@ func (r *CertificateResource) Create(ctx context.Context, req resource.CreateReq
 		data.Password = types.StringNull()
 	}
 
+	if err := fillCertificateChain(ctx, r.client, &data, certificateResourceModel{}); err != nil {
+		// The certificate is enrolled: keep track of it before reporting the failure.
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		addOperationError(ctx, &resp.Diagnostics, "Failed to retrieve certificate trust chain", err, createTimeout)
+		return
+	}
+
+	// Check that certificates are successfully added to Third Parties
+	thirdParties := make([]string, 0, len(data.WaitForThirdParties.Elements()))
+	resp.Diagnostics.Append(data.WaitForThirdParties.ElementsAs(ctx, &thirdParties, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if len(thirdParties) > 0 {
+		polled, err := waitForThirdParties(ctx, r.client, cert.Id, thirdParties, createTimeout)
+		if polled != nil {
+			fillResourceFromCertificate(&data, polled)
+		}
+		if err != nil {
+			// The certificate is enrolled: keep track of it before reporting the failure.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			addOperationError(ctx, &resp.Diagnostics, "Failed to verify third parties after enrollment", err, createTimeout)
+			return
+		}
+	}
+
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -528,6 +638,14 @@ func (r *CertificateResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	readTimeout, diags := data.Timeouts.Read(ctx, defaultReadTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
 	tflog.Info(ctx, fmt.Sprintf("Getting certificate %s", data.Id.ValueString()))
 	certResp, httpResp, err := r.client.CertificateAPI.CertificateGetId(ctx, data.Id.ValueString()).Execute()
 	if err != nil {
@@ -536,15 +654,21 @@ func (r *CertificateResource) Read(ctx context.Context, req resource.ReadRequest
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Failed to get certificate", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to get certificate", err, readTimeout)
 		return
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Successfully got certificate %s", data.Id.ValueString()))
 
+	prior := data
 	cert := certResp.GetCertificate()
 	fillResourceFromCertificate(&data, toCertificate(&cert))
 
+	if err := fillCertificateChain(ctx, r.client, &data, prior); err != nil {
+		addOperationError(ctx, &resp.Diagnostics, "Failed to retrieve certificate trust chain", err, readTimeout)
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -567,6 +691,14 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	updateTimeout, diags := data.Timeouts.Update(ctx, defaultUpdateTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Preserve existing PKCS12 and password from state only when not in write-only mode
 	if !data.Pkcs12WriteOnly.ValueBool() {
 		data.Pkcs12 = prior.Pkcs12
@@ -579,6 +711,14 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 		data.Password = types.StringNull()
 	}
 
+	effective, diags := effectiveLabels(ctx, r.defaults.Labels, data.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.EffectiveLabels = effective
+	setEffectiveMetadata(&data, r.defaults)
+
 	// ModifyPlan flips renewal_trigger to Unknown when the cert enters its
 	// renew_before window, so an Unknown planned value is the renewal signal.
 	renewRequested := data.RenewalTrigger.IsUnknown()
@@ -587,13 +727,8 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 	if renewRequested {
 		tflog.Info(ctx, fmt.Sprintf("Renewing certificate %s via WebRA renew", certID))
 
-		renewTemplate := models.NewWebRARenewRequestTemplateWithDefaults()
-		if !data.Csr.IsNull() && !data.Csr.IsUnknown() && data.Csr.ValueString() != "" {
-			renewTemplate.SetCsr(data.Csr.ValueString())
-		}
-		if !data.KeyType.IsNull() && !data.KeyType.IsUnknown() && data.KeyType.ValueString() != "" {
-			renewTemplate.SetKeyType(data.KeyType.ValueString())
-		}
+		rotateKey := keyRotationRequired(data, prior)
+		renewTemplate := buildRenewTemplate(data, prior, rotateKey)
 
 		renewSubmit := models.NewWebRARenewRequestOnSubmit(webRAModule, workflowRenew)
 		renewSubmit.SetCertificateId(certID)
@@ -608,7 +743,7 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 			RequestSubmitRequest(models.WebRARenewRequestOnSubmitAsRequestSubmitRequest(renewSubmit)).
 			Execute()
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to renew certificate", err.Error())
+			addOperationError(ctx, &resp.Diagnostics, "Failed to renew certificate", err, updateTimeout)
 			return
 		}
 
@@ -621,13 +756,38 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 
 		certResp, _, err := r.client.CertificateAPI.CertificateGetId(ctx, renewedCert.Id).Execute()
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to fetch renewed certificate", err.Error())
+			addOperationError(ctx, &resp.Diagnostics, "Failed to fetch renewed certificate", err, updateTimeout)
 			return
 		}
 		normalized := certResp.GetCertificate()
 		fillResourceFromCertificate(&data, toCertificate(&normalized))
 		certID = data.Id.ValueString()
 
+		if err := fillCertificateChain(ctx, r.client, &data, prior); err != nil {
+			// Keep track of the renewed certificate before reporting the failure.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			addOperationError(ctx, &resp.Diagnostics, "Failed to retrieve the trust chain of the renewed certificate", err, updateTimeout)
+			return
+		}
+
+		thirdParties := make([]string, 0, len(data.WaitForThirdParties.Elements()))
+		resp.Diagnostics.Append(data.WaitForThirdParties.ElementsAs(ctx, &thirdParties, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(thirdParties) > 0 {
+			polled, err := waitForThirdParties(ctx, r.client, certID, thirdParties, updateTimeout)
+			if polled != nil {
+				fillResourceFromCertificate(&data, polled)
+			}
+			if err != nil {
+				// Keep track of the renewed certificate before reporting the failure.
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+				addOperationError(ctx, &resp.Diagnostics, "Failed to verify third parties after renewal", err, updateTimeout)
+				return
+			}
+		}
+
 		if renewed.Pkcs12.IsSet() && renewed.Pkcs12.Get() != nil && !data.Pkcs12WriteOnly.ValueBool() {
 			data.Pkcs12 = types.StringValue(renewed.Pkcs12.Get().GetValue())
 		} else if data.Pkcs12WriteOnly.ValueBool() {
@@ -638,6 +798,8 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 		} else if data.PasswordWriteOnly.ValueBool() {
 			data.Password = types.StringNull()
 		}
+
+		resp.Diagnostics.Append(keyRotationDiagnostics(ctx, prior, data, rotateKey)...)
 	}
 
 	if renewRequested && !metadataChanged(data, prior) {
@@ -645,34 +807,10 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	template := models.NewWebRAUpdateRequestTemplateWithDefaults()
-
-	labels := make([]certificateLabelModel, 0, len(data.Labels.Elements()))
-	resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &labels, false)...)
-	labelElements := make([]models.RequestLabelElement, 0, len(labels))
-	for _, label := range labels {
-		el := models.RequestLabelElement{Label: label.Label.ValueString()}
-		el.SetValue(label.Value.ValueString())
-		labelElements = append(labelElements, el)
-	}
-	template.SetLabels(labelElements)
-
-	if !data.Owner.IsNull() {
-		owner := models.NewCertificateOwnerElementWithDefaults()
-		owner.SetValue(data.Owner.ValueString())
-		template.SetOwner(*owner)
-	}
-
-	if !data.Team.IsNull() {
-		team := models.NewCertificateTeamElementWithDefaults()
-		team.SetValue(data.Team.ValueString())
-		template.SetTeam(*team)
-	}
-
-	if !data.ContactEmail.IsNull() {
-		contact := models.NewCertificateContactEmailElementWithDefaults()
-		contact.SetValue(data.ContactEmail.ValueString())
-		template.SetContactEmail(*contact)
+	template, diags := buildUpdateTemplate(ctx, data, prior, r.defaults)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	submit := models.NewWebRAUpdateRequestOnSubmit(*template, workflowUpdate)
@@ -682,7 +820,7 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 		RequestSubmitRequest(models.WebRAUpdateRequestOnSubmitAsRequestSubmitRequest(submit)).
 		Execute()
 	if err != nil {
-		resp.Diagnostics.AddError("Failed to update certificate", err.Error())
+		addOperationError(ctx, &resp.Diagnostics, "Failed to update certificate", err, updateTimeout)
 		return
 	}
 
@@ -694,6 +832,15 @@ func (r *CertificateResource) Update(ctx context.Context, req resource.UpdateReq
 
 	fillResourceFromCertificate(&data, cert)
 
+	// The planned certificate is unknown until Horizon returns it, so the chain
+	// can only be resolved now. A renewal already resolved it above.
+	if !renewRequested {
+		if err := fillCertificateChain(ctx, r.client, &data, prior); err != nil {
+			addOperationError(ctx, &resp.Diagnostics, "Failed to retrieve certificate trust chain", err, updateTimeout)
+			return
+		}
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -709,6 +856,14 @@ func (r *CertificateResource) Delete(ctx context.Context, req resource.DeleteReq
 	}
 
 	if data.RevokeOnDelete.ValueBool() {
+		deleteTimeout, diags := data.Timeouts.Delete(ctx, defaultDeleteTimeout)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+		defer cancel()
+
 		revokeTemplate := models.NewWebRARevokeRequestTemplateWithDefaults()
 		reason := revocationReason
 		revokeTemplate.RevocationReason.Set(&reason)
@@ -720,7 +875,7 @@ func (r *CertificateResource) Delete(ctx context.Context, req resource.DeleteReq
 			RequestSubmitRequest(models.WebRARevokeRequestOnSubmitAsRequestSubmitRequest(submit)).
 			Execute()
 		if err != nil {
-			resp.Diagnostics.AddError("Failed to revoke certificate", err.Error())
+			addOperationError(ctx, &resp.Diagnostics, "Failed to revoke certificate", err, deleteTimeout)
 		}
 	}
 }
@@ -758,26 +913,80 @@ func (r CertificateResource) ValidateConfig(ctx context.Context, req resource.Va
 		if data.PasswordWriteOnly.ValueBool() {
 			resp.Diagnostics.AddAttributeWarning(path.Root("password_write_only"), "password_write_only has no effect when csr is provided (decentralized enrollment).", "")
 		}
+
+		if data.RotateKeyOnRenew.ValueBool() {
+			resp.Diagnostics.AddAttributeWarning(path.Root("rotate_key_on_renew"), "rotate_key_on_renew has no effect when csr is provided (decentralized enrollment).", "Regenerate the CSR-producing resource to renew with a new key.")
+		}
+	}
+
+	if !data.ChainOrder.IsNull() && !data.ChainOrder.IsUnknown() {
+		if _, ok := orderToHorizon[data.ChainOrder.ValueString()]; !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("chain_order"),
+				"Invalid chain_order value",
+				fmt.Sprintf("chain_order must be one of %q, %q, %q, or %q.",
+					trustChainOrderLeafToRoot,
+					trustChainOrderRootToLeaf,
+					trustChainOrderIssuerLeafToRoot,
+					trustChainOrderIssuerRootToLeaf),
+			)
+		}
 	}
 }
 
 func (r *CertificateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
-	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+	if req.Plan.Raw.IsNull() {
 		return
 	}
 
-	var state, plan certificateResourceModel
-	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	var plan certificateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if !isInRenewalWindow(state.NotAfter, plan.RenewBefore, time.Now()) {
+	// Surface the labels merged with the provider defaults in the plan.
+	effective, diags := effectiveLabels(ctx, r.defaults.Labels, plan.Labels)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.EffectiveLabels = effective
+	setEffectiveMetadata(&plan, r.defaults)
+
+	// Check the labels against their definitions, only when they change so
+	// that existing certificates keep planning if the definitions evolve.
+	var priorLabels types.Map
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("effective_labels"), &priorLabels)...)
+	}
+	if !effective.Equal(priorLabels) {
+		resp.Diagnostics.Append(checkCertificateLabels(ctx, r.labels, effective)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	tflog.Info(ctx, fmt.Sprintf("Certificate %s is in its renewal window (expires at %s).", state.Id.ValueString(), time.UnixMilli(state.NotAfter.ValueInt64())))
+	if req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	var state certificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	switch {
+	case isInRenewalWindow(state.NotAfter, plan.RenewBefore, time.Now()):
+		tflog.Info(ctx, fmt.Sprintf("Certificate %s is in its renewal window (expires at %s).", state.Id.ValueString(), time.UnixMilli(state.NotAfter.ValueInt64())))
+	case keyTypeChanged(plan, state):
+		tflog.Info(ctx, fmt.Sprintf("Certificate %s key type changes from %s to %s; planning a renewal with a new key.", state.Id.ValueString(), state.KeyType.ValueString(), plan.KeyType.ValueString()))
+	default:
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
 
 	plan.RenewalTrigger = types.StringUnknown()
 	plan.Id = types.StringUnknown()
@@ -790,6 +999,12 @@ func (r *CertificateResource) ModifyPlan(ctx context.Context, req resource.Modif
 	plan.SigningAlgorithm = types.StringUnknown()
 	plan.NotBefore = types.Int64Unknown()
 	plan.NotAfter = types.Int64Unknown()
+	plan.ThirdPartyData = types.ListUnknown(thirdPartyDataObjectType)
+	if plan.IncludeChain.ValueBool() {
+		plan.ChainPem = types.StringUnknown()
+		plan.FullchainPem = types.StringUnknown()
+	}
+	unknownCertificateDetails(&plan)
 
 	if plan.Csr.IsNull() {
 		if !plan.Pkcs12WriteOnly.ValueBool() {
@@ -820,9 +1035,15 @@ func fillResourceFromCertificate(d *certificateResourceModel, certificate *model
 	} else {
 		d.RevocationDate = types.Int64Value(0)
 	}
-	d.KeyType = types.StringValue(certificate.KeyType)
+	// Keep the configured notation of an equivalent key type, so that Horizon
+	// spelling it differently does not show as a change.
+	if !sameKeyType(d.KeyType, certificate.KeyType) {
+		d.KeyType = types.StringValue(certificate.KeyType)
+	}
 	d.SigningAlgorithm = types.StringValue(certificate.SigningAlgorithm)
 	d.RenewalTrigger = types.StringValue(renewalTriggerFor(certificate.NotAfter))
+	d.ThirdPartyData = thirdPartyDataValue(certificate)
+	fillCertificateDetails(d, certificate)
 }
 
 func renewalTriggerFor(notAfter int64) string {
@@ -866,11 +1087,198 @@ func extractUpdatedCertificate(resp *models.RequestSubmit201Response) (*models.C
 	return cert, diags
 }
 
+// buildUpdateTemplate builds the WebRA update template for the planned
+// metadata. Owner, team, contact email and labels that were sent in the prior
+// state but are no longer effective, whether removed from the resource or from
+// the provider defaults, are sent without a value, so that Horizon clears them
+// instead of keeping the previous values.
+func buildUpdateTemplate(ctx context.Context, plan, prior certificateResourceModel, defaults defaultMetadata) (*models.WebRAUpdateRequestTemplate, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	template := models.NewWebRAUpdateRequestTemplateWithDefaults()
+
+	labels, d := labelElements(ctx, plan.EffectiveLabels)
+	diags.Append(d...)
+
+	priorLabels := prior.EffectiveLabels
+	if priorLabels.IsNull() || priorLabels.IsUnknown() {
+		// State written before effective_labels existed.
+		priorLabels, d = effectiveLabels(ctx, defaults.Labels, prior.Labels)
+		diags.Append(d...)
+	}
+	previous, d := labelElements(ctx, priorLabels)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	kept := make(map[string]struct{}, len(labels))
+	for _, label := range labels {
+		kept[label.Label] = struct{}{}
+	}
+	for _, label := range previous {
+		if _, ok := kept[label.Label]; !ok {
+			labels = append(labels, models.RequestLabelElement{Label: label.Label})
+		}
+	}
+	template.SetLabels(labels)
+
+	if v := plan.EffectiveOwner; !v.IsNull() {
+		owner := models.NewCertificateOwnerElementWithDefaults()
+		owner.SetValue(v.ValueString())
+		template.SetOwner(*owner)
+	} else if !priorEffectiveString(prior.EffectiveOwner, prior.Owner, defaults.Owner).IsNull() {
+		template.SetOwner(*models.NewCertificateOwnerElementWithDefaults())
+	}
+
+	if v := plan.EffectiveTeam; !v.IsNull() {
+		team := models.NewCertificateTeamElementWithDefaults()
+		team.SetValue(v.ValueString())
+		template.SetTeam(*team)
+	} else if !priorEffectiveString(prior.EffectiveTeam, prior.Team, defaults.Team).IsNull() {
+		template.SetTeam(*models.NewCertificateTeamElementWithDefaults())
+	}
+
+	if v := plan.EffectiveContactEmail; !v.IsNull() {
+		contact := models.NewCertificateContactEmailElementWithDefaults()
+		contact.SetValue(v.ValueString())
+		template.SetContactEmail(*contact)
+	} else if !priorEffectiveString(prior.EffectiveContactEmail, prior.ContactEmail, defaults.ContactEmail).IsNull() {
+		template.SetContactEmail(*models.NewCertificateContactEmailElementWithDefaults())
+	}
+
+	return template, diags
+}
+
+// setEffectiveMetadata fills the owner, team and contact email sent to
+// Horizon, falling back to the provider defaults.
+func setEffectiveMetadata(data *certificateResourceModel, defaults defaultMetadata) {
+	data.EffectiveOwner = effectiveString(data.Owner, defaults.Owner)
+	data.EffectiveTeam = effectiveString(data.Team, defaults.Team)
+	data.EffectiveContactEmail = effectiveString(data.ContactEmail, defaults.ContactEmail)
+}
+
+// priorEffectiveString returns the effective value recorded in the prior
+// state. States written before the effective values existed only record the
+// resource value, which is resolved against the current defaults.
+func priorEffectiveString(effective, value types.String, def string) types.String {
+	if !effective.IsNull() {
+		return effective
+	}
+	return effectiveString(value, def)
+}
+
+// ecCurveAliases maps the other names of the elliptic curves Horizon supports
+// to the ones of its key types.
+var ecCurveAliases = map[string]string{
+	"p256":       "secp256r1",
+	"prime256v1": "secp256r1",
+	"p384":       "secp384r1",
+	"p521":       "secp521r1",
+}
+
+// normalizeKeyType returns the canonical notation of a key type, so that
+// `RSA_2048` and `rsa-2048`, or `ec-p256` and `ec-secp256r1`, compare equal.
+func normalizeKeyType(keyType string) string {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(keyType)), "_", "-")
+	algorithm, parameter, found := strings.Cut(normalized, "-")
+	if !found {
+		return normalized
+	}
+	if algorithm == "ecdsa" {
+		algorithm = "ec"
+	}
+	if curve, ok := ecCurveAliases[parameter]; ok && algorithm == "ec" {
+		parameter = curve
+	}
+	return algorithm + "-" + parameter
+}
+
+// sameKeyType reports whether keyType is a notation of the Horizon key type
+// horizonKeyType.
+func sameKeyType(keyType types.String, horizonKeyType string) bool {
+	if keyType.IsNull() || keyType.IsUnknown() {
+		return false
+	}
+	return normalizeKeyType(keyType.ValueString()) == normalizeKeyType(horizonKeyType)
+}
+
+// keyTypeChanged reports whether a centralized enrollment is configured with a
+// key type other than the one of its current certificate.
+func keyTypeChanged(plan, prior certificateResourceModel) bool {
+	if !plan.Csr.IsNull() || plan.KeyType.IsNull() || plan.KeyType.IsUnknown() || prior.KeyType.IsNull() || prior.KeyType.IsUnknown() {
+		return false
+	}
+	return !sameKeyType(plan.KeyType, prior.KeyType.ValueString())
+}
+
+// keyRotationRequired reports whether a centralized renewal must produce a new
+// key pair, either because the policy requires it or because the key type
+// changes.
+func keyRotationRequired(plan, prior certificateResourceModel) bool {
+	if !plan.Csr.IsNull() {
+		return false
+	}
+	return plan.RotateKeyOnRenew.ValueBool() || keyTypeChanged(plan, prior)
+}
+
+// buildRenewTemplate builds the WebRA renew template. Decentralized renewals
+// forward the CSR. The renew template has no explicit rekey option: Horizon
+// generates a new key pair of the template key type for a centralized renewal
+// carrying one, and otherwise applies the key policy of the profile, which
+// defaults to keeping the current key pair (WebRA renew request, `keyType`
+// template field, Horizon API reference). The key type is therefore only sent
+// when a new key is required, the planned one taking precedence over the
+// current one.
+func buildRenewTemplate(plan, prior certificateResourceModel, rotateKey bool) *models.WebRARenewRequestTemplate {
+	template := models.NewWebRARenewRequestTemplateWithDefaults()
+	if !plan.Csr.IsNull() && !plan.Csr.IsUnknown() && plan.Csr.ValueString() != "" {
+		template.SetCsr(plan.Csr.ValueString())
+	}
+	if !rotateKey {
+		return template
+	}
+	if !plan.KeyType.IsNull() && !plan.KeyType.IsUnknown() && plan.KeyType.ValueString() != "" {
+		template.SetKeyType(plan.KeyType.ValueString())
+	} else if prior.KeyType.ValueString() != "" {
+		template.SetKeyType(prior.KeyType.ValueString())
+	}
+	return template
+}
+
+// keyRotationDiagnostics logs the public key thumbprints before and after a
+// renewal. Only a required key rotation that did not happen, because the key
+// policy of the profile conflicts with it, is a warning: the renewed
+// certificate already exists in Horizon and must be kept in state.
+func keyRotationDiagnostics(ctx context.Context, prior, renewed certificateResourceModel, rotateKey bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	oldThumbprint := prior.PublicKeyThumbprint.ValueString()
+	newThumbprint := renewed.PublicKeyThumbprint.ValueString()
+
+	if rotateKey && oldThumbprint != "" && oldThumbprint == newThumbprint {
+		diags.AddWarning(
+			"Certificate key was not rotated on renewal",
+			fmt.Sprintf("Certificate %s was renewed as %s for the previous public key %s.\n\nA new key pair was required (rotate_key_on_renew or a key_type change) but Horizon renewed the certificate with the previous key pair. Check the key policy of the certificate's profile.",
+				prior.Id.ValueString(), renewed.Id.ValueString(), oldThumbprint),
+		)
+		return diags
+	}
+	tflog.Info(ctx, fmt.Sprintf("Certificate %s renewed as %s", prior.Id.ValueString(), renewed.Id.ValueString()), map[string]interface{}{
+		"previous_public_key_thumbprint": oldThumbprint,
+		"public_key_thumbprint":          newThumbprint,
+		"key_rotated":                    oldThumbprint != newThumbprint,
+	})
+	return diags
+}
+
 func metadataChanged(plan, prior certificateResourceModel) bool {
 	return !plan.Owner.Equal(prior.Owner) ||
 		!plan.Team.Equal(prior.Team) ||
 		!plan.ContactEmail.Equal(prior.ContactEmail) ||
-		!plan.Labels.Equal(prior.Labels)
+		!plan.Labels.Equal(prior.Labels) ||
+		(!plan.EffectiveLabels.IsNull() && !plan.EffectiveLabels.Equal(prior.EffectiveLabels)) ||
+		!plan.EffectiveOwner.Equal(prior.EffectiveOwner) ||
+		!plan.EffectiveTeam.Equal(prior.EffectiveTeam) ||
+		!plan.EffectiveContactEmail.Equal(prior.EffectiveContactEmail)
 }
 
 func isInRenewalWindow(notAfter types.Int64, renewBeforeDays types.Int64, now time.Time) bool {
@@ -941,19 +1349,3 @@ func validateWriteOnlyFlags(data certificateResourceModel) diag.Diagnostics {
 	}
 	return diags
 }
-
-func hasThirdParties(thirdPartyData []models.ThirdPartyItem, thirdParties []string) bool {
-	// build a set of connectors present in the certificate
-	connectors := make(map[string]struct{}, len(thirdPartyData))
-	for _, tpData := range thirdPartyData {
-		connectors[tpData.Connector] = struct{}{}
-	}
-
-	// check that all expected IDs are present
-	for _, tp := range thirdParties {
-		if _, ok := connectors[tp]; !ok {
-			return false
-		}
-	}
-	return true
-}
@@ -1,6 +1,8 @@
 package provider
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -281,21 +283,6 @@ func containsErrorSummary(diags diag.Diagnostics, want string) bool {
 }
 
 func TestMetadataChanged(t *testing.T) {
-	labelObjType := types.ObjectType{AttrTypes: map[string]attr.Type{
-		"label": types.StringType,
-		"value": types.StringType,
-	}}
-	labelSet := func(pairs ...[2]string) types.Set {
-		elements := make([]attr.Value, 0, len(pairs))
-		for _, p := range pairs {
-			elements = append(elements, types.ObjectValueMust(labelObjType.AttrTypes, map[string]attr.Value{
-				"label": types.StringValue(p[0]),
-				"value": types.StringValue(p[1]),
-			}))
-		}
-		return types.SetValueMust(labelObjType, elements)
-	}
-
 	tests := []struct {
 		name  string
 		plan  certificateResourceModel
@@ -308,13 +295,13 @@ func TestMetadataChanged(t *testing.T) {
 				Owner:        types.StringValue("alice"),
 				Team:         types.StringValue("platform"),
 				ContactEmail: types.StringValue("alice@example.com"),
-				Labels:       labelSet([2]string{"env", "prod"}),
+				Labels:       labelSetOf([2]string{"env", "prod"}),
 			},
 			prior: certificateResourceModel{
 				Owner:        types.StringValue("alice"),
 				Team:         types.StringValue("platform"),
 				ContactEmail: types.StringValue("alice@example.com"),
-				Labels:       labelSet([2]string{"env", "prod"}),
+				Labels:       labelSetOf([2]string{"env", "prod"}),
 			},
 			want: false,
 		},
@@ -322,11 +309,11 @@ func TestMetadataChanged(t *testing.T) {
 			name: "owner changed",
 			plan: certificateResourceModel{
 				Owner:  types.StringValue("bob"),
-				Labels: labelSet(),
+				Labels: labelSetOf(),
 			},
 			prior: certificateResourceModel{
 				Owner:  types.StringValue("alice"),
-				Labels: labelSet(),
+				Labels: labelSetOf(),
 			},
 			want: true,
 		},
@@ -334,11 +321,11 @@ func TestMetadataChanged(t *testing.T) {
 			name: "team changed",
 			plan: certificateResourceModel{
 				Team:   types.StringValue("security"),
-				Labels: labelSet(),
+				Labels: labelSetOf(),
 			},
 			prior: certificateResourceModel{
 				Team:   types.StringValue("platform"),
-				Labels: labelSet(),
+				Labels: labelSetOf(),
 			},
 			want: true,
 		},
@@ -346,11 +333,11 @@ func TestMetadataChanged(t *testing.T) {
 			name: "contact_email changed",
 			plan: certificateResourceModel{
 				ContactEmail: types.StringValue("bob@example.com"),
-				Labels:       labelSet(),
+				Labels:       labelSetOf(),
 			},
 			prior: certificateResourceModel{
 				ContactEmail: types.StringValue("alice@example.com"),
-				Labels:       labelSet(),
+				Labels:       labelSetOf(),
 			},
 			want: true,
 		},
@@ -358,51 +345,137 @@ func TestMetadataChanged(t *testing.T) {
 			name: "owner cleared (set in state, null in plan)",
 			plan: certificateResourceModel{
 				Owner:  types.StringNull(),
-				Labels: labelSet(),
+				Labels: labelSetOf(),
 			},
 			prior: certificateResourceModel{
 				Owner:  types.StringValue("alice"),
-				Labels: labelSet(),
+				Labels: labelSetOf(),
+			},
+			want: true,
+		},
+		{
+			name: "owner added (null in state, set in plan)",
+			plan: certificateResourceModel{
+				Owner:  types.StringValue("alice"),
+				Labels: labelSetOf(),
+			},
+			prior: certificateResourceModel{
+				Owner:  types.StringNull(),
+				Labels: labelSetOf(),
+			},
+			want: true,
+		},
+		{
+			name: "team cleared (set in state, null in plan)",
+			plan: certificateResourceModel{
+				Team:   types.StringNull(),
+				Labels: labelSetOf(),
+			},
+			prior: certificateResourceModel{
+				Team:   types.StringValue("platform"),
+				Labels: labelSetOf(),
+			},
+			want: true,
+		},
+		{
+			name: "contact_email cleared (set in state, null in plan)",
+			plan: certificateResourceModel{
+				ContactEmail: types.StringNull(),
+				Labels:       labelSetOf(),
+			},
+			prior: certificateResourceModel{
+				ContactEmail: types.StringValue("alice@example.com"),
+				Labels:       labelSetOf(),
+			},
+			want: true,
+		},
+		{
+			name: "labels removed",
+			plan: certificateResourceModel{
+				Labels: labelSetOf(),
+			},
+			prior: certificateResourceModel{
+				Labels: labelSetOf([2]string{"env", "prod"}),
 			},
 			want: true,
 		},
 		{
 			name: "labels added",
 			plan: certificateResourceModel{
-				Labels: labelSet([2]string{"env", "prod"}, [2]string{"tier", "1"}),
+				Labels: labelSetOf([2]string{"env", "prod"}, [2]string{"tier", "1"}),
 			},
 			prior: certificateResourceModel{
-				Labels: labelSet([2]string{"env", "prod"}),
+				Labels: labelSetOf([2]string{"env", "prod"}),
 			},
 			want: true,
 		},
 		{
 			name: "label value flipped",
 			plan: certificateResourceModel{
-				Labels: labelSet([2]string{"env", "staging"}),
+				Labels: labelSetOf([2]string{"env", "staging"}),
 			},
 			prior: certificateResourceModel{
-				Labels: labelSet([2]string{"env", "prod"}),
+				Labels: labelSetOf([2]string{"env", "prod"}),
 			},
 			want: true,
 		},
 		{
 			name: "label set order does not matter (Set semantics)",
 			plan: certificateResourceModel{
-				Labels: labelSet([2]string{"tier", "1"}, [2]string{"env", "prod"}),
+				Labels: labelSetOf([2]string{"tier", "1"}, [2]string{"env", "prod"}),
 			},
 			prior: certificateResourceModel{
-				Labels: labelSet([2]string{"env", "prod"}, [2]string{"tier", "1"}),
+				Labels: labelSetOf([2]string{"env", "prod"}, [2]string{"tier", "1"}),
 			},
 			want: false,
 		},
+		{
+			name: "provider default label changed (effective_labels differ)",
+			plan: certificateResourceModel{
+				Labels: labelSetOf(),
+				EffectiveLabels: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"cost_center": types.StringValue("43"),
+				}),
+			},
+			prior: certificateResourceModel{
+				Labels: labelSetOf(),
+				EffectiveLabels: types.MapValueMust(types.StringType, map[string]attr.Value{
+					"cost_center": types.StringValue("42"),
+				}),
+			},
+			want: true,
+		},
+		{
+			name: "provider default owner removed (effective_owner cleared)",
+			plan: certificateResourceModel{
+				Labels:         labelSetOf(),
+				EffectiveOwner: types.StringNull(),
+			},
+			prior: certificateResourceModel{
+				Labels:         labelSetOf(),
+				EffectiveOwner: types.StringValue("alice"),
+			},
+			want: true,
+		},
+		{
+			name: "provider default team changed (effective_team differs)",
+			plan: certificateResourceModel{
+				Labels:        labelSetOf(),
+				EffectiveTeam: types.StringValue("security"),
+			},
+			prior: certificateResourceModel{
+				Labels:        labelSetOf(),
+				EffectiveTeam: types.StringValue("platform"),
+			},
+			want: true,
+		},
 		{
 			name: "all metadata null on both sides → unchanged",
 			plan: certificateResourceModel{
-				Labels: labelSet(),
+				Labels: labelSetOf(),
 			},
 			prior: certificateResourceModel{
-				Labels: labelSet(),
+				Labels: labelSetOf(),
 			},
 			want: false,
 		},
@@ -416,6 +489,221 @@ func TestMetadataChanged(t *testing.T) {
 	}
 }
 
+func TestBuildUpdateTemplate(t *testing.T) {
+	ctx := context.Background()
+	labels := func(pairs map[string]string) types.Map {
+		m, _ := types.MapValueFrom(ctx, types.StringType, pairs)
+		return m
+	}
+
+	type wantValue struct {
+		sent  bool   // element present in the template
+		value string // expected value, "" meaning an explicit unset
+	}
+
+	tests := []struct {
+		name       string
+		plan       certificateResourceModel
+		prior      certificateResourceModel
+		defaults   defaultMetadata
+		wantOwner  wantValue
+		wantTeam   wantValue
+		wantEmail  wantValue
+		wantLabels map[string]*string // nil value means an explicit unset
+	}{
+		{
+			name: "added metadata is sent with its value",
+			plan: certificateResourceModel{
+				EffectiveOwner:        types.StringValue("alice"),
+				EffectiveTeam:         types.StringValue("platform"),
+				EffectiveContactEmail: types.StringValue("alice@example.com"),
+				EffectiveLabels:       labels(map[string]string{"env": "prod"}),
+			},
+			prior: certificateResourceModel{
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringNull(),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       labels(map[string]string{}),
+			},
+			wantOwner:  wantValue{sent: true, value: "alice"},
+			wantTeam:   wantValue{sent: true, value: "platform"},
+			wantEmail:  wantValue{sent: true, value: "alice@example.com"},
+			wantLabels: map[string]*string{"env": strPtr("prod")},
+		},
+		{
+			name: "changed metadata is sent with the new value",
+			plan: certificateResourceModel{
+				EffectiveOwner:        types.StringValue("bob"),
+				EffectiveTeam:         types.StringValue("security"),
+				EffectiveContactEmail: types.StringValue("bob@example.com"),
+				EffectiveLabels:       labels(map[string]string{"env": "staging"}),
+			},
+			prior: certificateResourceModel{
+				EffectiveOwner:        types.StringValue("alice"),
+				EffectiveTeam:         types.StringValue("platform"),
+				EffectiveContactEmail: types.StringValue("alice@example.com"),
+				EffectiveLabels:       labels(map[string]string{"env": "prod"}),
+			},
+			wantOwner:  wantValue{sent: true, value: "bob"},
+			wantTeam:   wantValue{sent: true, value: "security"},
+			wantEmail:  wantValue{sent: true, value: "bob@example.com"},
+			wantLabels: map[string]*string{"env": strPtr("staging")},
+		},
+		{
+			name: "removed metadata is sent as an explicit unset",
+			plan: certificateResourceModel{
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringNull(),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       labels(map[string]string{"tier": "1"}),
+			},
+			prior: certificateResourceModel{
+				Owner:                 types.StringValue("alice"),
+				Team:                  types.StringValue("platform"),
+				ContactEmail:          types.StringValue("alice@example.com"),
+				EffectiveOwner:        types.StringValue("alice"),
+				EffectiveTeam:         types.StringValue("platform"),
+				EffectiveContactEmail: types.StringValue("alice@example.com"),
+				EffectiveLabels:       labels(map[string]string{"env": "prod", "tier": "1"}),
+			},
+			wantOwner:  wantValue{sent: true},
+			wantTeam:   wantValue{sent: true},
+			wantEmail:  wantValue{sent: true},
+			wantLabels: map[string]*string{"env": nil, "tier": strPtr("1")},
+		},
+		{
+			name: "removed provider defaults are sent as an explicit unset",
+			plan: certificateResourceModel{
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringNull(),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       labels(map[string]string{}),
+			},
+			prior: certificateResourceModel{
+				Owner:                 types.StringNull(),
+				Team:                  types.StringNull(),
+				ContactEmail:          types.StringNull(),
+				EffectiveOwner:        types.StringValue("alice"),
+				EffectiveTeam:         types.StringValue("platform"),
+				EffectiveContactEmail: types.StringValue("alice@example.com"),
+				EffectiveLabels:       labels(map[string]string{}),
+			},
+			wantOwner:  wantValue{sent: true},
+			wantTeam:   wantValue{sent: true},
+			wantEmail:  wantValue{sent: true},
+			wantLabels: map[string]*string{},
+		},
+		{
+			name: "metadata absent on both sides is not sent",
+			plan: certificateResourceModel{
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringNull(),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       labels(map[string]string{}),
+			},
+			prior: certificateResourceModel{
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringNull(),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       labels(map[string]string{}),
+			},
+			wantLabels: map[string]*string{},
+		},
+		{
+			name: "removed resource value falls back to the provider default",
+			plan: certificateResourceModel{
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringValue("platform"),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       labels(map[string]string{}),
+			},
+			prior: certificateResourceModel{
+				Team:                  types.StringValue("security"),
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringValue("security"),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       labels(map[string]string{}),
+			},
+			defaults:   defaultMetadata{Team: "platform"},
+			wantTeam:   wantValue{sent: true, value: "platform"},
+			wantLabels: map[string]*string{},
+		},
+		{
+			name: "prior state without effective values falls back to the resource values",
+			plan: certificateResourceModel{
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringNull(),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       labels(map[string]string{}),
+			},
+			prior: certificateResourceModel{
+				Owner:                 types.StringValue("alice"),
+				Team:                  types.StringNull(),
+				ContactEmail:          types.StringNull(),
+				Labels:                labelSetOf([2]string{"env", "prod"}),
+				EffectiveOwner:        types.StringNull(),
+				EffectiveTeam:         types.StringNull(),
+				EffectiveContactEmail: types.StringNull(),
+				EffectiveLabels:       types.MapNull(types.StringType),
+			},
+			wantOwner:  wantValue{sent: true},
+			wantLabels: map[string]*string{"env": nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, diags := buildUpdateTemplate(ctx, tt.plan, tt.prior, tt.defaults)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			checkValue := func(field string, sent, hasValue bool, value string, want wantValue) {
+				t.Helper()
+				if sent != want.sent {
+					t.Fatalf("%s sent = %v, want %v", field, sent, want.sent)
+				}
+				if !sent {
+					return
+				}
+				if want.value == "" && hasValue {
+					t.Errorf("%s = %q, want an explicit unset", field, value)
+				}
+				if want.value != "" && value != want.value {
+					t.Errorf("%s = %q, want %q", field, value, want.value)
+				}
+			}
+			owner := template.GetOwner()
+			checkValue("owner", template.HasOwner(), owner.HasValue(), owner.GetValue(), tt.wantOwner)
+			team := template.GetTeam()
+			checkValue("team", template.HasTeam(), team.HasValue(), team.GetValue(), tt.wantTeam)
+			contact := template.GetContactEmail()
+			checkValue("contact_email", template.HasContactEmail(), contact.HasValue(), contact.GetValue(), tt.wantEmail)
+
+			got := template.GetLabels()
+			if len(got) != len(tt.wantLabels) {
+				t.Fatalf("labels = %+v, want %d elements", got, len(tt.wantLabels))
+			}
+			for _, label := range got {
+				want, ok := tt.wantLabels[label.Label]
+				if !ok {
+					t.Errorf("unexpected label %q", label.Label)
+					continue
+				}
+				if want == nil && label.HasValue() {
+					t.Errorf("label %q = %q, want an explicit unset", label.Label, label.GetValue())
+				}
+				if want != nil && label.GetValue() != *want {
+					t.Errorf("label %q = %q, want %q", label.Label, label.GetValue(), *want)
+				}
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
 func TestValidateWriteOnlyFlags(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -483,3 +771,138 @@ func TestValidateWriteOnlyFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestKeyRotation(t *testing.T) {
+	centralized := func(keyType types.String, rotate bool) certificateResourceModel {
+		return certificateResourceModel{
+			Csr:              types.StringNull(),
+			KeyType:          keyType,
+			RotateKeyOnRenew: types.BoolValue(rotate),
+		}
+	}
+	prior := certificateResourceModel{Csr: types.StringNull(), KeyType: types.StringValue("rsa-2048")}
+
+	tests := []struct {
+		name        string
+		plan        certificateResourceModel
+		wantChanged bool
+		wantRotate  bool
+		wantKeyType string
+	}{
+		{
+			name:        "same key type, no rotation policy",
+			plan:        centralized(types.StringValue("rsa-2048"), false),
+			wantKeyType: "",
+		},
+		{
+			name:        "key type not configured, no rotation policy",
+			plan:        centralized(types.StringUnknown(), false),
+			wantKeyType: "",
+		},
+		{
+			name:        "rotation policy with the same key type",
+			plan:        centralized(types.StringValue("rsa-2048"), true),
+			wantRotate:  true,
+			wantKeyType: "rsa-2048",
+		},
+		{
+			name:        "rotation policy with unconfigured key type keeps the current type",
+			plan:        centralized(types.StringUnknown(), true),
+			wantRotate:  true,
+			wantKeyType: "rsa-2048",
+		},
+		{
+			name:        "key type change renews with a new key",
+			plan:        centralized(types.StringValue("ec-p256"), false),
+			wantChanged: true,
+			wantRotate:  true,
+			wantKeyType: "ec-p256",
+		},
+		{
+			name:        "key type notation from Horizon is not a change",
+			plan:        centralized(types.StringValue("RSA_2048"), false),
+			wantKeyType: "",
+		},
+		{
+			name: "decentralized enrollment never rotates",
+			plan: certificateResourceModel{
+				Csr:              types.StringValue("-----BEGIN CERTIFICATE REQUEST-----"),
+				KeyType:          types.StringValue("ec-p256"),
+				RotateKeyOnRenew: types.BoolValue(true),
+			},
+			wantKeyType: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := keyTypeChanged(tt.plan, prior); got != tt.wantChanged {
+				t.Errorf("keyTypeChanged() = %v, want %v", got, tt.wantChanged)
+			}
+			rotate := keyRotationRequired(tt.plan, prior)
+			if rotate != tt.wantRotate {
+				t.Errorf("keyRotationRequired() = %v, want %v", rotate, tt.wantRotate)
+			}
+			template := buildRenewTemplate(tt.plan, prior, rotate)
+			if got := template.GetKeyType(); got != tt.wantKeyType {
+				t.Errorf("renew template key type = %q, want %q", got, tt.wantKeyType)
+			}
+		})
+	}
+}
+
+func TestKeyRotationDiagnostics(t *testing.T) {
+	prior := certificateResourceModel{Id: types.StringValue("old"), PublicKeyThumbprint: types.StringValue("aaaa")}
+	sameKey := certificateResourceModel{Id: types.StringValue("new"), PublicKeyThumbprint: types.StringValue("aaaa")}
+	newKey := certificateResourceModel{Id: types.StringValue("new"), PublicKeyThumbprint: types.StringValue("bbbb")}
+
+	tests := []struct {
+		name        string
+		renewed     certificateResourceModel
+		rotate      bool
+		wantSummary string
+	}{
+		{name: "rotation required and performed", renewed: newKey, rotate: true},
+		{name: "rotation required but key reused", renewed: sameKey, rotate: true, wantSummary: "Certificate key was not rotated on renewal"},
+		{name: "key reused without rotation policy", renewed: sameKey, rotate: false},
+		{name: "key rotated by the profile policy", renewed: newKey, rotate: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := keyRotationDiagnostics(t.Context(), prior, tt.renewed, tt.rotate)
+			if diags.HasError() {
+				t.Fatalf("a renewal that happened must not fail the apply: %v", diags)
+			}
+			if tt.wantSummary == "" {
+				if len(diags) != 0 {
+					t.Fatalf("an ordinary renewal must not warn: %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || diags[0].Summary() != tt.wantSummary {
+				t.Fatalf("diagnostics = %v, want a single %q", diags, tt.wantSummary)
+			}
+			if !strings.Contains(diags[0].Detail(), "aaaa") {
+				t.Errorf("detail %q must mention the previous thumbprint", diags[0].Detail())
+			}
+		})
+	}
+}
+
+func TestNormalizeKeyType(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{a: "rsa-2048", b: "RSA-2048", want: true},
+		{a: "rsa_2048", b: "rsa-2048", want: true},
+		{a: "ec-p256", b: "ec-secp256r1", want: true},
+		{a: "ECDSA-prime256v1", b: "ec-secp256r1", want: true},
+		{a: "rsa-2048", b: "rsa-4096", want: false},
+		{a: "ec-p256", b: "ec-secp384r1", want: false},
+	}
+	for _, tt := range tests {
+		if got := normalizeKeyType(tt.a) == normalizeKeyType(tt.b); got != tt.want {
+			t.Errorf("%s and %s: same = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,549 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/crypto/ocsp"
+)
+
+const (
+	revocationMethodAuto = "auto"
+	revocationMethodOCSP = "ocsp"
+	revocationMethodCRL  = "crl"
+
+	revocationStatusGood    = "good"
+	revocationStatusRevoked = "revoked"
+	revocationStatusUnknown = "unknown"
+
+	// revocationCheckTimeout bounds each request to an OCSP responder or CRL
+	// distribution point.
+	revocationCheckTimeout = 30 * time.Second
+
+	maxOCSPResponseSize = 1 << 20
+	maxCRLSize          = 64 << 20
+)
+
+// revocationReasons are the RFC 5280 CRLReason names, by code.
+var revocationReasons = map[int]string{
+	0:  "unspecified",
+	1:  "keyCompromise",
+	2:  "cACompromise",
+	3:  "affiliationChanged",
+	4:  "superseded",
+	5:  "cessationOfOperation",
+	6:  "certificateHold",
+	8:  "removeFromCRL",
+	9:  "privilegeWithdrawn",
+	10: "aACompromise",
+}
+
+func NewCertificateStatusDataSource() datasource.DataSource {
+	return &CertificateStatusDataSource{}
+}
+
+type CertificateStatusDataSource struct {
+	client     *horizon.APIClient
+	httpClient *http.Client
+}
+
+type certificateStatusDataSourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	CertificatePem   types.String `tfsdk:"certificate_pem"`
+	CertificateId    types.String `tfsdk:"certificate_id"`
+	IssuerPem        types.String `tfsdk:"issuer_pem"`
+	Method           types.String `tfsdk:"method"`
+	Status           types.String `tfsdk:"status"`
+	RevocationTime   types.Int64  `tfsdk:"revocation_time"`
+	RevocationReason types.String `tfsdk:"revocation_reason"`
+	Source           types.String `tfsdk:"source"`
+	Responder        types.String `tfsdk:"responder"`
+	ThisUpdate       types.Int64  `tfsdk:"this_update"`
+	NextUpdate       types.Int64  `tfsdk:"next_update"`
+}
+
+func (d *CertificateStatusDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_certificate_status"
+}
+
+func (d *CertificateStatusDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Checks the revocation status of a certificate against the OCSP responders and CRL distribution points it advertises, independently of the Horizon database. " +
+			"With the `auto` method, Horizon runs the check through its RFC 5280 validation API; the provider queries the responders itself, with its `proxy`, `ca_bundle_pem` and `skip_tls_verify` settings, when Horizon cannot answer or answers `unknown`, or when `method` is `ocsp` or `crl`. " +
+			"The issuer needed to build OCSP requests and verify responses is looked up with the Horizon trust chain API unless `issuer_pem` is set. " +
+			"Meant for `check` blocks guarding critical endpoints.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-1 thumbprint of the certificate, in lowercase hexadecimal.",
+			},
+			"certificate_pem": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "PEM-encoded X.509 certificate to check. Exactly one of `certificate_pem` or `certificate_id` must be set; when `certificate_id` is set, this is the PEM fetched from Horizon.",
+			},
+			"certificate_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Horizon id of the certificate to check, such as the `id` of a `horizon_certificate`. Exactly one of `certificate_pem` or `certificate_id` must be set.",
+			},
+			"issuer_pem": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "PEM-encoded certificate of the issuer. Looked up in Horizon when not set.",
+			},
+			"method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "How to check the status. One of `ocsp`, `crl`, or `auto` (OCSP, falling back to the CRL when OCSP is unavailable or answers `unknown`). Defaults to `auto`.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Revocation status: `good`, `revoked`, or `unknown` when the OCSP responder does not know the certificate.",
+			},
+			"revocation_time": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Revocation date, in milliseconds since the epoch. Null unless the certificate is revoked.",
+			},
+			"revocation_reason": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "RFC 5280 revocation reason, such as `keyCompromise` or `superseded`. Null unless the certificate is revoked, or when Horizon gives a reason it does not recognise.",
+			},
+			"source": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Source of the status: `ocsp` or `crl`.",
+			},
+			"responder": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the OCSP responder or CRL distribution point that provided the status.",
+			},
+			"this_update": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Date the status was produced by the OCSP responder or the CRL issuer, in milliseconds since the epoch.",
+			},
+			"next_update": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Date newer status information will be available, in milliseconds since the epoch. Null when not advertised.",
+			},
+		},
+	}
+}
+
+func (d *CertificateStatusDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.client
+	d.httpClient = providerData.httpClient
+}
+
+func (d *CertificateStatusDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data certificateStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Method.IsNull() && !data.Method.IsUnknown() {
+		switch data.Method.ValueString() {
+		case revocationMethodAuto, revocationMethodOCSP, revocationMethodCRL:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("method"),
+				"Invalid method value",
+				fmt.Sprintf("method must be one of %q, %q, or %q.", revocationMethodAuto, revocationMethodOCSP, revocationMethodCRL),
+			)
+		}
+	}
+
+	resp.Diagnostics.Append(validateCertificateInput(data.CertificatePem, data.CertificateId)...)
+}
+
+func (d *CertificateStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data certificateStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	method := revocationMethodAuto
+	if !data.Method.IsNull() && data.Method.ValueString() != "" {
+		method = data.Method.ValueString()
+	}
+
+	certificatePem, diags := resolveCertificatePem(ctx, d.client, data.CertificatePem, data.CertificateId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	certs, err := decodePEMCertificates(certificatePem)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("certificate_pem"), "Invalid certificate_pem", err.Error())
+		return
+	}
+	cert := certs[0]
+
+	var issuer *x509.Certificate
+	if !data.IssuerPem.IsNull() {
+		issuers, err := decodePEMCertificates(data.IssuerPem.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("issuer_pem"), "Invalid issuer_pem", err.Error())
+			return
+		}
+		issuer = issuers[0]
+	} else {
+		chainPems, err := fetchTrustChain(ctx, d.client, certificatePem, trustChainOrderLeafToRoot)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to retrieve the issuer of the certificate", err.Error())
+			return
+		}
+		leafToRoot, err := trustChainLeafToRoot(chainPems, cert, trustChainOrderLeafToRoot)
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to retrieve the issuer of the certificate", err.Error())
+			return
+		}
+		issuer = cert
+		if len(leafToRoot) > 1 {
+			issuer = leafToRoot[1]
+		}
+	}
+
+	var horizonCheck func() (*revocationStatus, error)
+	if method == revocationMethodAuto {
+		horizonCheck = func() (*revocationStatus, error) { return checkRevocationWithHorizon(ctx, d.client, cert) }
+	}
+	status, err := resolveRevocationStatus(ctx, horizonCheck, func() (*revocationStatus, error) {
+		return checkRevocation(ctx, d.httpClient, cert, issuer, method)
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to check the revocation status of the certificate", err.Error())
+		return
+	}
+
+	thumbprint := sha1.Sum(cert.Raw)
+	data.Id = types.StringValue(hex.EncodeToString(thumbprint[:]))
+	data.CertificatePem = types.StringValue(certificatePem)
+	if data.IssuerPem.IsNull() {
+		data.IssuerPem = types.StringValue(encodeCertificatePEM(issuer))
+	}
+	data.Method = types.StringValue(method)
+	data.Status = types.StringValue(status.Status)
+	data.RevocationTime = types.Int64Null()
+	data.RevocationReason = types.StringNull()
+	if status.Status == revocationStatusRevoked {
+		data.RevocationTime = types.Int64Value(status.RevocationTime.UnixMilli())
+		data.RevocationReason = revocationReasonName(status.Reason)
+	}
+	data.Source = types.StringValue(status.Source)
+	data.Responder = types.StringValue(status.Responder)
+	data.ThisUpdate = types.Int64Value(status.ThisUpdate.UnixMilli())
+	data.NextUpdate = types.Int64Null()
+	if !status.NextUpdate.IsZero() {
+		data.NextUpdate = types.Int64Value(status.NextUpdate.UnixMilli())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// revocationStatus is the revocation status of a certificate, as given by an
+// OCSP responder or a CRL.
+type revocationStatus struct {
+	Status         string
+	RevocationTime time.Time
+	// Reason is the RFC 5280 CRLReason code, or -1 when the reason is not
+	// known.
+	Reason     int
+	Source     string
+	Responder  string
+	ThisUpdate time.Time
+	NextUpdate time.Time
+}
+
+// revocationReasonName returns the RFC 5280 name of a revocation reason code,
+// or null when the reason is not known.
+func revocationReasonName(code int) types.String {
+	if code < 0 {
+		return types.StringNull()
+	}
+	if name, ok := revocationReasons[code]; ok {
+		return types.StringValue(name)
+	}
+	return types.StringValue(fmt.Sprintf("unknown (%d)", code))
+}
+
+// revocationReasonCode returns the code of an RFC 5280 revocation reason name,
+// or -1 when Horizon gives no reason or one that is not recognised.
+func revocationReasonCode(name string) int {
+	for code, reason := range revocationReasons {
+		if strings.EqualFold(reason, name) {
+			return code
+		}
+	}
+	return -1
+}
+
+// resolveRevocationStatus runs the Horizon check, when given, and the local
+// check when Horizon cannot answer or answers unknown, the same way the local
+// check falls back from OCSP to the CRL. An unknown status from Horizon is
+// kept when the local check does not know better.
+func resolveRevocationStatus(ctx context.Context, horizonCheck, localCheck func() (*revocationStatus, error)) (*revocationStatus, error) {
+	var status *revocationStatus
+	if horizonCheck != nil {
+		var err error
+		status, err = horizonCheck()
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Horizon could not check the revocation status, checking locally: %s", err))
+			status = nil
+		} else if status.Status != revocationStatusUnknown {
+			return status, nil
+		} else {
+			tflog.Debug(ctx, fmt.Sprintf("Horizon answered an unknown revocation status from %s, checking locally", status.Responder))
+		}
+	}
+
+	local, err := localCheck()
+	if err != nil {
+		if status != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Local revocation check failed, keeping the unknown status from Horizon: %s", err))
+			return status, nil
+		}
+		return nil, err
+	}
+	if status != nil && local.Status == revocationStatusUnknown {
+		return status, nil
+	}
+	return local, nil
+}
+
+// checkRevocationWithHorizon asks Horizon for the revocation status of cert,
+// so that responders only reachable from Horizon are used.
+func checkRevocationWithHorizon(ctx context.Context, client *horizon.APIClient, cert *x509.Certificate) (*revocationStatus, error) {
+	validation, _, err := client.Rfc5280API.Rfc5280Validation(ctx).X509([]byte(encodeCertificatePEM(cert))).Execute()
+	if err != nil {
+		return nil, err
+	}
+	return horizonRevocationStatus(validation)
+}
+
+// horizonRevocationStatus converts the answer of the Horizon RFC 5280
+// validation API.
+func horizonRevocationStatus(validation *models.Rfc5280Validation) (*revocationStatus, error) {
+	if validation == nil {
+		return nil, errors.New("empty validation response")
+	}
+	status := &revocationStatus{
+		Status:    strings.ToLower(validation.GetStatus()),
+		Source:    strings.ToLower(validation.GetSource()),
+		Responder: validation.GetResponder(),
+	}
+	switch status.Status {
+	case revocationStatusGood, revocationStatusUnknown:
+	case revocationStatusRevoked:
+		status.RevocationTime = time.UnixMilli(validation.GetRevocationDate())
+		status.Reason = revocationReasonCode(validation.GetRevocationReason())
+	default:
+		return nil, fmt.Errorf("unexpected revocation status %q", validation.GetStatus())
+	}
+	if status.Source != revocationMethodOCSP && status.Source != revocationMethodCRL {
+		return nil, fmt.Errorf("unexpected revocation status source %q", validation.GetSource())
+	}
+	if validation.ThisUpdate != nil {
+		status.ThisUpdate = time.UnixMilli(validation.GetThisUpdate())
+	}
+	if validation.NextUpdate != nil {
+		status.NextUpdate = time.UnixMilli(validation.GetNextUpdate())
+	}
+	return status, nil
+}
+
+// checkRevocation returns the revocation status of cert, issued by issuer,
+// using the given method.
+func checkRevocation(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate, method string) (*revocationStatus, error) {
+	switch method {
+	case revocationMethodOCSP:
+		return checkOCSP(ctx, client, cert, issuer)
+	case revocationMethodCRL:
+		return checkCRL(ctx, client, cert, issuer)
+	}
+
+	ocspStatus, ocspErr := checkOCSP(ctx, client, cert, issuer)
+	if ocspErr == nil && ocspStatus.Status != revocationStatusUnknown {
+		return ocspStatus, nil
+	}
+	crlStatus, crlErr := checkCRL(ctx, client, cert, issuer)
+	if crlErr == nil {
+		return crlStatus, nil
+	}
+	if ocspErr == nil {
+		return ocspStatus, nil
+	}
+	return nil, errors.Join(ocspErr, crlErr)
+}
+
+// checkOCSP queries the OCSP responders of cert in turn, until one answers.
+func checkOCSP(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate) (*revocationStatus, error) {
+	if len(cert.OCSPServer) == 0 {
+		return nil, errors.New("the certificate has no OCSP responder")
+	}
+	request, err := ocsp.CreateRequest(cert, issuer, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return nil, fmt.Errorf("building the OCSP request: %w", err)
+	}
+
+	var errs []error
+	for _, responder := range cert.OCSPServer {
+		response, err := queryOCSP(ctx, client, responder, request, cert, issuer)
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("OCSP responder %s failed: %s", responder, err))
+			errs = append(errs, fmt.Errorf("OCSP responder %s: %w", responder, err))
+			continue
+		}
+
+		status := &revocationStatus{
+			Source:     revocationMethodOCSP,
+			Responder:  responder,
+			ThisUpdate: response.ThisUpdate,
+			NextUpdate: response.NextUpdate,
+		}
+		switch response.Status {
+		case ocsp.Good:
+			status.Status = revocationStatusGood
+		case ocsp.Revoked:
+			status.Status = revocationStatusRevoked
+			status.RevocationTime = response.RevokedAt
+			status.Reason = response.RevocationReason
+		default:
+			status.Status = revocationStatusUnknown
+		}
+		return status, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+func queryOCSP(ctx context.Context, client *http.Client, responder string, request []byte, cert, issuer *x509.Certificate) (*ocsp.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, responder, bytes.NewReader(request))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/ocsp-request")
+	httpReq.Header.Set("Accept", "application/ocsp-response")
+
+	body, err := fetch(client, httpReq, maxOCSPResponseSize)
+	if err != nil {
+		return nil, err
+	}
+	return ocsp.ParseResponseForCert(body, cert, issuer)
+}
+
+// checkCRL downloads the CRLs of cert in turn, until one is valid.
+func checkCRL(ctx context.Context, client *http.Client, cert, issuer *x509.Certificate) (*revocationStatus, error) {
+	if len(cert.CRLDistributionPoints) == 0 {
+		return nil, errors.New("the certificate has no CRL distribution point")
+	}
+
+	var errs []error
+	for _, distributionPoint := range cert.CRLDistributionPoints {
+		crl, err := downloadCRL(ctx, client, distributionPoint, issuer)
+		if err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("CRL distribution point %s failed: %s", distributionPoint, err))
+			errs = append(errs, fmt.Errorf("CRL distribution point %s: %w", distributionPoint, err))
+			continue
+		}
+
+		status := &revocationStatus{
+			Status:     revocationStatusGood,
+			Source:     revocationMethodCRL,
+			Responder:  distributionPoint,
+			ThisUpdate: crl.ThisUpdate,
+			NextUpdate: crl.NextUpdate,
+		}
+		for _, entry := range crl.RevokedCertificateEntries {
+			if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				status.Status = revocationStatusRevoked
+				status.RevocationTime = entry.RevocationTime
+				status.Reason = entry.ReasonCode
+				break
+			}
+		}
+		return status, nil
+	}
+	return nil, errors.Join(errs...)
+}
+
+func downloadCRL(ctx context.Context, client *http.Client, distributionPoint string, issuer *x509.Certificate) (*x509.RevocationList, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, distributionPoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := fetch(client, httpReq, maxCRLSize)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(body); block != nil && block.Type == "X509 CRL" {
+		body = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(body)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(crl.RawIssuer, issuer.RawSubject) {
+		return nil, fmt.Errorf("the CRL is issued by %q, not by the certificate issuer", crl.Issuer)
+	}
+	if err := crl.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("invalid CRL signature: %w", err)
+	}
+	if !crl.NextUpdate.IsZero() && time.Now().After(crl.NextUpdate) {
+		return nil, fmt.Errorf("the CRL expired on %s", crl.NextUpdate.Format(time.RFC3339))
+	}
+	return crl, nil
+}
+
+// fetch sends req and returns the body of a successful response, up to limit
+// bytes.
+func fetch(client *http.Client, req *http.Request, limit int64) ([]byte, error) {
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", httpResp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("response larger than %d bytes", limit)
+	}
+	return body, nil
+}
@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"golang.org/x/crypto/ocsp"
+)
+
+var (
+	_ datasource.DataSource                   = &CertificateStatusDataSource{}
+	_ datasource.DataSourceWithConfigure      = &CertificateStatusDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &CertificateStatusDataSource{}
+)
+
+// revocationTestPKI is a CA serving an OCSP responder and a CRL for a leaf
+// certificate, revoked or not.
+type revocationTestPKI struct {
+	ca         *testCertificate
+	leaf       *testCertificate
+	ocspStatus int
+	ocspDown   bool
+	crlDown    bool
+	revokedAt  time.Time
+}
+
+func newRevocationTestPKI(t *testing.T) *revocationTestPKI {
+	t.Helper()
+	pki := &revocationTestPKI{
+		ca: newTestCertificate(t, &x509.Certificate{
+			Subject:               pkix.Name{CommonName: "Test CA"},
+			IsCA:                  true,
+			BasicConstraintsValid: true,
+			KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		}, nil),
+		ocspStatus: ocsp.Good,
+		revokedAt:  time.Now().Add(-time.Hour).Truncate(time.Second),
+	}
+
+	ocspServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pki.ocspDown {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		req, err := ocsp.ParseRequest(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		template := ocsp.Response{
+			Status:       pki.ocspStatus,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute).Truncate(time.Second),
+			NextUpdate:   time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+		if pki.ocspStatus == ocsp.Revoked {
+			template.RevokedAt = pki.revokedAt
+			template.RevocationReason = ocsp.KeyCompromise
+		}
+		resp, err := ocsp.CreateResponse(pki.ca.cert, pki.ca.cert, template, pki.ca.key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/ocsp-response")
+		_, _ = w.Write(resp)
+	}))
+	t.Cleanup(ocspServer.Close)
+
+	crlServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pki.crlDown {
+			http.Error(w, "down", http.StatusServiceUnavailable)
+			return
+		}
+		template := &x509.RevocationList{
+			Number:     big.NewInt(1),
+			ThisUpdate: time.Now().Add(-time.Minute).Truncate(time.Second),
+			NextUpdate: time.Now().Add(time.Hour).Truncate(time.Second),
+		}
+		if pki.ocspStatus == ocsp.Revoked {
+			template.RevokedCertificateEntries = []x509.RevocationListEntry{{
+				SerialNumber:   pki.leaf.cert.SerialNumber,
+				RevocationTime: pki.revokedAt,
+				ReasonCode:     ocsp.Superseded,
+			}}
+		}
+		crl, err := x509.CreateRevocationList(rand.Reader, template, pki.ca.cert, pki.ca.key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(crl)
+	}))
+	t.Cleanup(crlServer.Close)
+
+	pki.leaf = newTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "www.example.com"},
+		OCSPServer:            []string{ocspServer.URL},
+		CRLDistributionPoints: []string{crlServer.URL},
+	}, pki.ca)
+	return pki
+}
+
+func TestCheckRevocation(t *testing.T) {
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	t.Run("good over OCSP", func(t *testing.T) {
+		pki := newRevocationTestPKI(t)
+		got, err := checkRevocation(t.Context(), client, pki.leaf.cert, pki.ca.cert, revocationMethodAuto)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != revocationStatusGood || got.Source != revocationMethodOCSP || got.Responder != pki.leaf.cert.OCSPServer[0] {
+			t.Fatalf("got %+v, want good from the OCSP responder", got)
+		}
+		if got.NextUpdate.IsZero() {
+			t.Fatal("want next update from the OCSP response")
+		}
+	})
+
+	t.Run("revoked over OCSP", func(t *testing.T) {
+		pki := newRevocationTestPKI(t)
+		pki.ocspStatus = ocsp.Revoked
+		got, err := checkRevocation(t.Context(), client, pki.leaf.cert, pki.ca.cert, revocationMethodOCSP)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != revocationStatusRevoked || !got.RevocationTime.Equal(pki.revokedAt) {
+			t.Fatalf("got %+v, want revoked at %s", got, pki.revokedAt)
+		}
+		if name := revocationReasonName(got.Reason).ValueString(); name != "keyCompromise" {
+			t.Fatalf("got reason %q, want keyCompromise", name)
+		}
+	})
+
+	t.Run("revoked over CRL", func(t *testing.T) {
+		pki := newRevocationTestPKI(t)
+		pki.ocspStatus = ocsp.Revoked
+		got, err := checkRevocation(t.Context(), client, pki.leaf.cert, pki.ca.cert, revocationMethodCRL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != revocationStatusRevoked || got.Source != revocationMethodCRL || got.Responder != pki.leaf.cert.CRLDistributionPoints[0] {
+			t.Fatalf("got %+v, want revoked from the CRL", got)
+		}
+		if name := revocationReasonName(got.Reason).ValueString(); name != "superseded" {
+			t.Fatalf("got reason %q, want superseded", name)
+		}
+	})
+
+	t.Run("falls back to CRL", func(t *testing.T) {
+		pki := newRevocationTestPKI(t)
+		pki.ocspDown = true
+		got, err := checkRevocation(t.Context(), client, pki.leaf.cert, pki.ca.cert, revocationMethodAuto)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != revocationStatusGood || got.Source != revocationMethodCRL {
+			t.Fatalf("got %+v, want good from the CRL", got)
+		}
+	})
+
+	t.Run("unknown kept when CRL is down", func(t *testing.T) {
+		pki := newRevocationTestPKI(t)
+		pki.ocspStatus = ocsp.Unknown
+		pki.crlDown = true
+		got, err := checkRevocation(t.Context(), client, pki.leaf.cert, pki.ca.cert, revocationMethodAuto)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != revocationStatusUnknown || got.Source != revocationMethodOCSP {
+			t.Fatalf("got %+v, want unknown from the OCSP responder", got)
+		}
+	})
+
+	t.Run("all sources down", func(t *testing.T) {
+		pki := newRevocationTestPKI(t)
+		pki.ocspDown = true
+		pki.crlDown = true
+		if _, err := checkRevocation(t.Context(), client, pki.leaf.cert, pki.ca.cert, revocationMethodAuto); err == nil {
+			t.Fatal("want an error")
+		}
+	})
+
+	t.Run("CRL from another issuer", func(t *testing.T) {
+		pki := newRevocationTestPKI(t)
+		other := newTestCA(t, "Other CA", nil)
+		if _, err := checkRevocation(t.Context(), client, pki.leaf.cert, other.cert, revocationMethodCRL); err == nil {
+			t.Fatal("want an error for a CRL not issued by the given issuer")
+		}
+	})
+
+	t.Run("no responder", func(t *testing.T) {
+		pki := newRevocationTestPKI(t)
+		leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "bare"}}, pki.ca)
+		if _, err := checkRevocation(t.Context(), client, leaf.cert, pki.ca.cert, revocationMethodOCSP); err == nil {
+			t.Fatal("want an error for a certificate without OCSP responder")
+		}
+	})
+}
+
+func TestHorizonRevocationStatus(t *testing.T) {
+	revokedAt := time.Now().Add(-time.Hour).Truncate(time.Millisecond)
+
+	t.Run("revoked", func(t *testing.T) {
+		got, err := horizonRevocationStatus(&models.Rfc5280Validation{
+			Status:           "REVOKED",
+			Source:           strPtr("crl"),
+			Responder:        strPtr("http://crl.example.com/ca.crl"),
+			RevocationDate:   int64Ptr(revokedAt.UnixMilli()),
+			RevocationReason: strPtr("KEYCOMPROMISE"),
+			ThisUpdate:       int64Ptr(revokedAt.UnixMilli()),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != revocationStatusRevoked || got.Source != revocationMethodCRL || !got.RevocationTime.Equal(revokedAt) {
+			t.Fatalf("got %+v, want revoked from the CRL at %s", got, revokedAt)
+		}
+		if name := revocationReasonName(got.Reason).ValueString(); name != "keyCompromise" {
+			t.Fatalf("got reason %q, want keyCompromise", name)
+		}
+		if !got.NextUpdate.IsZero() {
+			t.Fatalf("got next update %s, want none", got.NextUpdate)
+		}
+	})
+
+	t.Run("good", func(t *testing.T) {
+		got, err := horizonRevocationStatus(&models.Rfc5280Validation{Status: "good", Source: strPtr("ocsp")})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != revocationStatusGood || got.Source != revocationMethodOCSP {
+			t.Fatalf("got %+v, want good from OCSP", got)
+		}
+	})
+
+	t.Run("unrecognised reason", func(t *testing.T) {
+		for _, reason := range []*string{nil, strPtr("SOMETHING_NEW")} {
+			got, err := horizonRevocationStatus(&models.Rfc5280Validation{
+				Status:           "revoked",
+				Source:           strPtr("ocsp"),
+				RevocationDate:   int64Ptr(revokedAt.UnixMilli()),
+				RevocationReason: reason,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if name := revocationReasonName(got.Reason); !name.IsNull() {
+				t.Fatalf("got reason %s, want null rather than unspecified", name)
+			}
+		}
+	})
+
+	for name, validation := range map[string]*models.Rfc5280Validation{
+		"empty":          nil,
+		"unknown status": {Status: "expired", Source: strPtr("ocsp")},
+		"no source":      {Status: "good"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := horizonRevocationStatus(validation); err == nil {
+				t.Fatal("want an error, to fall back to local checks")
+			}
+		})
+	}
+}
+
+func TestResolveRevocationStatus(t *testing.T) {
+	good := &revocationStatus{Status: revocationStatusGood, Source: revocationMethodCRL}
+	revoked := &revocationStatus{Status: revocationStatusRevoked, Source: revocationMethodOCSP}
+	unknown := &revocationStatus{Status: revocationStatusUnknown, Source: revocationMethodOCSP}
+	unavailable := errors.New("unavailable")
+	answer := func(status *revocationStatus, err error) func() (*revocationStatus, error) {
+		return func() (*revocationStatus, error) { return status, err }
+	}
+	tests := []struct {
+		name      string
+		horizon   func() (*revocationStatus, error)
+		local     func() (*revocationStatus, error)
+		want      *revocationStatus
+		wantLocal bool
+		wantErr   bool
+	}{
+		{name: "local method only", local: answer(good, nil), want: good, wantLocal: true},
+		{name: "Horizon answers", horizon: answer(revoked, nil), local: answer(good, nil), want: revoked},
+		{name: "Horizon unavailable falls back", horizon: answer(nil, unavailable), local: answer(good, nil), want: good, wantLocal: true},
+		{name: "Horizon unknown falls back", horizon: answer(unknown, nil), local: answer(good, nil), want: good, wantLocal: true},
+		{name: "Horizon unknown kept when the local check fails", horizon: answer(unknown, nil), local: answer(nil, unavailable), want: unknown, wantLocal: true},
+		{name: "Horizon unknown kept when the local check does not know", horizon: answer(unknown, nil), local: answer(&revocationStatus{Status: revocationStatusUnknown}, nil), want: unknown, wantLocal: true},
+		{name: "both unavailable", horizon: answer(nil, unavailable), local: answer(nil, unavailable), wantLocal: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkedLocally := false
+			got, err := resolveRevocationStatus(t.Context(), tt.horizon, func() (*revocationStatus, error) {
+				checkedLocally = true
+				return tt.local()
+			})
+			if checkedLocally != tt.wantLocal {
+				t.Errorf("checked locally = %v, want %v", checkedLocally, tt.wantLocal)
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("got %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
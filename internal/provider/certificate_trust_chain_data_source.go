@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	horizon "github.com/evertrust/horizon-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -21,6 +24,12 @@ const (
 	trustChainOrderIssuerRootToLeaf = "issuer_root_to_leaf"
 )
 
+const (
+	trustChainVerifyOff   = "off"
+	trustChainVerifyWarn  = "warn"
+	trustChainVerifyError = "error"
+)
+
 // orderToHorizon maps the provider-side order values to the Horizon API values.
 var orderToHorizon = map[string]string{
 	trustChainOrderLeafToRoot:       "ltr",
@@ -38,12 +47,20 @@ type CertificateTrustChainDataSource struct {
 }
 
 type certificateTrustChainDataSourceModel struct {
-	Id             types.String `tfsdk:"id"`
-	CertificatePem types.String `tfsdk:"certificate_pem"`
-	Order          types.String `tfsdk:"order"`
-	Chain          types.List   `tfsdk:"chain"`
-	ChainPem       types.String `tfsdk:"chain_pem"`
-	Length         types.Int64  `tfsdk:"length"`
+	Id               types.String `tfsdk:"id"`
+	CertificatePem   types.String `tfsdk:"certificate_pem"`
+	CertificateId    types.String `tfsdk:"certificate_id"`
+	Order            types.String `tfsdk:"order"`
+	IncludeLeaf      types.Bool   `tfsdk:"include_leaf"`
+	IncludeRoot      types.Bool   `tfsdk:"include_root"`
+	Verify           types.String `tfsdk:"verify"`
+	Chain            types.List   `tfsdk:"chain"`
+	ChainPem         types.String `tfsdk:"chain_pem"`
+	Length           types.Int64  `tfsdk:"length"`
+	LeafPem          types.String `tfsdk:"leaf_pem"`
+	IntermediatesPem types.String `tfsdk:"intermediates_pem"`
+	RootPem          types.String `tfsdk:"root_pem"`
+	ValidUntil       types.Int64  `tfsdk:"valid_until"`
 }
 
 func (d *CertificateTrustChainDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -52,21 +69,41 @@ func (d *CertificateTrustChainDataSource) Metadata(ctx context.Context, req data
 
 func (d *CertificateTrustChainDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		Description: "Fetches the trust chain of a PEM-encoded X.509 certificate from Horizon. The chain order returned by Horizon is preserved as-is.",
+		MarkdownDescription: "Fetches the trust chain of an X.509 certificate from Horizon, given either its PEM or its Horizon id. The chain order returned by Horizon is preserved as-is.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Computed:    true,
-				Description: "SHA-256 (hex) of the concatenated PEM chain returned by Horizon, in the requested order. Two reads with the same input certificate but different `order` values produce different ids.",
+				Computed:            true,
+				MarkdownDescription: "SHA-256 (hex) of `chain_pem`. Two reads with the same input certificate but different `order` values produce different ids.",
 			},
 			"certificate_pem": schema.StringAttribute{
-				Required:    true,
-				Description: "PEM-encoded X.509 certificate to look up the trust chain for.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "PEM-encoded X.509 certificate to look up the trust chain for. Exactly one of `certificate_pem` or `certificate_id` must be set; when `certificate_id` is set, this is the PEM fetched from Horizon.",
+			},
+			"certificate_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Horizon id of the certificate to look up the trust chain for, such as the `id` of a `horizon_certificate`. Exactly one of `certificate_pem` or `certificate_id` must be set.",
 			},
 			"order": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
 				MarkdownDescription: "Order of the returned chain. One of `leaf_to_root`, `root_to_leaf`, `issuer_leaf_to_root`, `issuer_root_to_leaf`. Defaults to `root_to_leaf`.",
 			},
+			"include_leaf": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether `chain` and `chain_pem` include the certificate itself. The `issuer_*` orders never include it. Defaults to `true`.",
+			},
+			"include_root": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether `chain` and `chain_pem` include the self-signed root. Set to `false` to build a web server full chain. Defaults to `true`.",
+			},
+			"verify": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Local verification of the chain returned by Horizon: each certificate must be signed by the next one up to a self-signed root, and the validity periods of all certificates must overlap. One of `off`, `warn` (report problems as warnings) or `error` (fail the read). Defaults to `off`.",
+			},
 			"chain": schema.ListAttribute{
 				Computed:    true,
 				ElementType: types.StringType,
@@ -80,6 +117,22 @@ func (d *CertificateTrustChainDataSource) Schema(ctx context.Context, req dataso
 				Computed:    true,
 				Description: "Number of certificates in the returned chain.",
 			},
+			"leaf_pem": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "PEM-encoded certificate whose chain was looked up, whatever `order` and `include_leaf`.",
+			},
+			"intermediates_pem": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Concatenated PEM bundle of the certificates between the leaf and the root, in the requested order. Empty when the leaf is directly issued by the root.",
+			},
+			"root_pem": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "PEM-encoded self-signed root of the chain, whatever `include_root`, e.g. for a Kubernetes `ca.crt`. Null when Horizon does not know the root.",
+			},
+			"valid_until": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Earliest expiration date of the certificate and of the certificates of its chain, in milliseconds since the epoch: the date the chain stops being usable.",
+			},
 		},
 	}
 }
@@ -89,16 +142,16 @@ func (d *CertificateTrustChainDataSource) Configure(ctx context.Context, req dat
 		return
 	}
 
-	client, ok := req.ProviderData.(*horizon.APIClient)
+	providerData, ok := req.ProviderData.(*horizonProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *horizon.APIClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
+	d.client = providerData.client
 }
 
 func (d *CertificateTrustChainDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
@@ -122,15 +175,19 @@ func (d *CertificateTrustChainDataSource) ValidateConfig(ctx context.Context, re
 		}
 	}
 
-	if !data.CertificatePem.IsNull() && !data.CertificatePem.IsUnknown() {
-		if strings.TrimSpace(data.CertificatePem.ValueString()) == "" {
+	if !data.Verify.IsNull() && !data.Verify.IsUnknown() {
+		switch data.Verify.ValueString() {
+		case trustChainVerifyOff, trustChainVerifyWarn, trustChainVerifyError:
+		default:
 			resp.Diagnostics.AddAttributeError(
-				path.Root("certificate_pem"),
-				"certificate_pem must not be empty",
-				"Provide a PEM-encoded X.509 certificate.",
+				path.Root("verify"),
+				"Invalid verify value",
+				fmt.Sprintf("verify must be one of %q, %q, or %q.", trustChainVerifyOff, trustChainVerifyWarn, trustChainVerifyError),
 			)
 		}
 	}
+
+	resp.Diagnostics.Append(validateCertificateInput(data.CertificatePem, data.CertificateId)...)
 }
 
 func (d *CertificateTrustChainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -145,8 +202,7 @@ func (d *CertificateTrustChainDataSource) Read(ctx context.Context, req datasour
 		order = data.Order.ValueString()
 	}
 
-	horizonOrder, ok := orderToHorizon[order]
-	if !ok {
+	if _, ok := orderToHorizon[order]; !ok {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("order"),
 			"Invalid order value",
@@ -159,62 +215,214 @@ func (d *CertificateTrustChainDataSource) Read(ctx context.Context, req datasour
 		return
 	}
 
-	pem := data.CertificatePem.ValueString()
-	if strings.TrimSpace(pem) == "" {
+	includeLeaf := data.IncludeLeaf.IsNull() || data.IncludeLeaf.ValueBool()
+	includeRoot := data.IncludeRoot.IsNull() || data.IncludeRoot.ValueBool()
+	verify := trustChainVerifyOff
+	if !data.Verify.IsNull() && data.Verify.ValueString() != "" {
+		verify = data.Verify.ValueString()
+	}
+
+	pem, diags := resolveCertificatePem(ctx, d.client, data.CertificatePem, data.CertificateId)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.CertificatePem = types.StringValue(pem)
+
+	leafCerts, err := decodePEMCertificates(pem)
+	if err != nil {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("certificate_pem"),
-			"certificate_pem must not be empty",
-			"Provide a PEM-encoded X.509 certificate.",
+			"Invalid certificate_pem",
+			err.Error(),
 		)
 		return
 	}
 
-	chainResp, _, err := d.client.Rfc5280API.Rfc5280TcFile(ctx).
-		X509([]byte(pem)).
-		Order(horizonOrder).
-		Execute()
+	chainPems, err := fetchTrustChain(ctx, d.client, pem, order)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to retrieve certificate trust chain", err.Error())
 		return
 	}
 
-	if len(chainResp) == 0 {
-		resp.Diagnostics.AddError(
-			"Empty trust chain",
-			"Horizon returned an empty trust chain for the provided certificate.",
-		)
+	selection, err := selectTrustChain(chainPems, leafCerts[0], includeLeaf, includeRoot)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid trust chain", err.Error())
 		return
 	}
 
-	chainPems := make([]string, 0, len(chainResp))
-	for i, c := range chainResp {
-		if c.Pem == "" {
-			resp.Diagnostics.AddError(
-				"Missing PEM in trust chain entry",
-				fmt.Sprintf("Horizon returned a trust chain entry without a PEM at index %d.", i),
-			)
-			return
+	leafToRoot, err := trustChainLeafToRoot(chainPems, leafCerts[0], order)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid trust chain", err.Error())
+		return
+	}
+	if verify != trustChainVerifyOff {
+		if problems := verifyTrustChain(leafToRoot); len(problems) > 0 {
+			summary := "Trust chain verification failed"
+			detail := fmt.Sprintf("The trust chain returned by Horizon for %q is invalid:\n- %s", leafCerts[0].Subject, strings.Join(problems, "\n- "))
+			if verify == trustChainVerifyError {
+				resp.Diagnostics.AddError(summary, detail)
+				return
+			}
+			resp.Diagnostics.AddWarning(summary, detail)
 		}
-		chainPems = append(chainPems, c.Pem)
 	}
 
-	chainList, diags := types.ListValueFrom(ctx, types.StringType, chainPems)
+	chainList, diags := types.ListValueFrom(ctx, types.StringType, selection.Chain)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	chainPem := strings.Join(chainPems, "\n")
+	chainPem := strings.Join(selection.Chain, "\n")
 
 	data.Chain = chainList
 	data.ChainPem = types.StringValue(chainPem)
-	data.Length = types.Int64Value(int64(len(chainPems)))
+	data.Length = types.Int64Value(int64(len(selection.Chain)))
 	data.Order = types.StringValue(order)
+	data.IncludeLeaf = types.BoolValue(includeLeaf)
+	data.IncludeRoot = types.BoolValue(includeRoot)
+	data.Verify = types.StringValue(verify)
+	data.ValidUntil = types.Int64Value(trustChainValidUntil(leafToRoot).UnixMilli())
+	data.LeafPem = types.StringValue(selection.Leaf)
+	data.IntermediatesPem = types.StringValue(strings.Join(selection.Intermediates, "\n"))
+	data.RootPem = types.StringNull()
+	if selection.Root != "" {
+		data.RootPem = types.StringValue(selection.Root)
+	}
 	data.Id = types.StringValue(trustChainID(chainPem))
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// fetchTrustChain returns the PEMs of the trust chain of certificatePem, as
+// returned by Horizon in the given order.
+func fetchTrustChain(ctx context.Context, client *horizon.APIClient, certificatePem, order string) ([]string, error) {
+	chainResp, _, err := client.Rfc5280API.Rfc5280TcFile(ctx).
+		X509([]byte(certificatePem)).
+		Order(orderToHorizon[order]).
+		Execute()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chainResp) == 0 {
+		return nil, errors.New("Horizon returned an empty trust chain for the provided certificate")
+	}
+
+	chainPems := make([]string, 0, len(chainResp))
+	for i, c := range chainResp {
+		if c.Pem == "" {
+			return nil, fmt.Errorf("Horizon returned a trust chain entry without a PEM at index %d", i)
+		}
+		chainPems = append(chainPems, c.Pem)
+	}
+	return chainPems, nil
+}
+
+// trustChainSelection is a trust chain returned by Horizon, split by role and
+// filtered by the include_* switches.
+type trustChainSelection struct {
+	Chain         []string
+	Leaf          string
+	Intermediates []string
+	Root          string
+}
+
+// selectTrustChain classifies the PEMs of a trust chain, keeping their order:
+// the entry matching leaf is the leaf, a self-signed entry is the root and the
+// others are intermediates. When the leaf is not part of the chain, as with
+// the issuer_* orders, Leaf is leaf encoded in PEM.
+func selectTrustChain(chainPems []string, leaf *x509.Certificate, includeLeaf, includeRoot bool) (trustChainSelection, error) {
+	selection := trustChainSelection{Chain: []string{}, Intermediates: []string{}}
+	for i, chainPem := range chainPems {
+		certs, err := decodePEMCertificates(chainPem)
+		if err != nil {
+			return selection, fmt.Errorf("trust chain entry %d: %w", i, err)
+		}
+		isLeaf, isRoot := certs[0].Equal(leaf), isSelfSigned(certs[0])
+		if isLeaf {
+			selection.Leaf = chainPem
+		}
+		if isRoot {
+			selection.Root = chainPem
+		}
+		if !isLeaf && !isRoot {
+			selection.Intermediates = append(selection.Intermediates, chainPem)
+		}
+		if (isLeaf && !includeLeaf) || (isRoot && !includeRoot) {
+			continue
+		}
+		selection.Chain = append(selection.Chain, chainPem)
+	}
+	if selection.Leaf == "" {
+		selection.Leaf = encodeCertificatePEM(leaf)
+	}
+	return selection, nil
+}
+
+// trustChainLeafToRoot returns leaf followed by the other certificates of a
+// trust chain returned by Horizon in the given order, from its issuer to the
+// root.
+func trustChainLeafToRoot(chainPems []string, leaf *x509.Certificate, order string) ([]*x509.Certificate, error) {
+	var issuers []*x509.Certificate
+	for i, chainPem := range chainPems {
+		certs, err := decodePEMCertificates(chainPem)
+		if err != nil {
+			return nil, fmt.Errorf("trust chain entry %d: %w", i, err)
+		}
+		if !certs[0].Equal(leaf) {
+			issuers = append(issuers, certs[0])
+		}
+	}
+	if order == trustChainOrderRootToLeaf || order == trustChainOrderIssuerRootToLeaf {
+		for i, j := 0, len(issuers)-1; i < j; i, j = i+1, j-1 {
+			issuers[i], issuers[j] = issuers[j], issuers[i]
+		}
+	}
+	return append([]*x509.Certificate{leaf}, issuers...), nil
+}
+
+// verifyTrustChain checks a chain ordered from the leaf to the root and
+// returns the problems found.
+func verifyTrustChain(chain []*x509.Certificate) []string {
+	var problems []string
+	for i := 0; i+1 < len(chain); i++ {
+		if !issuedBy(chain[i], chain[i+1]) {
+			problems = append(problems, fmt.Sprintf("%q is not signed by the next certificate of the chain, %q", chain[i].Subject, chain[i+1].Subject))
+		}
+	}
+	if root := chain[len(chain)-1]; !isSelfSigned(root) {
+		problems = append(problems, fmt.Sprintf("the chain ends with %q, which is not a self-signed root", root.Subject))
+	}
+
+	latestStart, earliestEnd := chain[0], chain[0]
+	for _, cert := range chain[1:] {
+		if cert.NotBefore.After(latestStart.NotBefore) {
+			latestStart = cert
+		}
+		if cert.NotAfter.Before(earliestEnd.NotAfter) {
+			earliestEnd = cert
+		}
+	}
+	if latestStart.NotBefore.After(earliestEnd.NotAfter) {
+		problems = append(problems, fmt.Sprintf("the validity periods do not overlap: %q expires on %s, before %q becomes valid on %s",
+			earliestEnd.Subject, earliestEnd.NotAfter.Format(time.RFC3339), latestStart.Subject, latestStart.NotBefore.Format(time.RFC3339)))
+	}
+	return problems
+}
+
+// trustChainValidUntil returns the earliest expiration date of the chain.
+func trustChainValidUntil(chain []*x509.Certificate) time.Time {
+	validUntil := chain[0].NotAfter
+	for _, cert := range chain[1:] {
+		if cert.NotAfter.Before(validUntil) {
+			validUntil = cert.NotAfter
+		}
+	}
+	return validUntil
+}
+
 func trustChainID(chainPem string) string {
 	sum := sha256.Sum256([]byte(chainPem))
 	return hex.EncodeToString(sum[:])
@@ -2,8 +2,12 @@ package provider
 
 import (
 	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/hex"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 )
@@ -92,3 +96,147 @@ func TestTrustChainID(t *testing.T) {
 		}
 	})
 }
+
+func TestSelectTrustChain(t *testing.T) {
+	root := newTestCA(t, "Root CA", nil)
+	intermediate := newTestCA(t, "Intermediate CA", root)
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leaf.example.org"}}, intermediate)
+	rootToLeaf := []string{root.pem, intermediate.pem, leaf.pem}
+
+	tests := []struct {
+		name                     string
+		chain                    []string
+		includeLeaf, includeRoot bool
+		want                     []string
+	}{
+		{name: "everything", chain: rootToLeaf, includeLeaf: true, includeRoot: true, want: rootToLeaf},
+		{name: "without root", chain: rootToLeaf, includeLeaf: true, want: []string{intermediate.pem, leaf.pem}},
+		{name: "without leaf", chain: rootToLeaf, includeRoot: true, want: []string{root.pem, intermediate.pem}},
+		{name: "intermediates only", chain: rootToLeaf, want: []string{intermediate.pem}},
+		{name: "issuer order", chain: []string{intermediate.pem, root.pem}, includeLeaf: true, includeRoot: true, want: []string{intermediate.pem, root.pem}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectTrustChain(tt.chain, leaf.cert, tt.includeLeaf, tt.includeRoot)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if strings.Join(got.Chain, "") != strings.Join(tt.want, "") {
+				t.Errorf("chain has %d certificates, want %d in order", len(got.Chain), len(tt.want))
+			}
+			if got.Root != root.pem {
+				t.Errorf("root is not the self-signed certificate")
+			}
+			if len(got.Intermediates) != 1 || got.Intermediates[0] != intermediate.pem {
+				t.Errorf("intermediates = %d certificates, want the intermediate only", len(got.Intermediates))
+			}
+			if got.Leaf != leaf.pem {
+				t.Errorf("leaf is not the looked up certificate")
+			}
+		})
+	}
+
+	t.Run("unknown root", func(t *testing.T) {
+		got, err := selectTrustChain([]string{leaf.pem, intermediate.pem}, leaf.cert, true, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.Root != "" || len(got.Chain) != 2 {
+			t.Errorf("got root %q and %d certificates, want no root and the whole chain", got.Root, len(got.Chain))
+		}
+	})
+
+	t.Run("invalid entry", func(t *testing.T) {
+		if _, err := selectTrustChain([]string{"garbage"}, leaf.cert, true, true); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
+
+func TestTrustChainLeafToRoot(t *testing.T) {
+	root := newTestCA(t, "Root CA", nil)
+	intermediate := newTestCA(t, "Intermediate CA", root)
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leaf.example.org"}}, intermediate)
+
+	tests := map[string][]string{
+		trustChainOrderLeafToRoot:       {leaf.pem, intermediate.pem, root.pem},
+		trustChainOrderRootToLeaf:       {root.pem, intermediate.pem, leaf.pem},
+		trustChainOrderIssuerLeafToRoot: {intermediate.pem, root.pem},
+		trustChainOrderIssuerRootToLeaf: {root.pem, intermediate.pem},
+	}
+	for order, chainPems := range tests {
+		t.Run(order, func(t *testing.T) {
+			got, err := trustChainLeafToRoot(chainPems, leaf.cert, order)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != 3 || !got[0].Equal(leaf.cert) || !got[1].Equal(intermediate.cert) || !got[2].Equal(root.cert) {
+				t.Fatalf("got %d certificates, want leaf, intermediate and root", len(got))
+			}
+		})
+	}
+}
+
+func TestVerifyTrustChain(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	root := newTestCA(t, "Root CA", nil)
+	intermediate := newTestCA(t, "Intermediate CA", root)
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leaf.example.org"}}, intermediate)
+	otherRoot := newTestCA(t, "Other Root CA", nil)
+	expired := newTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Expired CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		NotBefore:             now.Add(-48 * time.Hour),
+		NotAfter:              now.Add(-47 * time.Hour),
+	}, root)
+	issuedByExpired := newTestCertificate(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "late.example.org"},
+		NotBefore: now.Add(-time.Hour),
+	}, expired)
+
+	tests := []struct {
+		name     string
+		chain    []*x509.Certificate
+		problems []string
+	}{
+		{name: "valid", chain: []*x509.Certificate{leaf.cert, intermediate.cert, root.cert}},
+		{name: "self-signed leaf", chain: []*x509.Certificate{root.cert}},
+		{name: "wrong issuer", chain: []*x509.Certificate{leaf.cert, intermediate.cert, otherRoot.cert}, problems: []string{"is not signed by"}},
+		{name: "missing root", chain: []*x509.Certificate{leaf.cert, intermediate.cert}, problems: []string{"not a self-signed root"}},
+		{name: "validity periods", chain: []*x509.Certificate{issuedByExpired.cert, expired.cert, root.cert}, problems: []string{"do not overlap"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := verifyTrustChain(tt.chain)
+			if len(got) != len(tt.problems) {
+				t.Fatalf("got problems %q, want %d", got, len(tt.problems))
+			}
+			for i, want := range tt.problems {
+				if !strings.Contains(got[i], want) {
+					t.Errorf("problem %q does not contain %q", got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestTrustChainValidUntil(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	root := newTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "Root CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		NotAfter:              now.Add(10 * time.Hour),
+	}, nil)
+	leaf := newTestCertificate(t, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "leaf.example.org"},
+		NotAfter: now.Add(20 * time.Hour),
+	}, root)
+
+	if got := trustChainValidUntil([]*x509.Certificate{leaf.cert, root.cert}); !got.Equal(root.cert.NotAfter) {
+		t.Fatalf("trustChainValidUntil = %s, want the root expiration %s", got, root.cert.NotAfter)
+	}
+}
@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Connectors, to third parties or to CAs, come in types that each have their
+// own resource. The settings of a type are regular attributes, refreshed from
+// Horizon, and its credentials are write-only attributes, never stored in the
+// state. Credentials are sent on create, and on update only when
+// credentials_version changes: Horizon keeps the ones it has otherwise.
+
+// connectorAttribute is a setting or a credential of a connector type.
+type connectorAttribute struct {
+	// name is the Terraform attribute name, key is the Horizon one.
+	name        string
+	key         string
+	required    bool
+	description string
+	// requiredWith is the setting an optional credential is required with.
+	requiredWith string
+}
+
+// addConnectorAttributes adds the settings and credentials of a connector
+// type to the attributes of its schema.
+func addConnectorAttributes(attributes map[string]schema.Attribute, settings, credentials []connectorAttribute) {
+	for _, setting := range settings {
+		attributes[setting.name] = schema.StringAttribute{
+			Required:            setting.required,
+			Optional:            !setting.required,
+			MarkdownDescription: setting.description,
+		}
+	}
+	for _, credential := range credentials {
+		attributes[credential.name] = schema.StringAttribute{
+			Required:            credential.required,
+			Optional:            !credential.required,
+			Sensitive:           true,
+			WriteOnly:           true,
+			MarkdownDescription: credential.description,
+		}
+	}
+}
+
+// getConnectorAttributes returns the values of the settings from the plan and
+// of the credentials from the configuration, the only place write-only values
+// are available.
+func getConnectorAttributes(ctx context.Context, plan tfsdk.Plan, config tfsdk.Config, settings, credentials []connectorAttribute) (map[string]types.String, map[string]types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	settingValues := make(map[string]types.String, len(settings))
+	for _, setting := range settings {
+		var value types.String
+		diags.Append(plan.GetAttribute(ctx, path.Root(setting.name), &value)...)
+		settingValues[setting.name] = value
+	}
+	credentialValues := make(map[string]types.String, len(credentials))
+	for _, credential := range credentials {
+		var value types.String
+		diags.Append(config.GetAttribute(ctx, path.Root(credential.name), &value)...)
+		credentialValues[credential.name] = value
+	}
+	return settingValues, credentialValues, diags
+}
+
+// credentialsVersionChanged reports whether credentials_version differs
+// between state and plan, the only sign that write-only credentials must be
+// sent to Horizon again.
+func credentialsVersionChanged(ctx context.Context, plan tfsdk.Plan, state tfsdk.State) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var planned, prior types.Int64
+	diags.Append(plan.GetAttribute(ctx, path.Root("credentials_version"), &planned)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("credentials_version"), &prior)...)
+	return !planned.Equal(prior), diags
+}
+
+// newConnectorState returns an empty state holding only the
+// credentials_version of plan, which Horizon does not know of, for the
+// connector Horizon returned to be filled in.
+func newConnectorState(ctx context.Context, plan tfsdk.Plan) (tfsdk.State, diag.Diagnostics) {
+	state := tfsdk.State{
+		Schema: plan.Schema,
+		Raw:    tftypes.NewValue(plan.Schema.Type().TerraformType(ctx), nil),
+	}
+
+	var version types.Int64
+	diags := plan.GetAttribute(ctx, path.Root("credentials_version"), &version)
+	diags.Append(state.SetAttribute(ctx, path.Root("credentials_version"), version)...)
+	return state, diags
+}
+
+// connectorValues returns the non-null values of attributes by Horizon key,
+// or nil when they are all null.
+func connectorValues(attributes []connectorAttribute, values map[string]types.String) map[string]string {
+	var result map[string]string
+	for _, attribute := range attributes {
+		if value := values[attribute.name]; !value.IsNull() {
+			if result == nil {
+				result = map[string]string{}
+			}
+			result[attribute.key] = value.ValueString()
+		}
+	}
+	return result
+}
+
+// connectorAttributeValues returns the Terraform values of attributes, from
+// the values Horizon returned by key.
+func connectorAttributeValues(attributes []connectorAttribute, values map[string]string) map[string]types.String {
+	result := make(map[string]types.String, len(attributes))
+	for _, attribute := range attributes {
+		value, ok := values[attribute.key]
+		if !ok {
+			result[attribute.name] = types.StringNull()
+			continue
+		}
+		result[attribute.name] = types.StringValue(value)
+	}
+	return result
+}
+
+// setConnectorAttributes sets attribute values in state, in a stable order.
+func setConnectorAttributes(ctx context.Context, state *tfsdk.State, values map[string]types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		diags.Append(state.SetAttribute(ctx, path.Root(name), values[name])...)
+	}
+	return diags
+}
+
+// validateConnectorCredentials checks that the optional credentials and the
+// settings they are required with are set together.
+func validateConnectorCredentials(ctx context.Context, config tfsdk.Config, credentials []connectorAttribute) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, credential := range credentials {
+		if credential.requiredWith == "" {
+			continue
+		}
+		var setting, value types.String
+		diags.Append(config.GetAttribute(ctx, path.Root(credential.requiredWith), &setting)...)
+		diags.Append(config.GetAttribute(ctx, path.Root(credential.name), &value)...)
+		if setting.IsUnknown() || value.IsUnknown() || setting.IsNull() == value.IsNull() {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root(credential.name),
+			fmt.Sprintf("Inconsistent %s", credential.name),
+			fmt.Sprintf("%s and %s must be set together.", credential.requiredWith, credential.name),
+		)
+	}
+	return diags
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCredentialsVersion(t *testing.T) {
+	ctx := context.Background()
+	resp := &resource.SchemaResponse{}
+	NewAwsAcmConnectorResource().Schema(ctx, resource.SchemaRequest{}, resp)
+
+	plan := tfsdk.Plan{Schema: resp.Schema, Raw: tftypes.NewValue(resp.Schema.Type().TerraformType(ctx), nil)}
+	for name, value := range map[string]types.String{
+		"name":              types.StringValue("acm"),
+		"region":            types.StringValue("eu-west-1"),
+		"secret_access_key": types.StringValue("secret"),
+	} {
+		if diags := plan.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+	}
+	if diags := plan.SetAttribute(ctx, path.Root("credentials_version"), types.Int64Value(2)); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	state, diags := newConnectorState(ctx, plan)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var name, credential types.String
+	var version types.Int64
+	state.GetAttribute(ctx, path.Root("name"), &name)
+	state.GetAttribute(ctx, path.Root("secret_access_key"), &credential)
+	state.GetAttribute(ctx, path.Root("credentials_version"), &version)
+	if !name.IsNull() || !credential.IsNull() || version.ValueInt64() != 2 {
+		t.Fatalf("want only credentials_version from the plan, got name %s, credential %s, version %s", name, credential, version)
+	}
+
+	changed, diags := credentialsVersionChanged(ctx, plan, state)
+	if diags.HasError() || changed {
+		t.Fatalf("want unchanged credentials_version, got %t (%v)", changed, diags)
+	}
+	state.SetAttribute(ctx, path.Root("credentials_version"), types.Int64Value(1))
+	if changed, _ := credentialsVersionChanged(ctx, plan, state); !changed {
+		t.Fatal("want changed credentials_version")
+	}
+	state.SetAttribute(ctx, path.Root("credentials_version"), types.Int64Null())
+	if changed, _ := credentialsVersionChanged(ctx, plan, state); !changed {
+		t.Fatal("want credentials_version set for the first time to count as changed")
+	}
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// defaultMetadataModel describes the provider `default_metadata` block.
+type defaultMetadataModel struct {
+	Labels       types.Map    `tfsdk:"labels"`
+	Owner        types.String `tfsdk:"owner"`
+	Team         types.String `tfsdk:"team"`
+	ContactEmail types.String `tfsdk:"contact_email"`
+}
+
+// defaultMetadata is the resolved form of defaultMetadataModel shared with
+// resources once the provider is configured.
+type defaultMetadata struct {
+	Labels       map[string]string
+	Owner        string
+	Team         string
+	ContactEmail string
+}
+
+func (m *defaultMetadataModel) toDefaults(ctx context.Context) (defaultMetadata, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if m == nil {
+		return defaultMetadata{}, diags
+	}
+
+	// Defaults are resolved once, when the provider is configured: a value
+	// only known after apply would change the certificates between plan and
+	// apply.
+	unknown := map[string]bool{
+		"labels":        m.Labels.IsUnknown(),
+		"owner":         m.Owner.IsUnknown(),
+		"team":          m.Team.IsUnknown(),
+		"contact_email": m.ContactEmail.IsUnknown(),
+	}
+	for _, name := range []string{"labels", "owner", "team", "contact_email"} {
+		if unknown[name] {
+			diags.AddAttributeError(
+				path.Root("default_metadata").AtName(name),
+				"Unknown default_metadata value",
+				fmt.Sprintf("default_metadata.%s must be known when the provider is configured, and cannot depend on values computed during apply.", name),
+			)
+		}
+	}
+	if diags.HasError() {
+		return defaultMetadata{}, diags
+	}
+
+	defaults := defaultMetadata{
+		Owner:        m.Owner.ValueString(),
+		Team:         m.Team.ValueString(),
+		ContactEmail: m.ContactEmail.ValueString(),
+	}
+	if !m.Labels.IsNull() {
+		labels := make(map[string]string, len(m.Labels.Elements()))
+		diags.Append(m.Labels.ElementsAs(ctx, &labels, false)...)
+		defaults.Labels = labels
+	}
+	return defaults, diags
+}
+
+// effectiveLabels merges the provider default labels with the resource labels,
+// resource values winning on conflicting label names. The result is unknown
+// whenever any resource label is not yet known.
+func effectiveLabels(ctx context.Context, defaults map[string]string, labels types.Set) (types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if labels.IsUnknown() {
+		return types.MapUnknown(types.StringType), diags
+	}
+
+	merged := make(map[string]string, len(defaults)+len(labels.Elements()))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+
+	resourceLabels := make([]certificateLabelModel, 0, len(labels.Elements()))
+	diags.Append(labels.ElementsAs(ctx, &resourceLabels, false)...)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), diags
+	}
+	for _, label := range resourceLabels {
+		if label.Label.IsUnknown() || label.Value.IsUnknown() {
+			return types.MapUnknown(types.StringType), diags
+		}
+		merged[label.Label.ValueString()] = label.Value.ValueString()
+	}
+
+	result, d := types.MapValueFrom(ctx, types.StringType, merged)
+	diags.Append(d...)
+	return result, diags
+}
+
+// effectiveString returns the resource value when set, the provider default
+// otherwise, and null when neither is available.
+func effectiveString(value types.String, def string) types.String {
+	if !value.IsNull() && !value.IsUnknown() {
+		return value
+	}
+	if value.IsNull() && def != "" {
+		return types.StringValue(def)
+	}
+	return value
+}
+
+// labelElements converts effective labels to request label elements, sorted by
+// label name so the submitted template is deterministic.
+func labelElements(ctx context.Context, labels types.Map) ([]models.RequestLabelElement, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	values := make(map[string]string, len(labels.Elements()))
+	if !labels.IsNull() && !labels.IsUnknown() {
+		diags.Append(labels.ElementsAs(ctx, &values, false)...)
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	elements := make([]models.RequestLabelElement, 0, len(names))
+	for _, name := range names {
+		el := models.RequestLabelElement{Label: name}
+		el.SetValue(values[name])
+		elements = append(elements, el)
+	}
+	return elements, diags
+}
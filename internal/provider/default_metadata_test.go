@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var labelObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"label": types.StringType,
+	"value": types.StringType,
+}}
+
+func labelSetOf(pairs ...[2]string) types.Set {
+	elements := make([]attr.Value, 0, len(pairs))
+	for _, p := range pairs {
+		elements = append(elements, types.ObjectValueMust(labelObjectType.AttrTypes, map[string]attr.Value{
+			"label": types.StringValue(p[0]),
+			"value": types.StringValue(p[1]),
+		}))
+	}
+	return types.SetValueMust(labelObjectType, elements)
+}
+
+func TestEffectiveLabels(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		defaults    map[string]string
+		labels      types.Set
+		want        map[string]string
+		wantUnknown bool
+	}{
+		{
+			name:   "no defaults, no labels → empty map",
+			labels: types.SetNull(labelObjectType),
+			want:   map[string]string{},
+		},
+		{
+			name:     "defaults only",
+			defaults: map[string]string{"cost_center": "42"},
+			labels:   types.SetNull(labelObjectType),
+			want:     map[string]string{"cost_center": "42"},
+		},
+		{
+			name:   "resource labels only",
+			labels: labelSetOf([2]string{"env", "prod"}),
+			want:   map[string]string{"env": "prod"},
+		},
+		{
+			name:     "defaults and resource labels are merged",
+			defaults: map[string]string{"cost_center": "42"},
+			labels:   labelSetOf([2]string{"env", "prod"}),
+			want:     map[string]string{"cost_center": "42", "env": "prod"},
+		},
+		{
+			name:     "resource label overrides the default with the same name",
+			defaults: map[string]string{"cost_center": "42", "env": "dev"},
+			labels:   labelSetOf([2]string{"env", "prod"}),
+			want:     map[string]string{"cost_center": "42", "env": "prod"},
+		},
+		{
+			name:        "unknown labels → unknown result",
+			defaults:    map[string]string{"cost_center": "42"},
+			labels:      types.SetUnknown(labelObjectType),
+			wantUnknown: true,
+		},
+		{
+			name:     "unknown label value → unknown result",
+			defaults: map[string]string{"cost_center": "42"},
+			labels: types.SetValueMust(labelObjectType, []attr.Value{
+				types.ObjectValueMust(labelObjectType.AttrTypes, map[string]attr.Value{
+					"label": types.StringValue("env"),
+					"value": types.StringUnknown(),
+				}),
+			}),
+			wantUnknown: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := effectiveLabels(ctx, tt.defaults, tt.labels)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if tt.wantUnknown {
+				if !got.IsUnknown() {
+					t.Fatalf("effectiveLabels() = %v, want unknown", got)
+				}
+				return
+			}
+			want, _ := types.MapValueFrom(ctx, types.StringType, tt.want)
+			if !got.Equal(want) {
+				t.Fatalf("effectiveLabels() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestEffectiveString(t *testing.T) {
+	tests := []struct {
+		name  string
+		value types.String
+		def   string
+		want  types.String
+	}{
+		{name: "resource value wins", value: types.StringValue("alice"), def: "bob", want: types.StringValue("alice")},
+		{name: "null falls back to default", value: types.StringNull(), def: "bob", want: types.StringValue("bob")},
+		{name: "null without default stays null", value: types.StringNull(), def: "", want: types.StringNull()},
+		{name: "unknown stays unknown", value: types.StringUnknown(), def: "bob", want: types.StringUnknown()},
+		{name: "empty string is an explicit value", value: types.StringValue(""), def: "bob", want: types.StringValue("")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := effectiveString(tt.value, tt.def); !got.Equal(tt.want) {
+				t.Fatalf("effectiveString(%v, %q) = %v, want %v", tt.value, tt.def, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelElements(t *testing.T) {
+	ctx := context.Background()
+	labels, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{
+		"tier":        "1",
+		"cost_center": "42",
+		"env":         "prod",
+	})
+
+	got, diags := labelElements(ctx, labels)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	wantOrder := []string{"cost_center", "env", "tier"}
+	if len(got) != len(wantOrder) {
+		t.Fatalf("labelElements() returned %d elements, want %d", len(got), len(wantOrder))
+	}
+	for i, name := range wantOrder {
+		if got[i].Label != name {
+			t.Errorf("labelElements()[%d].Label = %q, want %q (elements must be sorted)", i, got[i].Label, name)
+		}
+	}
+
+	empty, diags := labelElements(ctx, types.MapNull(types.StringType))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(empty) != 0 {
+		t.Errorf("labelElements(null) = %v, want no elements", empty)
+	}
+}
+
+func TestDefaultMetadataModelToDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	var nilModel *defaultMetadataModel
+	defaults, diags := nilModel.toDefaults(ctx)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if defaults.Owner != "" || defaults.Team != "" || defaults.ContactEmail != "" || defaults.Labels != nil {
+		t.Fatalf("absent default_metadata block must resolve to empty defaults, got %+v", defaults)
+	}
+
+	labels, _ := types.MapValueFrom(ctx, types.StringType, map[string]string{"cost_center": "42"})
+	model := &defaultMetadataModel{
+		Labels:       labels,
+		Owner:        types.StringNull(),
+		Team:         types.StringValue("platform"),
+		ContactEmail: types.StringValue("platform@example.com"),
+	}
+	defaults, diags = model.toDefaults(ctx)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if defaults.Owner != "" {
+		t.Errorf("Owner = %q, want empty", defaults.Owner)
+	}
+	if defaults.Team != "platform" {
+		t.Errorf("Team = %q, want %q", defaults.Team, "platform")
+	}
+	if defaults.ContactEmail != "platform@example.com" {
+		t.Errorf("ContactEmail = %q, want %q", defaults.ContactEmail, "platform@example.com")
+	}
+	if defaults.Labels["cost_center"] != "42" {
+		t.Errorf("Labels = %v, want cost_center=42", defaults.Labels)
+	}
+}
+
+func TestDefaultMetadataModelToDefaultsUnknown(t *testing.T) {
+	ctx := context.Background()
+
+	model := &defaultMetadataModel{
+		Labels:       types.MapUnknown(types.StringType),
+		Owner:        types.StringNull(),
+		Team:         types.StringUnknown(),
+		ContactEmail: types.StringNull(),
+	}
+	_, diags := model.toDefaults(ctx)
+	if diags.ErrorsCount() != 2 {
+		t.Fatalf("want an error for each unknown value, got %v", diags)
+	}
+}
@@ -0,0 +1,302 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/evertrust/terraform-provider-horizon/internal/hrql"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// discoveryModule is the module of the certificates Horizon only knows from
+// discovery, which no lifecycle module manages.
+const discoveryModule = "discovery"
+
+// discoverySearchPageSize is the number of certificates fetched per search
+// request.
+const discoverySearchPageSize = 100
+
+func NewDiscoveredCertificatesDataSource() datasource.DataSource {
+	return &DiscoveredCertificatesDataSource{}
+}
+
+// DiscoveredCertificatesDataSource lists the certificates found by discovery
+// campaigns, to track the shadow certificates deployed outside of Horizon.
+type DiscoveredCertificatesDataSource struct {
+	client *horizon.APIClient
+}
+
+type discoveredCertificatesDataSourceModel struct {
+	Campaign      types.String `tfsdk:"campaign"`
+	UnmanagedOnly types.Bool   `tfsdk:"unmanaged_only"`
+	Ids           types.List   `tfsdk:"ids"`
+	Certificates  types.List   `tfsdk:"certificates"`
+}
+
+var discoveryDataObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"ip":        types.StringType,
+	"hostnames": types.ListType{ElemType: types.StringType},
+	"sources":   types.ListType{ElemType: types.StringType},
+	"os":        types.StringType,
+	"datetime":  types.Int64Type,
+}}
+
+var discoveredCertificateObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":                 types.StringType,
+	"dn":                 types.StringType,
+	"serial":             types.StringType,
+	"issuer":             types.StringType,
+	"not_after":          types.Int64Type,
+	"module":             types.StringType,
+	"profile":            types.StringType,
+	"discovered_trusted": types.BoolType,
+	"discovery_info":     types.ListType{ElemType: certificateDiscoveryInfoObjectType},
+	"discovery_data":     types.ListType{ElemType: discoveryDataObjectType},
+}}
+
+func (d *DiscoveredCertificatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_discovered_certificates"
+}
+
+func (d *DiscoveredCertificatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the certificates found by discovery campaigns, such as the ones of `horizon_discovery_campaign`, " +
+			"with where and when they were found. Set `unmanaged_only` to track the shadow certificates deployed outside of Horizon.",
+		Attributes: map[string]schema.Attribute{
+			"campaign": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return the certificates found by this campaign. Certificates found by any campaign are returned when unset.",
+			},
+			"unmanaged_only": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return the certificates Horizon only knows from discovery, whose `module` is `discovery`, leaving out the ones a lifecycle module such as WebRA manages.",
+			},
+			"ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Horizon ids of the certificates, sorted.",
+			},
+			"certificates": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Certificates, sorted by id.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Horizon id of the certificate.",
+						},
+						"dn": schema.StringAttribute{
+							Computed:    true,
+							Description: "DN of the certificate.",
+						},
+						"serial": schema.StringAttribute{
+							Computed:    true,
+							Description: "Serial number of the certificate.",
+						},
+						"issuer": schema.StringAttribute{
+							Computed:    true,
+							Description: "Issuer DN of the certificate.",
+						},
+						"not_after": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Expiration date of the certificate, in milliseconds since the epoch.",
+						},
+						"module": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Module managing the certificate, such as `webra`, or `discovery` for a certificate Horizon only knows from discovery.",
+						},
+						"profile": schema.StringAttribute{
+							Computed:    true,
+							Description: "Profile of the certificate, when a module manages it.",
+						},
+						"discovered_trusted": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the certificate chains up to a CA trusted by Horizon.",
+						},
+						"discovery_info": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Discovery campaigns that found the certificate, sorted by campaign.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"campaign": schema.StringAttribute{
+										Computed:    true,
+										Description: "Discovery campaign name.",
+									},
+									"last_discovery_date": schema.Int64Attribute{
+										Computed:    true,
+										Description: "Date the campaign last found the certificate.",
+									},
+									"identifier": schema.StringAttribute{
+										Computed:    true,
+										Description: "Identifier of the discovery event.",
+									},
+								},
+							},
+						},
+						"discovery_data": schema.ListNestedAttribute{
+							Computed:    true,
+							Description: "Hosts the certificate was found on, sorted by IP address.",
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"ip": schema.StringAttribute{
+										Computed:    true,
+										Description: "IP address of the host.",
+									},
+									"hostnames": schema.ListAttribute{
+										Computed:    true,
+										ElementType: types.StringType,
+										Description: "Host names resolving to the IP address, sorted.",
+									},
+									"sources": schema.ListAttribute{
+										Computed:    true,
+										ElementType: types.StringType,
+										Description: "Sources that reported the certificate, such as scans or agents, sorted.",
+									},
+									"os": schema.StringAttribute{
+										Computed:    true,
+										Description: "Operating system of the host, when known.",
+									},
+									"datetime": schema.Int64Attribute{
+										Computed:    true,
+										Description: "Date the certificate was last found on the host, in milliseconds since the epoch.",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DiscoveredCertificatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.client
+}
+
+func (d *DiscoveredCertificatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data discoveredCertificatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hrqlQuery, err := discoveredCertificatesQuery(data.Campaign.ValueString(), data.UnmanagedOnly.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to build certificate search query", err.Error())
+		return
+	}
+
+	var certificates []models.Certificate
+	for page := int32(1); ; page++ {
+		query := models.NewCertificateSearchQuery()
+		query.SetPageIndex(page)
+		query.SetPageSize(discoverySearchPageSize)
+		query.SetQuery(hrqlQuery)
+
+		results, _, err := d.client.CertificateAPI.CertificateSearch(ctx).CertificateSearchQuery(*query).Execute()
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to search discovered certificates", err.Error())
+			return
+		}
+		certificates = append(certificates, results.GetResults()...)
+		if !results.GetHasMore() {
+			break
+		}
+	}
+
+	data.Ids, data.Certificates = discoveredCertificatesValue(certificates)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// discoveredCertificatesQuery returns the HRQL query matching the certificates
+// found by campaign, or by any campaign when campaign is empty.
+func discoveredCertificatesQuery(campaign string, unmanagedOnly bool) (string, error) {
+	// HRQL field names are lowercase.
+	var exprs []hrql.Expr
+	if campaign != "" {
+		exprs = append(exprs, hrql.Equals("discoveryinfo.campaign", campaign))
+	} else {
+		exprs = append(exprs, hrql.NotEquals("discoveryinfo.campaign", ""))
+	}
+	if unmanagedOnly {
+		exprs = append(exprs, hrql.Equals("module", discoveryModule))
+	}
+	return hrql.Build(hrql.And(exprs...))
+}
+
+// discoveredCertificatesValue returns the ids and the certificates attributes
+// of certificates, sorted by id.
+func discoveredCertificatesValue(certificates []models.Certificate) (types.List, types.List) {
+	sorted := make([]models.Certificate, len(certificates))
+	copy(sorted, certificates)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Id < sorted[j].Id })
+
+	ids := make([]attr.Value, 0, len(sorted))
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, certificate := range sorted {
+		ids = append(ids, types.StringValue(certificate.Id))
+		elements = append(elements, types.ObjectValueMust(discoveredCertificateObjectType.AttrTypes, map[string]attr.Value{
+			"id":                 types.StringValue(certificate.Id),
+			"dn":                 types.StringValue(certificate.Dn),
+			"serial":             types.StringValue(certificate.Serial),
+			"issuer":             types.StringValue(certificate.Issuer),
+			"not_after":          types.Int64Value(certificate.NotAfter),
+			"module":             types.StringValue(certificate.Module),
+			"profile":            types.StringPointerValue(certificate.Profile),
+			"discovered_trusted": types.BoolPointerValue(certificate.DiscoveredTrusted),
+			"discovery_info":     discoveryInfoValue(certificate.DiscoveryInfo),
+			"discovery_data":     discoveryDataValue(certificate.DiscoveryData),
+		}))
+	}
+	return types.ListValueMust(types.StringType, ids), types.ListValueMust(discoveredCertificateObjectType, elements)
+}
+
+func discoveryDataValue(data []models.CertificateDiscoveryData) types.List {
+	sorted := make([]models.CertificateDiscoveryData, len(data))
+	copy(sorted, data)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].GetIp() < sorted[j].GetIp() })
+
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, host := range sorted {
+		elements = append(elements, types.ObjectValueMust(discoveryDataObjectType.AttrTypes, map[string]attr.Value{
+			"ip":        types.StringValue(host.GetIp()),
+			"hostnames": sortedStringList(host.GetHostnames()),
+			"sources":   sortedStringList(host.GetSources()),
+			"os":        types.StringPointerValue(host.Os),
+			"datetime":  types.Int64Value(host.GetDatetime()),
+		}))
+	}
+	return types.ListValueMust(discoveryDataObjectType, elements)
+}
+
+func sortedStringList(values []string) types.List {
+	sorted := make([]string, len(values))
+	copy(sorted, values)
+	sort.Strings(sorted)
+
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, value := range sorted {
+		elements = append(elements, types.StringValue(value))
+	}
+	return types.ListValueMust(types.StringType, elements)
+}
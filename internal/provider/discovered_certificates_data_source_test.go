@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDiscoveredCertificatesQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		campaign      string
+		unmanagedOnly bool
+		want          string
+	}{
+		{name: "any campaign", want: `discoveryinfo.campaign not equals ""`},
+		{name: "campaign", campaign: "datacenter", want: `discoveryinfo.campaign equals "datacenter"`},
+		{name: "unmanaged", campaign: "datacenter", unmanagedOnly: true, want: `discoveryinfo.campaign equals "datacenter" and module equals "discovery"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := discoveredCertificatesQuery(tt.campaign, tt.unmanagedOnly)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDiscoveredCertificatesValue(t *testing.T) {
+	ids, certificates := discoveredCertificatesValue([]models.Certificate{
+		{
+			Id:      "b",
+			Module:  "webra",
+			Profile: strPtr("servers"),
+		},
+		{
+			Id:                "a",
+			Dn:                "CN=shadow.example.com",
+			Module:            "discovery",
+			DiscoveredTrusted: boolPtr(false),
+			DiscoveryInfo:     []models.CertificateDiscoveryInfo{{Campaign: "datacenter", LastDiscoveryDate: 1700000000000}},
+			DiscoveryData: []models.CertificateDiscoveryData{
+				{Ip: "10.0.0.2", Hostnames: []string{"www.example.com", "shadow.example.com"}, Datetime: 1700000000000},
+				{Ip: "10.0.0.1", Sources: []string{"scan"}, Os: strPtr("linux")},
+			},
+		},
+	})
+
+	if got := ids.String(); got != `["a","b"]` {
+		t.Fatalf("unexpected ids %s", got)
+	}
+	elements := certificates.Elements()
+	if len(elements) != 2 {
+		t.Fatalf("want 2 certificates, got %d", len(elements))
+	}
+
+	shadow := elements[0].(types.Object).Attributes()
+	if shadow["module"].(types.String).ValueString() != "discovery" || shadow["discovered_trusted"].(types.Bool).ValueBool() {
+		t.Fatalf("unexpected certificate %s", elements[0])
+	}
+	hosts := shadow["discovery_data"].(types.List).Elements()
+	first := hosts[0].(types.Object).Attributes()
+	if first["ip"].(types.String).ValueString() != "10.0.0.1" || first["os"].(types.String).ValueString() != "linux" {
+		t.Fatalf("discovery data not sorted by ip: %s", shadow["discovery_data"])
+	}
+	second := hosts[1].(types.Object).Attributes()
+	if got := second["hostnames"].String(); got != `["shadow.example.com","www.example.com"]` {
+		t.Fatalf("unexpected hostnames %s", got)
+	}
+	if len(shadow["discovery_info"].(types.List).Elements()) != 1 {
+		t.Fatalf("unexpected discovery info %s", shadow["discovery_info"])
+	}
+
+	managed := elements[1].(types.Object).Attributes()
+	if managed["profile"].(types.String).ValueString() != "servers" || !managed["discovered_trusted"].IsNull() {
+		t.Fatalf("unexpected certificate %s", elements[1])
+	}
+}
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultDiscoveryPort is the port scanned when a campaign sets none.
+const defaultDiscoveryPort = 443
+
+// hostnamePattern matches DNS host names, made of labels of letters, digits
+// and hyphens.
+var hostnamePattern = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+
+func NewDiscoveryCampaignResource() resource.Resource {
+	return &DiscoveryCampaignResource{}
+}
+
+// DiscoveryCampaignResource manages a network discovery campaign, which scans
+// hosts for the TLS certificates they serve.
+type DiscoveryCampaignResource struct {
+	client *horizon.APIClient
+}
+
+type discoveryCampaignResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	Hosts          types.Set    `tfsdk:"hosts"`
+	Ports          types.Set    `tfsdk:"ports"`
+	Exclusions     types.Set    `tfsdk:"exclusions"`
+	Schedule       types.String `tfsdk:"schedule"`
+	Timeout        types.String `tfsdk:"timeout"`
+	MaxConcurrency types.Int64  `tfsdk:"max_concurrency"`
+}
+
+func (r *DiscoveryCampaignResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_discovery_campaign"
+}
+
+func (r *DiscoveryCampaignResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a network discovery campaign, which scans hosts for the TLS certificates they serve. " +
+			"The certificates it finds carry the campaign in their `discovery_info`, and are listed by `horizon_discovered_certificates`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the campaign.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the campaign. Changing it creates a new campaign.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the campaign.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the campaign runs on its schedule. Defaults to true.",
+			},
+			"hosts": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Hosts to scan: host names, IP addresses or CIDR ranges such as `10.0.0.0/24`.",
+			},
+			"ports": schema.SetAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.Int64Type,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(defaultDiscoveryPort)})),
+				MarkdownDescription: "TCP ports to scan on every host. Defaults to `[443]`.",
+			},
+			"exclusions": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Hosts not to scan, in the format of `hosts`.",
+			},
+			"schedule": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "When the campaign runs, as a Quartz cron expression such as `0 0 2 * * ?` for every day at 2 AM. The campaign only runs when started from Horizon when unset.",
+			},
+			"timeout": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "How long to wait for a host to answer on a port, as a duration such as `5 seconds` or `PT5S`. The Horizon default applies when unset.",
+			},
+			"max_concurrency": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of connections the campaign opens at once. The Horizon default applies when unset.",
+			},
+		},
+	}
+}
+
+func (r *DiscoveryCampaignResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *DiscoveryCampaignResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data discoveryCampaignResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	campaign, diags := discoveryCampaignFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating discovery campaign %s", campaign.Name))
+	created, _, err := r.client.DiscoveryCampaignAPI.DiscoveryCampaignCreate(ctx).DiscoveryCampaign(*campaign).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create discovery campaign", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillDiscoveryCampaignModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DiscoveryCampaignResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data discoveryCampaignResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	campaign, httpResp, err := r.client.DiscoveryCampaignAPI.DiscoveryCampaignGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Discovery campaign %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get discovery campaign", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillDiscoveryCampaignModel(ctx, &data, campaign)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DiscoveryCampaignResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data discoveryCampaignResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	campaign, diags := discoveryCampaignFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating discovery campaign %s", campaign.Name))
+	updated, _, err := r.client.DiscoveryCampaignAPI.DiscoveryCampaignUpdate(ctx).DiscoveryCampaign(*campaign).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update discovery campaign", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillDiscoveryCampaignModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DiscoveryCampaignResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data discoveryCampaignResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.DiscoveryCampaignAPI.DiscoveryCampaignDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete discovery campaign", err.Error())
+	}
+}
+
+func (r *DiscoveryCampaignResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *DiscoveryCampaignResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data discoveryCampaignResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateDiscoveryCampaign(ctx, data)...)
+}
+
+// validateDiscoveryCampaign checks the known values of a campaign
+// configuration.
+func validateDiscoveryCampaign(ctx context.Context, data discoveryCampaignResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(validateDiscoveryTargets(ctx, "hosts", data.Hosts)...)
+	diags.Append(validateDiscoveryTargets(ctx, "exclusions", data.Exclusions)...)
+	diags.Append(validateDuration("timeout", data.Timeout)...)
+
+	if !data.Ports.IsNull() && !data.Ports.IsUnknown() {
+		var ports []types.Int64
+		diags.Append(data.Ports.ElementsAs(ctx, &ports, false)...)
+		for _, port := range ports {
+			if !port.IsUnknown() && (port.ValueInt64() < 1 || port.ValueInt64() > 65535) {
+				diags.AddAttributeError(
+					path.Root("ports"),
+					"Invalid ports value",
+					fmt.Sprintf("%d is not a TCP port.", port.ValueInt64()),
+				)
+			}
+		}
+	}
+
+	// Quartz cron expressions have seconds, minutes, hours, day of month,
+	// month, day of week and an optional year.
+	if !data.Schedule.IsNull() && !data.Schedule.IsUnknown() {
+		if fields := len(strings.Fields(data.Schedule.ValueString())); fields != 6 && fields != 7 {
+			diags.AddAttributeError(
+				path.Root("schedule"),
+				"Invalid schedule value",
+				fmt.Sprintf("%q is not a Quartz cron expression: expected 6 or 7 fields, got %d.", data.Schedule.ValueString(), fields),
+			)
+		}
+	}
+
+	if !data.MaxConcurrency.IsNull() && !data.MaxConcurrency.IsUnknown() && data.MaxConcurrency.ValueInt64() < 1 {
+		diags.AddAttributeError(
+			path.Root("max_concurrency"),
+			"Invalid max_concurrency value",
+			"max_concurrency must be positive.",
+		)
+	}
+	return diags
+}
+
+// validateDiscoveryTargets checks that the known elements of attribute are
+// host names, IP addresses or CIDR ranges.
+func validateDiscoveryTargets(ctx context.Context, attribute string, targets types.Set) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if targets.IsNull() || targets.IsUnknown() {
+		return diags
+	}
+
+	var values []types.String
+	diags.Append(targets.ElementsAs(ctx, &values, false)...)
+	for _, value := range values {
+		if value.IsUnknown() || isDiscoveryTarget(value.ValueString()) {
+			continue
+		}
+		diags.AddAttributeError(
+			path.Root(attribute),
+			fmt.Sprintf("Invalid %s value", attribute),
+			fmt.Sprintf("%q is not a host name, an IP address or a CIDR range.", value.ValueString()),
+		)
+	}
+	return diags
+}
+
+func isDiscoveryTarget(target string) bool {
+	if net.ParseIP(target) != nil {
+		return true
+	}
+	if _, _, err := net.ParseCIDR(target); err == nil {
+		return true
+	}
+	return len(target) <= 253 && hostnamePattern.MatchString(target)
+}
+
+func discoveryCampaignFromModel(ctx context.Context, data discoveryCampaignResourceModel) (*models.DiscoveryCampaign, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	campaign := models.NewDiscoveryCampaignWithDefaults()
+	campaign.Name = data.Name.ValueString()
+	campaign.Description = data.Description.ValueStringPointer()
+	campaign.Enabled = data.Enabled.ValueBoolPointer()
+	campaign.Schedule = data.Schedule.ValueStringPointer()
+	campaign.Timeout = horizonDuration(data.Timeout)
+	if !data.MaxConcurrency.IsNull() {
+		maxConcurrency := int32(data.MaxConcurrency.ValueInt64())
+		campaign.MaxConcurrency = &maxConcurrency
+	}
+	diags.Append(data.Hosts.ElementsAs(ctx, &campaign.Hosts, false)...)
+	if !data.Ports.IsNull() {
+		diags.Append(data.Ports.ElementsAs(ctx, &campaign.Ports, false)...)
+	}
+	if !data.Exclusions.IsNull() {
+		diags.Append(data.Exclusions.ElementsAs(ctx, &campaign.Exclusions, false)...)
+	}
+	return campaign, diags
+}
+
+func fillDiscoveryCampaignModel(ctx context.Context, data *discoveryCampaignResourceModel, campaign *models.DiscoveryCampaign) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	data.Id = types.StringValue(campaign.Name)
+	data.Name = types.StringValue(campaign.Name)
+	data.Description = types.StringPointerValue(campaign.Description)
+	data.Enabled = types.BoolValue(campaign.Enabled == nil || *campaign.Enabled)
+	data.Schedule = types.StringPointerValue(campaign.Schedule)
+	data.Timeout = durationValue(data.Timeout, campaign.Timeout)
+	data.MaxConcurrency = types.Int64Null()
+	if campaign.MaxConcurrency != nil {
+		data.MaxConcurrency = types.Int64Value(int64(*campaign.MaxConcurrency))
+	}
+
+	data.Hosts, d = types.SetValueFrom(ctx, types.StringType, campaign.Hosts)
+	diags.Append(d...)
+	ports := campaign.Ports
+	if len(ports) == 0 {
+		ports = []int32{defaultDiscoveryPort}
+	}
+	data.Ports, d = types.SetValueFrom(ctx, types.Int64Type, ports)
+	diags.Append(d...)
+	data.Exclusions = emptySetValue(data.Exclusions, types.StringType)
+	if len(campaign.Exclusions) > 0 {
+		data.Exclusions, d = types.SetValueFrom(ctx, types.StringType, campaign.Exclusions)
+		diags.Append(d...)
+	}
+	return diags
+}
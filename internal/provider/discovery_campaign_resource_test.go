@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillDiscoveryCampaignModel(t *testing.T) {
+	ctx := context.Background()
+	emptySet := types.SetValueMust(types.StringType, nil)
+	gateway := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.1")})
+	configured := func(timeout types.String, exclusions types.Set) func(t *testing.T) discoveryCampaignResourceModel {
+		return func(t *testing.T) discoveryCampaignResourceModel {
+			return discoveryCampaignResourceModel{Name: types.StringValue("datacenter"), Timeout: timeout, Exclusions: exclusions}
+		}
+	}
+	imported := func(t *testing.T) discoveryCampaignResourceModel {
+		var data discoveryCampaignResourceModel
+		if diags := importedState(t, NewDiscoveryCampaignResource(), "datacenter").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+	stored := func(timeout string, exclusions ...string) *models.DiscoveryCampaign {
+		return &models.DiscoveryCampaign{
+			Name:       "datacenter",
+			Hosts:      []string{"10.0.0.0/24"},
+			Ports:      []int32{443, 8443},
+			Exclusions: exclusions,
+			Timeout:    strPtr(timeout),
+		}
+	}
+
+	tests := []struct {
+		name           string
+		prior          func(t *testing.T) discoveryCampaignResourceModel
+		campaign       *models.DiscoveryCampaign
+		wantTimeout    types.String
+		wantExclusions types.Set
+	}{
+		{
+			// Horizon stores durations in its own notation.
+			name:           "duration notation is kept",
+			prior:          configured(types.StringValue("PT5S"), gateway),
+			campaign:       stored("5 seconds", "10.0.0.1"),
+			wantTimeout:    types.StringValue("PT5S"),
+			wantExclusions: gateway,
+		},
+		{
+			name:           "duration changed outside of Terraform",
+			prior:          configured(types.StringValue("PT5S"), gateway),
+			campaign:       stored("10 seconds", "10.0.0.1"),
+			wantTimeout:    types.StringValue("10 seconds"),
+			wantExclusions: gateway,
+		},
+		{
+			// Omitting exclusions does not plan a change.
+			name:           "unset exclusions read back as null",
+			prior:          configured(types.StringValue("PT5S"), types.SetNull(types.StringType)),
+			campaign:       stored("5 seconds"),
+			wantTimeout:    types.StringValue("PT5S"),
+			wantExclusions: types.SetNull(types.StringType),
+		},
+		{
+			// Neither does configuring them as empty.
+			name:           "empty exclusions read back as empty",
+			prior:          configured(types.StringValue("PT5S"), emptySet),
+			campaign:       stored("5 seconds"),
+			wantTimeout:    types.StringValue("PT5S"),
+			wantExclusions: emptySet,
+		},
+		{
+			name:           "exclusions emptied outside of Terraform read back as null",
+			prior:          configured(types.StringValue("PT5S"), gateway),
+			campaign:       stored("5 seconds"),
+			wantTimeout:    types.StringValue("PT5S"),
+			wantExclusions: types.SetNull(types.StringType),
+		},
+		{
+			name:           "imported by name",
+			prior:          imported,
+			campaign:       stored("5 seconds", "10.0.0.1"),
+			wantTimeout:    types.StringValue("5 seconds"),
+			wantExclusions: gateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.campaign.Name {
+				t.Fatalf("Read looks up the campaign %q, want %q", data.Name.ValueString(), tt.campaign.Name)
+			}
+			if diags := fillDiscoveryCampaignModel(ctx, &data, tt.campaign); diags.HasError() {
+				t.Fatal(diags)
+			}
+			wantPorts := types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(443), types.Int64Value(8443)})
+			if data.Id.ValueString() != "datacenter" || !data.Enabled.ValueBool() || !data.Ports.Equal(wantPorts) || !data.MaxConcurrency.IsNull() {
+				t.Errorf("id %s, enabled %s, ports %s, max_concurrency %s", data.Id, data.Enabled, data.Ports, data.MaxConcurrency)
+			}
+			if !data.Timeout.Equal(tt.wantTimeout) {
+				t.Errorf("timeout = %s, want %s", data.Timeout, tt.wantTimeout)
+			}
+			if !data.Exclusions.Equal(tt.wantExclusions) {
+				t.Errorf("exclusions = %s, want %s", data.Exclusions, tt.wantExclusions)
+			}
+		})
+	}
+}
+
+func TestDiscoveryCampaignFromModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name           string
+		exclusions     types.Set
+		wantExclusions []string
+	}{
+		{name: "null exclusions are not sent", exclusions: types.SetNull(types.StringType)},
+		{name: "empty exclusions are not sent", exclusions: types.SetValueMust(types.StringType, nil)},
+		{
+			name:           "exclusions are sent",
+			exclusions:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.1")}),
+			wantExclusions: []string{"10.0.0.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			campaign, diags := discoveryCampaignFromModel(ctx, discoveryCampaignResourceModel{
+				Name:           types.StringValue("datacenter"),
+				Description:    types.StringNull(),
+				Enabled:        types.BoolValue(true),
+				Hosts:          types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/24")}),
+				Ports:          types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(443)}),
+				Exclusions:     tt.exclusions,
+				Schedule:       types.StringNull(),
+				Timeout:        types.StringValue("PT5S"),
+				MaxConcurrency: types.Int64Null(),
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			// Durations are sent in the notation of Horizon.
+			if campaign.Timeout == nil || *campaign.Timeout != "5 seconds" || campaign.MaxConcurrency != nil || campaign.Schedule != nil {
+				t.Errorf("unexpected campaign: %+v", campaign)
+			}
+			if !slices.Equal(campaign.Hosts, []string{"10.0.0.0/24"}) || !slices.Equal(campaign.Ports, []int32{443}) {
+				t.Errorf("hosts %v, ports %v", campaign.Hosts, campaign.Ports)
+			}
+			if !slices.Equal(campaign.Exclusions, tt.wantExclusions) {
+				t.Errorf("exclusions = %v, want %v", campaign.Exclusions, tt.wantExclusions)
+			}
+		})
+	}
+}
+
+func TestFillDiscoveryCampaignModelDefaultPort(t *testing.T) {
+	var data discoveryCampaignResourceModel
+	if diags := fillDiscoveryCampaignModel(context.Background(), &data, &models.DiscoveryCampaign{Name: "c", Hosts: []string{"example.com"}}); diags.HasError() {
+		t.Fatal(diags)
+	}
+	want := types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(443)})
+	if !data.Ports.Equal(want) {
+		t.Fatalf("want ports %s, got %s", want, data.Ports)
+	}
+	if !data.Exclusions.IsNull() {
+		t.Fatalf("want null exclusions, got %s", data.Exclusions)
+	}
+}
+
+func TestValidateDiscoveryCampaign(t *testing.T) {
+	ctx := context.Background()
+	base := func() discoveryCampaignResourceModel {
+		return discoveryCampaignResourceModel{
+			Name:           types.StringValue("c"),
+			Hosts:          types.SetValueMust(types.StringType, []attr.Value{types.StringValue("example.com")}),
+			Ports:          types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(443)}),
+			Exclusions:     types.SetNull(types.StringType),
+			Schedule:       types.StringNull(),
+			Timeout:        types.StringNull(),
+			MaxConcurrency: types.Int64Null(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*discoveryCampaignResourceModel)
+		wantErr bool
+	}{
+		{name: "defaults", mutate: func(d *discoveryCampaignResourceModel) {}},
+		{name: "addresses and ranges", mutate: func(d *discoveryCampaignResourceModel) {
+			d.Hosts = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/24"), types.StringValue("2001:db8::1")})
+			d.Exclusions = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.1")})
+		}},
+		{name: "unknown host", mutate: func(d *discoveryCampaignResourceModel) {
+			d.Hosts = types.SetValueMust(types.StringType, []attr.Value{types.StringUnknown()})
+		}},
+		{name: "invalid host", wantErr: true, mutate: func(d *discoveryCampaignResourceModel) {
+			d.Hosts = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("https://example.com")})
+		}},
+		{name: "invalid exclusion", wantErr: true, mutate: func(d *discoveryCampaignResourceModel) {
+			d.Exclusions = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("10.0.0.0/33")})
+		}},
+		{name: "port out of range", wantErr: true, mutate: func(d *discoveryCampaignResourceModel) {
+			d.Ports = types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(65536)})
+		}},
+		{name: "quartz schedule", mutate: func(d *discoveryCampaignResourceModel) {
+			d.Schedule = types.StringValue("0 0 2 ? * MON-FRI")
+		}},
+		{name: "unix cron schedule", wantErr: true, mutate: func(d *discoveryCampaignResourceModel) {
+			d.Schedule = types.StringValue("0 2 * * *")
+		}},
+		{name: "invalid timeout", wantErr: true, mutate: func(d *discoveryCampaignResourceModel) {
+			d.Timeout = types.StringValue("5 fortnights")
+		}},
+		{name: "zero max concurrency", wantErr: true, mutate: func(d *discoveryCampaignResourceModel) {
+			d.MaxConcurrency = types.Int64Value(0)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base()
+			tt.mutate(&data)
+			if diags := validateDiscoveryCampaign(ctx, data); diags.HasError() != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ function.Function = &ParseDnFunction{}
+	_ function.Function = &FormatDnFunction{}
+)
+
+func NewParseDnFunction() function.Function {
+	return &ParseDnFunction{}
+}
+
+func NewFormatDnFunction() function.Function {
+	return &FormatDnFunction{}
+}
+
+// ParseDnFunction splits a distinguished name into its attributes.
+type ParseDnFunction struct{}
+
+// FormatDnFunction renders a distinguished name in a normalized style.
+type FormatDnFunction struct{}
+
+const (
+	dnStyleRFC4514 = "rfc4514"
+	dnStyleOpenSSL = "openssl"
+)
+
+var dnAttributeObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":  types.StringType,
+	"value": types.StringType,
+}}
+
+func (f *ParseDnFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_dn"
+}
+
+func (f *ParseDnFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a distinguished name.",
+		MarkdownDescription: "Parses a distinguished name written in RFC 4514 style (`CN=example.org,O=Example,C=FR`) or OpenSSL style (`/C=FR/O=Example/CN=example.org`) " +
+			"and returns its attributes in RFC 4514 order, most specific first, whatever the input style. " +
+			"Escapes are resolved, attribute types are upper-cased and long names or OIDs of common types are replaced by their short name, e.g. `commonName` and `2.5.4.3` become `CN`. " +
+			"Attributes of a multi-valued RDN are returned one after the other.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "dn",
+				Description: "Distinguished name to parse.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: dnAttributeObjectType,
+		},
+	}
+}
+
+func (f *ParseDnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	rdns, err := parseDN(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	elements := make([]attr.Value, 0, len(rdns))
+	for _, rdn := range rdns {
+		for _, a := range rdn {
+			elements = append(elements, types.ObjectValueMust(dnAttributeObjectType.AttrTypes, map[string]attr.Value{
+				"type":  types.StringValue(a.Type),
+				"value": types.StringValue(a.Value),
+			}))
+		}
+	}
+	value, diags := types.ListValue(dnAttributeObjectType, elements)
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
+
+func (f *FormatDnFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_dn"
+}
+
+func (f *FormatDnFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Format a distinguished name.",
+		MarkdownDescription: "Parses a distinguished name as `parse_dn` does and renders it in the requested style: " +
+			"`rfc4514` (`CN=example.org,O=Example,C=FR`) or `openssl` (`/C=FR/O=Example/CN=example.org`, as accepted by `openssl req -subj`). " +
+			"As the output is normalized, two DNs formatted in the same style can be compared as strings.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "dn",
+				Description: "Distinguished name to format, in RFC 4514 or OpenSSL style.",
+			},
+			function.StringParameter{
+				Name:                "style",
+				MarkdownDescription: "Output style. One of `rfc4514` or `openssl`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *FormatDnFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input, style string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input, &style))
+	if resp.Error != nil {
+		return
+	}
+
+	rdns, err := parseDN(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	var formatted string
+	switch style {
+	case dnStyleRFC4514:
+		formatted = formatDN(rdns, ",", escapeRFC4514)
+	case dnStyleOpenSSL:
+		reversed := make([][]dnAttribute, 0, len(rdns))
+		for i := len(rdns) - 1; i >= 0; i-- {
+			reversed = append(reversed, rdns[i])
+		}
+		if len(reversed) > 0 {
+			formatted = "/" + formatDN(reversed, "/", escapeOpenSSL)
+		}
+	default:
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("style must be one of %q or %q", dnStyleRFC4514, dnStyleOpenSSL))
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, formatted))
+}
+
+type dnAttribute struct {
+	Type  string
+	Value string
+}
+
+// dnTypeAliases maps upper-cased long names and OIDs of common attribute types
+// to their short name.
+var dnTypeAliases = map[string]string{
+	"COMMONNAME":                 "CN",
+	"2.5.4.3":                    "CN",
+	"SURNAME":                    "SN",
+	"2.5.4.4":                    "SN",
+	"SERIALNUMBER":               "SERIALNUMBER",
+	"2.5.4.5":                    "SERIALNUMBER",
+	"COUNTRYNAME":                "C",
+	"2.5.4.6":                    "C",
+	"LOCALITYNAME":               "L",
+	"2.5.4.7":                    "L",
+	"STATEORPROVINCENAME":        "ST",
+	"S":                          "ST",
+	"2.5.4.8":                    "ST",
+	"STREETADDRESS":              "STREET",
+	"2.5.4.9":                    "STREET",
+	"ORGANIZATIONNAME":           "O",
+	"2.5.4.10":                   "O",
+	"ORGANIZATIONALUNITNAME":     "OU",
+	"2.5.4.11":                   "OU",
+	"TITLE":                      "T",
+	"2.5.4.12":                   "T",
+	"GIVENNAME":                  "GIVENNAME",
+	"2.5.4.42":                   "GIVENNAME",
+	"ORGANIZATIONIDENTIFIER":     "ORGANIZATIONIDENTIFIER",
+	"2.5.4.97":                   "ORGANIZATIONIDENTIFIER",
+	"DOMAINCOMPONENT":            "DC",
+	"0.9.2342.19200300.100.1.25": "DC",
+	"USERID":                     "UID",
+	"0.9.2342.19200300.100.1.1":  "UID",
+	"E":                          "EMAILADDRESS",
+	"1.2.840.113549.1.9.1":       "EMAILADDRESS",
+}
+
+// parseDN parses an RFC 4514 or OpenSSL style DN and returns its RDNs in RFC
+// 4514 order.
+func parseDN(input string) ([][]dnAttribute, error) {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "/") {
+		rdns, err := parseDNComponents(input[1:], "/")
+		if err != nil {
+			return nil, err
+		}
+		for i, j := 0, len(rdns)-1; i < j; i, j = i+1, j-1 {
+			rdns[i], rdns[j] = rdns[j], rdns[i]
+		}
+		return rdns, nil
+	}
+	return parseDNComponents(input, ",;")
+}
+
+// parseDNComponents splits input into RDNs on rdnSeparators and into
+// attributes on `+`, resolving backslash escapes.
+func parseDNComponents(input, rdnSeparators string) ([][]dnAttribute, error) {
+	var rdns [][]dnAttribute
+	if strings.TrimSpace(input) == "" {
+		return rdns, nil
+	}
+
+	var (
+		rdn      []dnAttribute
+		typ      string
+		buf      []byte
+		inValue  bool
+		hexValue bool
+		// keep is the length of buf up to the last escaped or non-space
+		// character, so that unescaped trailing spaces can be trimmed.
+		keep int
+	)
+	flush := func(endOfRDN bool) error {
+		if !inValue {
+			return fmt.Errorf("missing `=` in %q", strings.TrimSpace(string(buf)))
+		}
+		value := string(buf[:keep])
+		if hexValue {
+			decoded, err := decodeDNHexValue(value)
+			if err != nil {
+				return fmt.Errorf("invalid value of %s: %w", typ, err)
+			}
+			value = decoded
+		}
+		rdn = append(rdn, dnAttribute{Type: typ, Value: value})
+		if endOfRDN {
+			rdns = append(rdns, rdn)
+			rdn = nil
+		}
+		typ, buf, inValue, hexValue, keep = "", nil, false, false, 0
+		return nil
+	}
+
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		switch {
+		case c == '\\':
+			if i+1 >= len(input) {
+				return nil, errors.New("trailing backslash")
+			}
+			if i+2 < len(input) && isHexDigit(input[i+1]) && isHexDigit(input[i+2]) {
+				b, _ := hex.DecodeString(input[i+1 : i+3])
+				buf = append(buf, b[0])
+				i += 2
+			} else {
+				buf = append(buf, input[i+1])
+				i++
+			}
+			keep = len(buf)
+		case c == '=' && !inValue:
+			typ = normalizeDNType(string(buf))
+			if typ == "" {
+				return nil, fmt.Errorf("missing attribute type before `=` at offset %d", i)
+			}
+			buf, inValue, keep = nil, true, 0
+		case c == '+':
+			if err := flush(false); err != nil {
+				return nil, err
+			}
+		case strings.IndexByte(rdnSeparators, c) >= 0:
+			if err := flush(true); err != nil {
+				return nil, err
+			}
+		case c == ' ' && len(buf) == 0:
+			// Leading spaces are not part of the type or value.
+		case c == '#' && inValue && len(buf) == 0:
+			hexValue = true
+		default:
+			buf = append(buf, c)
+			if c != ' ' {
+				keep = len(buf)
+			}
+		}
+	}
+	if err := flush(true); err != nil {
+		return nil, err
+	}
+	return rdns, nil
+}
+
+func normalizeDNType(typ string) string {
+	typ = strings.ToUpper(strings.TrimSpace(typ))
+	if alias, ok := dnTypeAliases[typ]; ok {
+		return alias
+	}
+	return typ
+}
+
+// decodeDNHexValue decodes a `#`-prefixed value, the BER encoding of a string.
+func decodeDNHexValue(value string) (string, error) {
+	der, err := hex.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+	var raw asn1.RawValue
+	if rest, err := asn1.Unmarshal(der, &raw); err != nil {
+		return "", err
+	} else if len(rest) > 0 {
+		return "", errors.New("trailing data after the encoded value")
+	}
+	if raw.Class != asn1.ClassUniversal {
+		return "", fmt.Errorf("unsupported ASN.1 class %d", raw.Class)
+	}
+	switch raw.Tag {
+	case asn1.TagUTF8String, asn1.TagPrintableString, asn1.TagIA5String, asn1.TagT61String, 26 /* VisibleString */ :
+		return string(raw.Bytes), nil
+	}
+	return "", fmt.Errorf("unsupported ASN.1 tag %d", raw.Tag)
+}
+
+func isHexDigit(c byte) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f') || ('A' <= c && c <= 'F')
+}
+
+func formatDN(rdns [][]dnAttribute, separator string, escape func(string) string) string {
+	parts := make([]string, 0, len(rdns))
+	for _, rdn := range rdns {
+		attributes := make([]string, 0, len(rdn))
+		for _, a := range rdn {
+			attributes = append(attributes, a.Type+"="+escape(a.Value))
+		}
+		parts = append(parts, strings.Join(attributes, "+"))
+	}
+	return strings.Join(parts, separator)
+}
+
+// escapeRFC4514 escapes a value as described in RFC 4514 section 2.4.
+func escapeRFC4514(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == 0:
+			b.WriteString(`\00`)
+			continue
+		case strings.IndexByte(`,+"\<>;`, c) >= 0,
+			c == '#' && i == 0,
+			c == ' ' && (i == 0 || i == len(value)-1):
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// escapeOpenSSL escapes the characters that delimit attributes in the OpenSSL
+// `-subj` syntax.
+func escapeOpenSSL(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if strings.IndexByte(`/+\`, value[i]) >= 0 {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(value[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runParseDn(t *testing.T, dn string) ([]dnAttribute, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(dn)})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.ListUnknown(dnAttributeObjectType))}
+	NewParseDnFunction().Run(t.Context(), req, resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var attributes []dnAttribute
+	for _, element := range resp.Result.Value().(types.List).Elements() {
+		attrs := element.(types.Object).Attributes()
+		attributes = append(attributes, dnAttribute{
+			Type:  attrs["type"].(types.String).ValueString(),
+			Value: attrs["value"].(types.String).ValueString(),
+		})
+	}
+	return attributes, nil
+}
+
+func runFormatDn(t *testing.T, dn, style string) (string, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(dn), types.StringValue(style)})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewFormatDnFunction().Run(t.Context(), req, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func TestParseDnFunction(t *testing.T) {
+	tests := []struct {
+		name string
+		dn   string
+		want []dnAttribute
+	}{
+		{
+			name: "rfc4514",
+			dn:   "CN=example.org, O=Example ,C=FR",
+			want: []dnAttribute{{"CN", "example.org"}, {"O", "Example"}, {"C", "FR"}},
+		},
+		{
+			name: "openssl style is returned in rfc4514 order",
+			dn:   "/C=FR/O=Example/CN=example.org",
+			want: []dnAttribute{{"CN", "example.org"}, {"O", "Example"}, {"C", "FR"}},
+		},
+		{
+			name: "escapes",
+			dn:   `CN=Doe\, John,O=\"Quoted\" \2B co\ ,OU=caf\C3\A9`,
+			want: []dnAttribute{{"CN", "Doe, John"}, {"O", `"Quoted" + co `}, {"OU", "café"}},
+		},
+		{
+			name: "openssl escapes",
+			dn:   `/O=A\/B/CN=x\+y`,
+			want: []dnAttribute{{"CN", "x+y"}, {"O", "A/B"}},
+		},
+		{
+			name: "type aliases and multi-valued RDN",
+			dn:   "commonName=a+2.5.4.5=42,organizationalUnitName=b,dc=org",
+			want: []dnAttribute{{"CN", "a"}, {"SERIALNUMBER", "42"}, {"OU", "b"}, {"DC", "org"}},
+		},
+		{
+			name: "hex-encoded value",
+			dn:   "1.2.840.113549.1.9.1=#161161646d696e406578616d706c652e6f7267,CN=a",
+			want: []dnAttribute{{"EMAILADDRESS", "admin@example.org"}, {"CN", "a"}},
+		},
+		{
+			name: "value containing equals",
+			dn:   "CN=a=b",
+			want: []dnAttribute{{"CN", "a=b"}},
+		},
+		{
+			name: "empty",
+			dn:   "",
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := runParseDn(t, tt.dn)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("parse_dn(%q) = %v, want %v", tt.dn, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("parse_dn(%q)[%d] = %v, want %v", tt.dn, i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseDnFunction_Errors(t *testing.T) {
+	for _, dn := range []string{"CN", "CN=a,O", "=a", `CN=a\`, "CN=#zz", "CN=#0203010203"} {
+		t.Run(dn, func(t *testing.T) {
+			if got, err := runParseDn(t, dn); err == nil {
+				t.Fatalf("parse_dn(%q) = %v, want an error", dn, got)
+			}
+		})
+	}
+}
+
+func TestFormatDnFunction(t *testing.T) {
+	tests := []struct {
+		dn, style, want string
+	}{
+		{"/C=FR/O=Example/CN=example.org", "rfc4514", "CN=example.org,O=Example,C=FR"},
+		{"cn=example.org, o=Example, c=FR", "openssl", "/C=FR/O=Example/CN=example.org"},
+		{`CN=Doe\, John,O=A/B`, "rfc4514", `CN=Doe\, John,O=A/B`},
+		{`CN=Doe\, John,O=A/B`, "openssl", `/O=A\/B/CN=Doe, John`},
+		{`CN=\ padded\ +SERIALNUMBER=1`, "rfc4514", `CN=\ padded\ +SERIALNUMBER=1`},
+		{`/CN=\#hash`, "rfc4514", `CN=\#hash`},
+		{"", "openssl", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dn+" "+tt.style, func(t *testing.T) {
+			got, err := runFormatDn(t, tt.dn, tt.style)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("format_dn(%q, %q) = %s, want %s", tt.dn, tt.style, got, tt.want)
+			}
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		const dn = `CN=a\,b+UID=c\+d,O=e\\f,C=FR`
+		openssl, err := runFormatDn(t, dn, "openssl")
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := runFormatDn(t, openssl, "rfc4514")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if back != dn {
+			t.Fatalf("round trip through %s = %s, want %s", openssl, back, dn)
+		}
+	})
+
+	t.Run("unknown style", func(t *testing.T) {
+		if _, err := runFormatDn(t, "CN=a", "ldap"); err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
@@ -0,0 +1,243 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Profiles of the WebRA, ACME, EST and SCEP enrollment modules share the
+// attributes binding them to a CA and bounding the certificates they issue.
+// Each resource adds the enrollment and authorization settings of its module
+// to them.
+
+// enrollmentProfileAttributes returns the attributes common to the profiles
+// of the enrollment modules.
+func enrollmentProfileAttributes(module string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"id": schema.StringAttribute{
+			Computed:    true,
+			Description: "Name of the profile.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		},
+		"name": schema.StringAttribute{
+			Required:    true,
+			Description: "Name of the profile, which " + module + " requests reference. Changing it creates a new profile.",
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		},
+		"enabled": schema.BoolAttribute{
+			Optional:    true,
+			Computed:    true,
+			Default:     booldefault.StaticBool(true),
+			Description: "Whether " + module + " requests can be submitted on the profile. Defaults to true.",
+		},
+		"pki_connector": schema.StringAttribute{
+			Required:            true,
+			MarkdownDescription: "Name of the PKI connector issuing the certificates of the profile, such as the `name` of a `horizon_ejbca_pki_connector`.",
+		},
+		"key_types": schema.SetAttribute{
+			Optional:            true,
+			ElementType:         types.StringType,
+			MarkdownDescription: "Key types accepted on the profile, such as `rsa-2048` or `ec-secp256r1`. All key types supported by the PKI connector are accepted when unset or empty.",
+		},
+		"max_lifetime": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: "Maximum lifetime of the certificates of the profile, as a duration such as `365 days` or `P1Y`. The PKI connector lifetime applies when unset.",
+		},
+	}
+}
+
+// authorizedKeyTypes returns the key types of a profile for its crypto policy,
+// nil when unset.
+func authorizedKeyTypes(ctx context.Context, keyTypes types.Set) ([]string, diag.Diagnostics) {
+	if keyTypes.IsNull() || keyTypes.IsUnknown() {
+		return nil, nil
+	}
+	var values []string
+	diags := keyTypes.ElementsAs(ctx, &values, false)
+	return values, diags
+}
+
+// keyTypesValue returns the key types accepted by a crypto policy, null when
+// every key type of the PKI connector is, unless prior is an empty set.
+func keyTypesValue(ctx context.Context, policy *models.ProfileCryptoPolicy, prior types.Set) (types.Set, diag.Diagnostics) {
+	if policy == nil || len(policy.AuthorizedKeyTypes) == 0 {
+		return emptySetValue(prior, types.StringType), nil
+	}
+	return types.SetValueFrom(ctx, types.StringType, policy.AuthorizedKeyTypes)
+}
+
+// emptySetValue returns the value of a set Horizon holds no element of.
+// Horizon does not tell an empty set from an unset one, so a set configured
+// as empty, as in the prior state or plan, stays empty; it is null otherwise.
+func emptySetValue(prior types.Set, elementType attr.Type) types.Set {
+	if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+		return prior
+	}
+	return types.SetNull(elementType)
+}
+
+// emptyListValue is emptySetValue for lists.
+func emptyListValue(prior types.List, elementType attr.Type) types.List {
+	if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+		return prior
+	}
+	return types.ListNull(elementType)
+}
+
+// emptyMapValue is emptySetValue for maps.
+func emptyMapValue(prior types.Map, elementType attr.Type) types.Map {
+	if !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+		return prior
+	}
+	return types.MapNull(elementType)
+}
+
+// Horizon stores durations the way Scala prints them, such as `365 days` or
+// `12 hours`. ISO 8601 durations such as `P1Y` are accepted too and converted,
+// counting a year as 365 days and a month as 30 days. The notation of the
+// configuration is kept in state as long as the duration is the same.
+
+// horizonDurationPattern matches the durations of Horizon, such as `7 days`.
+var horizonDurationPattern = regexp.MustCompile(`^(\d+)\s*(d|days?|h|hours?|min|mins|minutes?|s|secs?|seconds?)$`)
+
+// isoDurationPattern matches ISO 8601 durations, such as `P1Y` or `PT12H`.
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+const day = 24 * time.Hour
+
+var isoDurationUnits = []time.Duration{365 * day, 30 * day, 7 * day, day, time.Hour, time.Minute, time.Second}
+
+// horizonDurationUnits are the units durations are sent to Horizon in, from
+// the largest.
+var horizonDurationUnits = []struct {
+	name   string
+	length time.Duration
+}{
+	{"day", day},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// parseDuration parses a Horizon or ISO 8601 duration.
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if m := horizonDurationPattern.FindStringSubmatch(strings.ToLower(value)); m != nil {
+		count, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		unit := time.Second
+		switch {
+		case strings.HasPrefix(m[2], "d"):
+			unit = day
+		case strings.HasPrefix(m[2], "h"):
+			unit = time.Hour
+		case strings.HasPrefix(m[2], "m"):
+			unit = time.Minute
+		}
+		return time.Duration(count) * unit, nil
+	}
+
+	m := isoDurationPattern.FindStringSubmatch(value)
+	if m == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, fmt.Errorf("%q is not a duration such as \"365 days\" or P1Y", value)
+	}
+	var duration time.Duration
+	for i, unit := range isoDurationUnits {
+		if m[i+1] == "" {
+			continue
+		}
+		count, err := strconv.ParseInt(m[i+1], 10, 64)
+		if err != nil {
+			return 0, err
+		}
+		duration += time.Duration(count) * unit
+	}
+	return duration, nil
+}
+
+// formatHorizonDuration prints d in the largest unit dividing it, the way
+// Horizon does.
+func formatHorizonDuration(d time.Duration) string {
+	for _, unit := range horizonDurationUnits {
+		if d%unit.length != 0 {
+			continue
+		}
+		count := int64(d / unit.length)
+		if count == 1 {
+			return "1 " + unit.name
+		}
+		return fmt.Sprintf("%d %ss", count, unit.name)
+	}
+	return fmt.Sprintf("%d seconds", int64(d/time.Second))
+}
+
+// horizonDuration returns value in the format of Horizon, or nil when unset.
+func horizonDuration(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	duration, err := parseDuration(value.ValueString())
+	if err != nil {
+		// Rejected by validateDuration beforehand.
+		return value.ValueStringPointer()
+	}
+	formatted := formatHorizonDuration(duration)
+	return &formatted
+}
+
+// durationValue returns the duration stored in Horizon, keeping the notation
+// of current when both are the same duration.
+func durationValue(current types.String, stored *string) types.String {
+	if stored == nil {
+		return types.StringNull()
+	}
+	if !current.IsNull() && !current.IsUnknown() {
+		want, err := parseDuration(current.ValueString())
+		got, storedErr := parseDuration(*stored)
+		if err == nil && storedErr == nil && want == got {
+			return current
+		}
+	}
+	return types.StringValue(*stored)
+}
+
+// validateDuration checks that the known value of attribute is a positive
+// Horizon or ISO 8601 duration.
+func validateDuration(attribute string, value types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if value.IsNull() || value.IsUnknown() {
+		return diags
+	}
+	duration, err := parseDuration(value.ValueString())
+	if err == nil && duration <= 0 {
+		err = fmt.Errorf("%q is not a positive duration", value.ValueString())
+	}
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root(attribute),
+			fmt.Sprintf("Invalid %s value", attribute),
+			err.Error()+".",
+		)
+	}
+	return diags
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "365 days", want: 365 * day},
+		{value: "1 day", want: day},
+		{value: "12 hours", want: 12 * time.Hour},
+		{value: "30 minutes", want: 30 * time.Minute},
+		{value: "90s", want: 90 * time.Second},
+		{value: "P1Y", want: 365 * day},
+		{value: "P1Y6M", want: 545 * day},
+		{value: "P2W", want: 14 * day},
+		{value: "P1DT12H", want: 36 * time.Hour},
+		{value: "PT30M", want: 30 * time.Minute},
+		{value: "P", wantErr: true},
+		{value: "P1DT", wantErr: true},
+		{value: "one year", wantErr: true},
+		{value: "7 weeks", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := parseDuration(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("wantErr=%v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Fatalf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatHorizonDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		365 * day:                    "365 days",
+		day:                          "1 day",
+		36 * time.Hour:               "36 hours",
+		90 * time.Minute:             "90 minutes",
+		time.Minute + 30*time.Second: "90 seconds",
+	}
+	for duration, want := range tests {
+		if got := formatHorizonDuration(duration); got != want {
+			t.Errorf("formatHorizonDuration(%s) = %s, want %s", duration, got, want)
+		}
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		current types.String
+		stored  *string
+		want    types.String
+	}{
+		{name: "unset", current: types.StringValue("P1Y"), want: types.StringNull()},
+		{name: "same duration", current: types.StringValue("P1Y"), stored: strPtr("365 days"), want: types.StringValue("P1Y")},
+		{name: "changed outside of Terraform", current: types.StringValue("P1Y"), stored: strPtr("90 days"), want: types.StringValue("90 days")},
+		{name: "imported", current: types.StringNull(), stored: strPtr("7 days"), want: types.StringValue("7 days")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := durationValue(tt.current, tt.stored); !got.Equal(tt.want) {
+				t.Fatalf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestKeyTypesValue(t *testing.T) {
+	empty := types.SetValueMust(types.StringType, nil)
+	tests := []struct {
+		name   string
+		policy *models.ProfileCryptoPolicy
+		prior  types.Set
+		want   types.Set
+	}{
+		{name: "unset", prior: types.SetNull(types.StringType), want: types.SetNull(types.StringType)},
+		{name: "imported", policy: &models.ProfileCryptoPolicy{}, prior: types.SetNull(types.StringType), want: types.SetNull(types.StringType)},
+		{name: "configured empty", policy: &models.ProfileCryptoPolicy{}, prior: empty, want: empty},
+		{name: "emptied outside of Terraform", prior: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("rsa-2048")}), want: types.SetNull(types.StringType)},
+		{
+			name:   "stored",
+			policy: &models.ProfileCryptoPolicy{AuthorizedKeyTypes: []string{"rsa-2048"}},
+			prior:  empty,
+			want:   types.SetValueMust(types.StringType, []attr.Value{types.StringValue("rsa-2048")}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := keyTypesValue(t.Context(), tt.policy, tt.prior)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
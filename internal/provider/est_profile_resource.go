@@ -0,0 +1,270 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const estAuthorizationChallenge = "challenge"
+
+// estAuthorizationModes are the ways EST clients may authenticate: with a
+// challenge password Horizon generates for each request, with the credentials
+// of a local account, or with a client certificate.
+var estAuthorizationModes = []string{estAuthorizationChallenge, "basic", "x509"}
+
+func NewEstProfileResource() resource.Resource {
+	return &EstProfileResource{}
+}
+
+// EstProfileResource manages an EST enrollment profile.
+type EstProfileResource struct {
+	client *horizon.APIClient
+}
+
+type estProfileResourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	Name                types.String `tfsdk:"name"`
+	Enabled             types.Bool   `tfsdk:"enabled"`
+	PkiConnector        types.String `tfsdk:"pki_connector"`
+	KeyTypes            types.Set    `tfsdk:"key_types"`
+	MaxLifetime         types.String `tfsdk:"max_lifetime"`
+	AuthorizationMode   types.String `tfsdk:"authorization_mode"`
+	ChallengeLifetime   types.String `tfsdk:"challenge_lifetime"`
+	Renewal             types.Bool   `tfsdk:"renewal"`
+	ServerKeyGeneration types.Bool   `tfsdk:"server_key_generation"`
+}
+
+func (r *EstProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_est_profile"
+}
+
+func (r *EstProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an EST profile, which network devices and other EST clients enroll on, at `/.well-known/est/<name>`.",
+		Attributes:          enrollmentProfileAttributes("EST"),
+	}
+
+	resp.Schema.Attributes["authorization_mode"] = schema.StringAttribute{
+		Required: true,
+		MarkdownDescription: "How clients authenticate their enrollment requests: `challenge` with a one-time password generated in Horizon for each request, " +
+			"`basic` with the credentials of a `horizon_local_account` allowed to enroll on the profile, or `x509` with a client certificate.",
+	}
+	resp.Schema.Attributes["challenge_lifetime"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "How long a generated challenge password can be used, as a duration such as `1 hour` or `PT1H`. Only applies to the `challenge` authorization mode.",
+	}
+	resp.Schema.Attributes["renewal"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+		MarkdownDescription: "Whether clients may renew their certificate with `simplereenroll`, authenticated by the certificate itself. Defaults to true.",
+	}
+	resp.Schema.Attributes["server_key_generation"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+		MarkdownDescription: "Whether clients may have Horizon generate their key pair with `serverkeygen`. Defaults to false.",
+	}
+}
+
+func (r *EstProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *EstProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data estProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, diags := estProfileFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating EST profile %s", profile.Name))
+	created, _, err := r.client.EstProfileAPI.EstProfileCreate(ctx).EstProfile(*profile).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create EST profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillEstProfileModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EstProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data estProfileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, httpResp, err := r.client.EstProfileAPI.EstProfileGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("EST profile %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get EST profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillEstProfileModel(ctx, &data, profile)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EstProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data estProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, diags := estProfileFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating EST profile %s", profile.Name))
+	updated, _, err := r.client.EstProfileAPI.EstProfileUpdate(ctx).EstProfile(*profile).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update EST profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillEstProfileModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *EstProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data estProfileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.EstProfileAPI.EstProfileDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete EST profile", err.Error())
+	}
+}
+
+func (r *EstProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *EstProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data estProfileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateEstProfile(ctx, data)...)
+}
+
+// validateEstProfile checks the known values of an EST profile configuration.
+func validateEstProfile(ctx context.Context, data estProfileResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(validateDuration("max_lifetime", data.MaxLifetime)...)
+	diags.Append(validateDuration("challenge_lifetime", data.ChallengeLifetime)...)
+
+	if data.AuthorizationMode.IsUnknown() {
+		return diags
+	}
+	mode := data.AuthorizationMode.ValueString()
+	if !containsString(estAuthorizationModes, mode) {
+		diags.AddAttributeError(
+			path.Root("authorization_mode"),
+			"Invalid authorization_mode value",
+			fmt.Sprintf("%q is not an EST authorization mode. Expected one of %s.", mode, strings.Join(estAuthorizationModes, ", ")),
+		)
+		return diags
+	}
+	if mode != estAuthorizationChallenge && !data.ChallengeLifetime.IsNull() {
+		diags.AddAttributeError(
+			path.Root("challenge_lifetime"),
+			"Unused challenge_lifetime",
+			"challenge_lifetime only applies to the challenge authorization mode.",
+		)
+	}
+	return diags
+}
+
+func estProfileFromModel(ctx context.Context, data estProfileResourceModel) (*models.EstProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	profile := models.NewEstProfileWithDefaults()
+	profile.Name = data.Name.ValueString()
+	profile.Enabled = data.Enabled.ValueBoolPointer()
+	profile.PkiConnector = data.PkiConnector.ValueStringPointer()
+	profile.MaxCertificateLifetime = horizonDuration(data.MaxLifetime)
+	profile.AuthorizationMode = data.AuthorizationMode.ValueString()
+	profile.ChallengeLifetime = horizonDuration(data.ChallengeLifetime)
+	profile.Renewal = data.Renewal.ValueBoolPointer()
+	profile.ServerKeyGeneration = data.ServerKeyGeneration.ValueBoolPointer()
+	keyTypes, d := authorizedKeyTypes(ctx, data.KeyTypes)
+	diags.Append(d...)
+	if keyTypes != nil {
+		profile.CryptoPolicy = &models.ProfileCryptoPolicy{AuthorizedKeyTypes: keyTypes}
+	}
+	return profile, diags
+}
+
+func fillEstProfileModel(ctx context.Context, data *estProfileResourceModel, profile *models.EstProfile) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(profile.Name)
+	data.Name = types.StringValue(profile.Name)
+	data.Enabled = types.BoolValue(profile.Enabled == nil || *profile.Enabled)
+	data.PkiConnector = types.StringPointerValue(profile.PkiConnector)
+	data.MaxLifetime = durationValue(data.MaxLifetime, profile.MaxCertificateLifetime)
+	data.AuthorizationMode = types.StringValue(profile.AuthorizationMode)
+	data.ChallengeLifetime = durationValue(data.ChallengeLifetime, profile.ChallengeLifetime)
+	data.Renewal = types.BoolValue(profile.Renewal == nil || *profile.Renewal)
+	data.ServerKeyGeneration = types.BoolValue(profile.ServerKeyGeneration != nil && *profile.ServerKeyGeneration)
+
+	keyTypes, d := keyTypesValue(ctx, profile.CryptoPolicy, data.KeyTypes)
+	diags.Append(d...)
+	data.KeyTypes = keyTypes
+	return diags
+}
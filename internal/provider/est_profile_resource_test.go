@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillEstProfileModel(t *testing.T) {
+	ctx := context.Background()
+	configured := func(challengeLifetime types.String) func(t *testing.T) estProfileResourceModel {
+		return func(t *testing.T) estProfileResourceModel {
+			return estProfileResourceModel{
+				Name:              types.StringValue("routers"),
+				KeyTypes:          types.SetNull(types.StringType),
+				MaxLifetime:       types.StringNull(),
+				ChallengeLifetime: challengeLifetime,
+			}
+		}
+	}
+	imported := func(t *testing.T) estProfileResourceModel {
+		var data estProfileResourceModel
+		if diags := importedState(t, NewEstProfileResource(), "routers").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+	stored := func(challengeLifetime *string) *models.EstProfile {
+		return &models.EstProfile{
+			Name:              "routers",
+			PkiConnector:      strPtr("ejbca-issuing"),
+			AuthorizationMode: "challenge",
+			ChallengeLifetime: challengeLifetime,
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		prior                 func(t *testing.T) estProfileResourceModel
+		profile               *models.EstProfile
+		wantChallengeLifetime types.String
+	}{
+		{
+			// Horizon stores durations in its own notation.
+			name:                  "duration notation is kept",
+			prior:                 configured(types.StringValue("PT1H")),
+			profile:               stored(strPtr("1 hour")),
+			wantChallengeLifetime: types.StringValue("PT1H"),
+		},
+		{
+			name:                  "duration changed outside of Terraform",
+			prior:                 configured(types.StringValue("PT1H")),
+			profile:               stored(strPtr("30 minutes")),
+			wantChallengeLifetime: types.StringValue("30 minutes"),
+		},
+		{
+			name:                  "duration unset outside of Terraform",
+			prior:                 configured(types.StringValue("PT1H")),
+			profile:               stored(nil),
+			wantChallengeLifetime: types.StringNull(),
+		},
+		{
+			name:                  "imported by name",
+			prior:                 imported,
+			profile:               stored(strPtr("1 hour")),
+			wantChallengeLifetime: types.StringValue("1 hour"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.profile.Name {
+				t.Fatalf("Read looks up the profile %q, want %q", data.Name.ValueString(), tt.profile.Name)
+			}
+			if diags := fillEstProfileModel(ctx, &data, tt.profile); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "routers" || data.AuthorizationMode.ValueString() != "challenge" || !data.MaxLifetime.IsNull() || !data.KeyTypes.IsNull() {
+				t.Errorf("id %s, authorization_mode %s, max_lifetime %s, key_types %s", data.Id, data.AuthorizationMode, data.MaxLifetime, data.KeyTypes)
+			}
+			// Unset flags read as the schema defaults, so that omitting them
+			// does not plan a change.
+			if !data.Enabled.ValueBool() || !data.Renewal.ValueBool() || data.ServerKeyGeneration.ValueBool() {
+				t.Errorf("enabled %s, renewal %s, server_key_generation %s", data.Enabled, data.Renewal, data.ServerKeyGeneration)
+			}
+			if !data.ChallengeLifetime.Equal(tt.wantChallengeLifetime) {
+				t.Errorf("challenge_lifetime = %s, want %s", data.ChallengeLifetime, tt.wantChallengeLifetime)
+			}
+		})
+	}
+}
+
+func TestEstProfileFromModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name                  string
+		keyTypes              types.Set
+		challengeLifetime     types.String
+		wantKeyTypes          []string
+		wantChallengeLifetime *string
+	}{
+		{
+			name:              "unset key types and lifetime are not sent",
+			keyTypes:          types.SetNull(types.StringType),
+			challengeLifetime: types.StringNull(),
+		},
+		{
+			// Durations are sent in the notation of Horizon.
+			name:                  "key types and lifetime are sent",
+			keyTypes:              types.SetValueMust(types.StringType, []attr.Value{types.StringValue("rsa-2048")}),
+			challengeLifetime:     types.StringValue("PT1H"),
+			wantKeyTypes:          []string{"rsa-2048"},
+			wantChallengeLifetime: strPtr("1 hour"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, diags := estProfileFromModel(ctx, estProfileResourceModel{
+				Name:                types.StringValue("routers"),
+				Enabled:             types.BoolValue(true),
+				PkiConnector:        types.StringValue("ejbca-issuing"),
+				KeyTypes:            tt.keyTypes,
+				MaxLifetime:         types.StringNull(),
+				AuthorizationMode:   types.StringValue("challenge"),
+				ChallengeLifetime:   tt.challengeLifetime,
+				Renewal:             types.BoolValue(true),
+				ServerKeyGeneration: types.BoolValue(false),
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if profile.Name != "routers" || profile.AuthorizationMode != "challenge" || profile.MaxCertificateLifetime != nil {
+				t.Errorf("unexpected profile: %+v", profile)
+			}
+			if !types.StringPointerValue(profile.ChallengeLifetime).Equal(types.StringPointerValue(tt.wantChallengeLifetime)) {
+				t.Errorf("challenge lifetime = %v, want %v", profile.ChallengeLifetime, tt.wantChallengeLifetime)
+			}
+			var keyTypes []string
+			if profile.CryptoPolicy != nil {
+				keyTypes = profile.CryptoPolicy.AuthorizedKeyTypes
+			}
+			if !slices.Equal(keyTypes, tt.wantKeyTypes) || (tt.wantKeyTypes == nil && profile.CryptoPolicy != nil) {
+				t.Errorf("crypto policy = %+v, want key types %v", profile.CryptoPolicy, tt.wantKeyTypes)
+			}
+		})
+	}
+}
+
+func TestValidateEstProfile(t *testing.T) {
+	ctx := context.Background()
+	base := func() estProfileResourceModel {
+		return estProfileResourceModel{
+			Name:              types.StringValue("p"),
+			MaxLifetime:       types.StringNull(),
+			AuthorizationMode: types.StringValue("challenge"),
+			ChallengeLifetime: types.StringNull(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*estProfileResourceModel)
+		wantErr bool
+	}{
+		{name: "challenge", mutate: func(d *estProfileResourceModel) {
+			d.ChallengeLifetime = types.StringValue("PT30M")
+		}},
+		{name: "client certificate", mutate: func(d *estProfileResourceModel) {
+			d.AuthorizationMode = types.StringValue("x509")
+		}},
+		{name: "unknown mode", wantErr: true, mutate: func(d *estProfileResourceModel) {
+			d.AuthorizationMode = types.StringValue("kerberos")
+		}},
+		{name: "challenge lifetime without challenge", wantErr: true, mutate: func(d *estProfileResourceModel) {
+			d.AuthorizationMode = types.StringValue("basic")
+			d.ChallengeLifetime = types.StringValue("PT30M")
+		}},
+		{name: "invalid challenge lifetime", wantErr: true, mutate: func(d *estProfileResourceModel) {
+			d.ChallengeLifetime = types.StringValue("an hour")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base()
+			tt.mutate(&data)
+			if diags := validateEstProfile(ctx, data); diags.HasError() != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func NewGradingPolicyResource() resource.Resource {
+	return &GradingPolicyResource{}
+}
+
+// GradingPolicyResource manages a grading policy, which grades the
+// certificates of its scope against a set of grading rules.
+type GradingPolicyResource struct {
+	client *horizon.APIClient
+}
+
+type gradingPolicyResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Scope       types.String `tfsdk:"scope"`
+	Rules       types.Set    `tfsdk:"rules"`
+}
+
+func (r *GradingPolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grading_policy"
+}
+
+func (r *GradingPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a grading policy, which grades the certificates of its scope against a set of `horizon_grading_rule`. " +
+			"The grades it gives appear in the `grades` of `horizon_certificate`, under the name of the policy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the policy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the policy, naming the grades it gives. Changing it creates a new policy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the policy.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the policy grades certificates. Defaults to true.",
+			},
+			"scope": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "HRQL query matching the certificates the policy grades, such as `module equals \"webra\"`. The policy grades every certificate when unset.",
+			},
+			"rules": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the grading rules the policy checks, such as the `name` of a `horizon_grading_rule`.",
+			},
+		},
+	}
+}
+
+func (r *GradingPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *GradingPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data gradingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, diags := gradingPolicyFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating grading policy %s", policy.Name))
+	created, _, err := r.client.GradingPolicyAPI.GradingPolicyCreate(ctx).GradingPolicy(*policy).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create grading policy", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillGradingPolicyModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GradingPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data gradingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, httpResp, err := r.client.GradingPolicyAPI.GradingPolicyGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Grading policy %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get grading policy", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillGradingPolicyModel(ctx, &data, policy)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GradingPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data gradingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy, diags := gradingPolicyFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating grading policy %s", policy.Name))
+	updated, _, err := r.client.GradingPolicyAPI.GradingPolicyUpdate(ctx).GradingPolicy(*policy).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update grading policy", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillGradingPolicyModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GradingPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data gradingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.GradingPolicyAPI.GradingPolicyDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete grading policy", err.Error())
+	}
+}
+
+func (r *GradingPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *GradingPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data gradingPolicyResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateGradingPolicy(data)...)
+}
+
+// validateGradingPolicy checks the known values of a grading policy
+// configuration.
+func validateGradingPolicy(data gradingPolicyResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.Scope.IsNull() && !data.Scope.IsUnknown() && strings.TrimSpace(data.Scope.ValueString()) == "" {
+		diags.AddAttributeError(
+			path.Root("scope"),
+			"Invalid scope value",
+			"scope must be a non-empty HRQL query. Leave it unset to grade every certificate.",
+		)
+	}
+	if !data.Rules.IsNull() && !data.Rules.IsUnknown() && len(data.Rules.Elements()) == 0 {
+		diags.AddAttributeError(
+			path.Root("rules"),
+			"Invalid rules value",
+			"A grading policy checks at least one rule.",
+		)
+	}
+	return diags
+}
+
+func gradingPolicyFromModel(ctx context.Context, data gradingPolicyResourceModel) (*models.GradingPolicy, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	policy := models.NewGradingPolicyWithDefaults()
+	policy.Name = data.Name.ValueString()
+	policy.Description = data.Description.ValueStringPointer()
+	policy.Enabled = data.Enabled.ValueBoolPointer()
+	policy.Scope = data.Scope.ValueStringPointer()
+	diags.Append(data.Rules.ElementsAs(ctx, &policy.Rules, false)...)
+	return policy, diags
+}
+
+func fillGradingPolicyModel(ctx context.Context, data *gradingPolicyResourceModel, policy *models.GradingPolicy) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	data.Id = types.StringValue(policy.Name)
+	data.Name = types.StringValue(policy.Name)
+	data.Description = types.StringPointerValue(policy.Description)
+	data.Enabled = types.BoolValue(policy.Enabled == nil || *policy.Enabled)
+	data.Scope = types.StringPointerValue(policy.Scope)
+	data.Rules, d = types.SetValueFrom(ctx, types.StringType, policy.Rules)
+	diags.Append(d...)
+	return diags
+}
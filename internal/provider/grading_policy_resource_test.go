@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillGradingPolicyModel(t *testing.T) {
+	ctx := context.Background()
+	rules := func(names ...string) types.Set {
+		set, _ := types.SetValueFrom(ctx, types.StringType, names)
+		return set
+	}
+
+	tests := []struct {
+		name        string
+		prior       func(t *testing.T) gradingPolicyResourceModel
+		policy      *models.GradingPolicy
+		wantEnabled bool
+		wantScope   types.String
+		wantRules   types.Set
+	}{
+		{
+			name: "changed outside of Terraform",
+			prior: func(t *testing.T) gradingPolicyResourceModel {
+				return gradingPolicyResourceModel{
+					Name:    types.StringValue("crypto-policy"),
+					Enabled: types.BoolValue(true),
+					Scope:   types.StringValue(`module equals "webra"`),
+					Rules:   rules("min-rsa-key-size"),
+				}
+			},
+			policy:      &models.GradingPolicy{Name: "crypto-policy", Enabled: boolPtr(false), Rules: []string{"min-rsa-key-size", "max-lifetime"}},
+			wantEnabled: false,
+			wantScope:   types.StringNull(),
+			wantRules:   rules("min-rsa-key-size", "max-lifetime"),
+		},
+		{
+			// Horizon leaves out enabled when it is true.
+			name: "imported by name",
+			prior: func(t *testing.T) gradingPolicyResourceModel {
+				var data gradingPolicyResourceModel
+				if diags := importedState(t, NewGradingPolicyResource(), "crypto-policy").Get(ctx, &data); diags.HasError() {
+					t.Fatal(diags)
+				}
+				return data
+			},
+			policy:      &models.GradingPolicy{Name: "crypto-policy", Scope: strPtr(`module equals "webra"`), Rules: []string{"min-rsa-key-size"}},
+			wantEnabled: true,
+			wantScope:   types.StringValue(`module equals "webra"`),
+			wantRules:   rules("min-rsa-key-size"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.policy.Name {
+				t.Fatalf("Read looks up the policy %q, want %q", data.Name.ValueString(), tt.policy.Name)
+			}
+			if diags := fillGradingPolicyModel(ctx, &data, tt.policy); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "crypto-policy" || !data.Description.IsNull() {
+				t.Errorf("id %s, description %s", data.Id, data.Description)
+			}
+			if data.Enabled.ValueBool() != tt.wantEnabled {
+				t.Errorf("enabled = %s, want %t", data.Enabled, tt.wantEnabled)
+			}
+			if !data.Scope.Equal(tt.wantScope) {
+				t.Errorf("scope = %s, want %s", data.Scope, tt.wantScope)
+			}
+			if !data.Rules.Equal(tt.wantRules) {
+				t.Errorf("rules = %s, want %s", data.Rules, tt.wantRules)
+			}
+		})
+	}
+}
+
+func TestGradingPolicyFromModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name      string
+		scope     types.String
+		wantScope *string
+	}{
+		// Without scope, the policy grades every certificate.
+		{name: "null scope is not sent", scope: types.StringNull()},
+		{name: "scope is sent", scope: types.StringValue(`module equals "webra"`), wantScope: strPtr(`module equals "webra"`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy, diags := gradingPolicyFromModel(ctx, gradingPolicyResourceModel{
+				Name:        types.StringValue("crypto-policy"),
+				Description: types.StringNull(),
+				Enabled:     types.BoolValue(false),
+				Scope:       tt.scope,
+				Rules:       types.SetValueMust(types.StringType, []attr.Value{types.StringValue("min-rsa-key-size")}),
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if policy.Name != "crypto-policy" || policy.Description != nil || policy.Enabled == nil || *policy.Enabled {
+				t.Errorf("unexpected policy: %+v", policy)
+			}
+			if !types.StringPointerValue(policy.Scope).Equal(types.StringPointerValue(tt.wantScope)) {
+				t.Errorf("scope = %v, want %v", policy.Scope, tt.wantScope)
+			}
+			if !slices.Equal(policy.Rules, []string{"min-rsa-key-size"}) {
+				t.Errorf("rules = %v", policy.Rules)
+			}
+		})
+	}
+}
+
+func TestValidateGradingPolicy(t *testing.T) {
+	base := func() gradingPolicyResourceModel {
+		return gradingPolicyResourceModel{
+			Name:  types.StringValue("p"),
+			Scope: types.StringNull(),
+			Rules: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("min-rsa-key-size")}),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*gradingPolicyResourceModel)
+		wantErr bool
+	}{
+		{name: "defaults", mutate: func(d *gradingPolicyResourceModel) {}},
+		{name: "scope", mutate: func(d *gradingPolicyResourceModel) {
+			d.Scope = types.StringValue(`module equals "webra"`)
+		}},
+		{name: "blank scope", wantErr: true, mutate: func(d *gradingPolicyResourceModel) {
+			d.Scope = types.StringValue("")
+		}},
+		{name: "unknown rules", mutate: func(d *gradingPolicyResourceModel) {
+			d.Rules = types.SetUnknown(types.StringType)
+		}},
+		{name: "no rules", wantErr: true, mutate: func(d *gradingPolicyResourceModel) {
+			d.Rules = types.SetValueMust(types.StringType, []attr.Value{})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base()
+			tt.mutate(&data)
+			if diags := validateGradingPolicy(data); diags.HasError() != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
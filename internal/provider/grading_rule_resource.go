@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// gradingSeverities are the severities of grading rules, from the least to
+// the most severe.
+var gradingSeverities = []string{"low", "medium", "high", "critical"}
+
+func NewGradingRuleResource() resource.Resource {
+	return &GradingRuleResource{}
+}
+
+// GradingRuleResource manages a grading rule, a check grading policies run on
+// certificates.
+type GradingRuleResource struct {
+	client *horizon.APIClient
+}
+
+type gradingRuleResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Expression  types.String `tfsdk:"expression"`
+	Severity    types.String `tfsdk:"severity"`
+}
+
+func (r *GradingRuleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_grading_rule"
+}
+
+func (r *GradingRuleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a grading rule, a check of the certificates such as a minimum key size or a forbidden signature algorithm. " +
+			"Rules grade certificates once part of a `horizon_grading_policy`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the rule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the rule. Changing it creates a new rule.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the rule, shown with the grades it gives.",
+			},
+			"expression": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "HRQL query matching the certificates that break the rule, such as `keytype in [\"rsa-1024\"]`. " +
+					"The `provider::horizon::hrql` function builds it with every value quoted.",
+			},
+			"severity": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Severity of the rule, one of `low`, `medium`, `high` or `critical`. The grade a policy gives a certificate drops with the severity of the rules it breaks.",
+			},
+		},
+	}
+}
+
+func (r *GradingRuleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *GradingRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data gradingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule := gradingRuleFromModel(data)
+	tflog.Info(ctx, fmt.Sprintf("Creating grading rule %s", rule.Name))
+	created, _, err := r.client.GradingRuleAPI.GradingRuleCreate(ctx).GradingRule(*rule).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create grading rule", err.Error())
+		return
+	}
+
+	fillGradingRuleModel(&data, created)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GradingRuleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data gradingRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule, httpResp, err := r.client.GradingRuleAPI.GradingRuleGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Grading rule %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get grading rule", err.Error())
+		return
+	}
+
+	fillGradingRuleModel(&data, rule)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GradingRuleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data gradingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rule := gradingRuleFromModel(data)
+	tflog.Info(ctx, fmt.Sprintf("Updating grading rule %s", rule.Name))
+	updated, _, err := r.client.GradingRuleAPI.GradingRuleUpdate(ctx).GradingRule(*rule).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update grading rule", err.Error())
+		return
+	}
+
+	fillGradingRuleModel(&data, updated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *GradingRuleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data gradingRuleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.GradingRuleAPI.GradingRuleDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete grading rule", err.Error())
+	}
+}
+
+func (r *GradingRuleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *GradingRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data gradingRuleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateGradingRule(data)...)
+}
+
+// validateGradingRule checks the known values of a grading rule configuration.
+func validateGradingRule(data gradingRuleResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.Expression.IsNull() && !data.Expression.IsUnknown() && strings.TrimSpace(data.Expression.ValueString()) == "" {
+		diags.AddAttributeError(
+			path.Root("expression"),
+			"Invalid expression value",
+			"expression must be a non-empty HRQL query.",
+		)
+	}
+	if !data.Severity.IsNull() && !data.Severity.IsUnknown() && !containsString(gradingSeverities, data.Severity.ValueString()) {
+		diags.AddAttributeError(
+			path.Root("severity"),
+			"Invalid severity value",
+			fmt.Sprintf("%q is not a grading severity. Expected one of %s.", data.Severity.ValueString(), strings.Join(gradingSeverities, ", ")),
+		)
+	}
+	return diags
+}
+
+func gradingRuleFromModel(data gradingRuleResourceModel) *models.GradingRule {
+	rule := models.NewGradingRuleWithDefaults()
+	rule.Name = data.Name.ValueString()
+	rule.Description = data.Description.ValueStringPointer()
+	rule.Expression = data.Expression.ValueString()
+	rule.Severity = data.Severity.ValueString()
+	return rule
+}
+
+func fillGradingRuleModel(data *gradingRuleResourceModel, rule *models.GradingRule) {
+	data.Id = types.StringValue(rule.Name)
+	data.Name = types.StringValue(rule.Name)
+	data.Description = types.StringPointerValue(rule.Description)
+	data.Expression = types.StringValue(rule.Expression)
+	data.Severity = types.StringValue(rule.Severity)
+}
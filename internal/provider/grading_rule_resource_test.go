@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillGradingRuleModel(t *testing.T) {
+	ctx := context.Background()
+	rule := &models.GradingRule{
+		Name:       "min-rsa-key-size",
+		Expression: `keytype in ["rsa-512", "rsa-1024"]`,
+		Severity:   "critical",
+	}
+
+	tests := []struct {
+		name  string
+		prior func(t *testing.T) gradingRuleResourceModel
+	}{
+		{
+			name: "changed outside of Terraform",
+			prior: func(t *testing.T) gradingRuleResourceModel {
+				return gradingRuleResourceModel{
+					Name:        types.StringValue("min-rsa-key-size"),
+					Description: types.StringValue("RSA keys are at least 2048 bits long"),
+					Expression:  types.StringValue(`keytype in ["rsa-1024"]`),
+					Severity:    types.StringValue("high"),
+				}
+			},
+		},
+		{
+			name: "imported by name",
+			prior: func(t *testing.T) gradingRuleResourceModel {
+				var data gradingRuleResourceModel
+				if diags := importedState(t, NewGradingRuleResource(), "min-rsa-key-size").Get(ctx, &data); diags.HasError() {
+					t.Fatal(diags)
+				}
+				return data
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != rule.Name {
+				t.Fatalf("Read looks up the rule %q, want %q", data.Name.ValueString(), rule.Name)
+			}
+			fillGradingRuleModel(&data, rule)
+			if data.Id.ValueString() != "min-rsa-key-size" || !data.Description.IsNull() {
+				t.Errorf("id %s, description %s", data.Id, data.Description)
+			}
+			if data.Expression.ValueString() != rule.Expression || data.Severity.ValueString() != "critical" {
+				t.Errorf("expression %s, severity %s", data.Expression, data.Severity)
+			}
+		})
+	}
+}
+
+func TestGradingRuleFromModel(t *testing.T) {
+	tests := []struct {
+		name            string
+		description     types.String
+		wantDescription *string
+	}{
+		{name: "null description is not sent", description: types.StringNull()},
+		{name: "description is sent", description: types.StringValue("RSA keys are at least 2048 bits long"), wantDescription: strPtr("RSA keys are at least 2048 bits long")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := gradingRuleFromModel(gradingRuleResourceModel{
+				Name:        types.StringValue("min-rsa-key-size"),
+				Description: tt.description,
+				Expression:  types.StringValue(`keytype in ["rsa-1024"]`),
+				Severity:    types.StringValue("critical"),
+			})
+			if rule.Name != "min-rsa-key-size" || rule.Expression != `keytype in ["rsa-1024"]` || rule.Severity != "critical" {
+				t.Errorf("unexpected rule: %+v", rule)
+			}
+			if !types.StringPointerValue(rule.Description).Equal(types.StringPointerValue(tt.wantDescription)) {
+				t.Errorf("description = %v, want %v", rule.Description, tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestValidateGradingRule(t *testing.T) {
+	base := func() gradingRuleResourceModel {
+		return gradingRuleResourceModel{
+			Name:       types.StringValue("r"),
+			Expression: types.StringValue(`signingalgorithm equals "SHA1WithRSA"`),
+			Severity:   types.StringValue("high"),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*gradingRuleResourceModel)
+		wantErr bool
+	}{
+		{name: "valid", mutate: func(d *gradingRuleResourceModel) {}},
+		{name: "unknown values", mutate: func(d *gradingRuleResourceModel) {
+			d.Expression = types.StringUnknown()
+			d.Severity = types.StringUnknown()
+		}},
+		{name: "blank expression", wantErr: true, mutate: func(d *gradingRuleResourceModel) {
+			d.Expression = types.StringValue("  ")
+		}},
+		{name: "unknown severity", wantErr: true, mutate: func(d *gradingRuleResourceModel) {
+			d.Severity = types.StringValue("warning")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base()
+			tt.mutate(&data)
+			if diags := validateGradingRule(data); diags.HasError() != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/evertrust/terraform-provider-horizon/internal/hrql"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+var _ function.Function = &HrqlFunction{}
+
+func NewHrqlFunction() function.Function {
+	return &HrqlFunction{}
+}
+
+// HrqlFunction renders an HRQL query from a structured expression.
+type HrqlFunction struct{}
+
+// hrqlComparisons are the keys of a condition, next to `field`.
+var hrqlComparisons = []string{"equals", "not_equals", "contains", "in", "before", "after"}
+
+func (f *HrqlFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "hrql"
+}
+
+func (f *HrqlFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build an HRQL query.",
+		MarkdownDescription: "Renders a Horizon query language (HRQL) query from a structured expression, quoting and escaping every value so that it cannot alter the query.\n\n" +
+			"An expression is an object that is either:\n\n" +
+			"- a condition: `field` and exactly one of `equals`, `not_equals`, `contains`, `in` (list of values), `before` or `after` (RFC 3339 timestamps);\n" +
+			"- `and` or `or`: a list of expressions;\n" +
+			"- `not`: an expression.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "expression",
+				Description: "Expression to render.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *HrqlFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expression types.Dynamic
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &expression))
+	if resp.Error != nil {
+		return
+	}
+
+	expr, err := hrqlExpression(expression, "expression")
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	query, err := hrql.Build(expr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, query))
+}
+
+// hrqlExpression converts an expression object to an hrql.Expr. at locates
+// the value in the argument, for error messages.
+func hrqlExpression(value attr.Value, at string) (hrql.Expr, error) {
+	fields, err := hrqlObject(value, at)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	if _, ok := fields["field"]; !ok {
+		if len(keys) != 1 {
+			return nil, fmt.Errorf("%s: expected a condition with `field`, or exactly one of `and`, `or`, `not`; got keys %v", at, keys)
+		}
+		key := keys[0]
+		switch key {
+		case "and", "or":
+			elements, err := hrqlList(fields[key], at+"."+key)
+			if err != nil {
+				return nil, err
+			}
+			exprs := make([]hrql.Expr, 0, len(elements))
+			for i, element := range elements {
+				e, err := hrqlExpression(element, fmt.Sprintf("%s.%s[%d]", at, key, i))
+				if err != nil {
+					return nil, err
+				}
+				exprs = append(exprs, e)
+			}
+			if key == "and" {
+				return hrql.And(exprs...), nil
+			}
+			return hrql.Or(exprs...), nil
+		case "not":
+			e, err := hrqlExpression(fields[key], at+".not")
+			if err != nil {
+				return nil, err
+			}
+			return hrql.Not(e), nil
+		}
+		return nil, fmt.Errorf("%s: unknown key %q", at, key)
+	}
+
+	field, err := hrqlScalar(fields["field"], at+".field")
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) != 2 {
+		return nil, fmt.Errorf("%s: a condition needs `field` and exactly one of %s; got keys %v", at, strings.Join(hrqlComparisons, ", "), keys)
+	}
+	operator := keys[0]
+	if operator == "field" {
+		operator = keys[1]
+	}
+	operand := fields[operator]
+	operandAt := at + "." + operator
+
+	switch operator {
+	case "in":
+		elements, err := hrqlList(operand, operandAt)
+		if err != nil {
+			return nil, err
+		}
+		values := make([]string, 0, len(elements))
+		for i, element := range elements {
+			v, err := hrqlScalar(element, fmt.Sprintf("%s[%d]", operandAt, i))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return hrql.In(field, values...), nil
+	case "before", "after":
+		v, err := hrqlScalar(operand, operandAt)
+		if err != nil {
+			return nil, err
+		}
+		date, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: expected an RFC 3339 timestamp: %w", operandAt, err)
+		}
+		if operator == "before" {
+			return hrql.Before(field, date), nil
+		}
+		return hrql.After(field, date), nil
+	}
+
+	v, err := hrqlScalar(operand, operandAt)
+	if err != nil {
+		return nil, err
+	}
+	switch operator {
+	case "equals":
+		return hrql.Equals(field, v), nil
+	case "not_equals":
+		return hrql.NotEquals(field, v), nil
+	case "contains":
+		return hrql.Contains(field, v), nil
+	}
+	return nil, fmt.Errorf("%s: unknown operator %q, expected one of %s", at, operator, strings.Join(hrqlComparisons, ", "))
+}
+
+func hrqlUnwrap(value attr.Value) attr.Value {
+	if dynamic, ok := value.(basetypes.DynamicValue); ok {
+		return dynamic.UnderlyingValue()
+	}
+	return value
+}
+
+func hrqlObject(value attr.Value, at string) (map[string]attr.Value, error) {
+	switch v := hrqlUnwrap(value).(type) {
+	case basetypes.ObjectValue:
+		return v.Attributes(), nil
+	case basetypes.MapValue:
+		return v.Elements(), nil
+	}
+	return nil, fmt.Errorf("%s: expected an object", at)
+}
+
+func hrqlList(value attr.Value, at string) ([]attr.Value, error) {
+	switch v := hrqlUnwrap(value).(type) {
+	case basetypes.TupleValue:
+		return v.Elements(), nil
+	case basetypes.ListValue:
+		return v.Elements(), nil
+	case basetypes.SetValue:
+		return v.Elements(), nil
+	}
+	return nil, fmt.Errorf("%s: expected a list", at)
+}
+
+func hrqlScalar(value attr.Value, at string) (string, error) {
+	switch v := hrqlUnwrap(value).(type) {
+	case basetypes.StringValue:
+		if !v.IsNull() {
+			return v.ValueString(), nil
+		}
+	case basetypes.NumberValue:
+		if !v.IsNull() {
+			return v.ValueBigFloat().Text('f', -1), nil
+		}
+	case basetypes.BoolValue:
+		if !v.IsNull() {
+			return fmt.Sprint(v.ValueBool()), nil
+		}
+	}
+	return "", fmt.Errorf("%s: expected a string", at)
+}
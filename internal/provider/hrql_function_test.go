@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// hclObject mimics an HCL object literal, as received by a dynamic parameter.
+func hclObject(attrs map[string]attr.Value) types.Object {
+	attrTypes := make(map[string]attr.Type, len(attrs))
+	for k, v := range attrs {
+		attrTypes[k] = v.Type(nil)
+	}
+	return types.ObjectValueMust(attrTypes, attrs)
+}
+
+// hclTuple mimics an HCL list literal, as received by a dynamic parameter.
+func hclTuple(elements ...attr.Value) types.Tuple {
+	elemTypes := make([]attr.Type, 0, len(elements))
+	for _, e := range elements {
+		elemTypes = append(elemTypes, e.Type(nil))
+	}
+	return types.TupleValueMust(elemTypes, elements)
+}
+
+func runHrql(t *testing.T, expression attr.Value) (string, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(expression)})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewHrqlFunction().Run(t.Context(), req, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func TestHrqlFunction(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression attr.Value
+		want       string
+	}{
+		{
+			name:       "single condition",
+			expression: hclObject(map[string]attr.Value{"field": types.StringValue("status"), "equals": types.StringValue("valid")}),
+			want:       `status equals "valid"`,
+		},
+		{
+			name: "and, or, not, in and dates",
+			expression: hclObject(map[string]attr.Value{"and": hclTuple(
+				hclObject(map[string]attr.Value{"field": types.StringValue("module"), "in": hclTuple(types.StringValue("webra"), types.StringValue("est"))}),
+				hclObject(map[string]attr.Value{"field": types.StringValue("notafter"), "before": types.StringValue("2030-01-01T00:00:00Z")}),
+				hclObject(map[string]attr.Value{"or": hclTuple(
+					hclObject(map[string]attr.Value{"field": types.StringValue("profile"), "equals": types.StringValue("a")}),
+					hclObject(map[string]attr.Value{"field": types.StringValue("profile"), "not_equals": types.StringValue("b")}),
+				)}),
+				hclObject(map[string]attr.Value{"not": hclObject(map[string]attr.Value{"field": types.StringValue("dn"), "contains": types.StringValue(`te"st`)})}),
+			)}),
+			want: `module in ["webra", "est"] and notafter before "2030-01-01T00:00:00Z" and (profile equals "a" or profile not equals "b") and (not dn contains "te\"st")`,
+		},
+		{
+			name:       "numbers are rendered as strings",
+			expression: hclObject(map[string]attr.Value{"field": types.StringValue("keysize"), "equals": types.NumberValue(big.NewFloat(2048))}),
+			want:       `keysize equals "2048"`,
+		},
+		{
+			name:       "after",
+			expression: hclObject(map[string]attr.Value{"field": types.StringValue("notbefore"), "after": types.StringValue("2024-06-01T02:00:00+02:00")}),
+			want:       `notbefore after "2024-06-01T00:00:00Z"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := runHrql(t, tt.expression)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("hrql() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHrqlFunction_Errors(t *testing.T) {
+	tests := map[string]attr.Value{
+		"not an object":     types.StringValue(`status equals "valid"`),
+		"no operator":       hclObject(map[string]attr.Value{"field": types.StringValue("status")}),
+		"two operators":     hclObject(map[string]attr.Value{"field": types.StringValue("status"), "equals": types.StringValue("a"), "contains": types.StringValue("b")}),
+		"unknown operator":  hclObject(map[string]attr.Value{"field": types.StringValue("status"), "matches": types.StringValue("a")}),
+		"invalid field":     hclObject(map[string]attr.Value{"field": types.StringValue("status equals"), "equals": types.StringValue("a")}),
+		"invalid date":      hclObject(map[string]attr.Value{"field": types.StringValue("notafter"), "before": types.StringValue("tomorrow")}),
+		"in without list":   hclObject(map[string]attr.Value{"field": types.StringValue("module"), "in": types.StringValue("webra")}),
+		"and without list":  hclObject(map[string]attr.Value{"and": types.StringValue("x")}),
+		"empty and":         hclObject(map[string]attr.Value{"and": hclTuple()}),
+		"and and or":        hclObject(map[string]attr.Value{"and": hclTuple(), "or": hclTuple()}),
+		"nested error":      hclObject(map[string]attr.Value{"not": hclObject(map[string]attr.Value{"field": types.StringValue("dn")})}),
+		"object as operand": hclObject(map[string]attr.Value{"field": types.StringValue("dn"), "equals": hclObject(map[string]attr.Value{})}),
+	}
+	for name, expression := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got, err := runHrql(t, expression); err == nil {
+				t.Fatalf("expected an error, got %s", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func NewLabelResource() resource.Resource {
+	return &LabelResource{}
+}
+
+// LabelResource manages the definition of a certificate label.
+type LabelResource struct {
+	client *horizon.APIClient
+}
+
+type labelResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Description   types.String `tfsdk:"description"`
+	Regex         types.String `tfsdk:"regex"`
+	AllowedValues types.Set    `tfsdk:"allowed_values"`
+	Mandatory     types.Bool   `tfsdk:"mandatory"`
+}
+
+func (r *LabelResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_label"
+}
+
+func (r *LabelResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the definition of a label, the metadata certificates carry in their `labels`. " +
+			"`horizon_certificate` resources check their labels against the definitions at plan time.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the label.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the label, as referenced by certificates. Changing it creates a new label.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the label shown in the Horizon UI.",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the label.",
+			},
+			"regex": schema.StringAttribute{
+				Optional:    true,
+				Description: "Regular expression the values of the label must match.",
+			},
+			"allowed_values": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Values the label may take. Any value is allowed when unset or empty.",
+			},
+			"mandatory": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether every certificate must carry the label. Defaults to false.",
+			},
+		},
+	}
+}
+
+func (r *LabelResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *LabelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data labelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label, diags := labelFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating label %s", label.Name))
+	created, _, err := r.client.LabelAPI.LabelCreate(ctx).Label(*label).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create label", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillLabelModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LabelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data labelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label, httpResp, err := r.client.LabelAPI.LabelGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Label %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get label", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillLabelModel(ctx, &data, label)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LabelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data labelResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	label, diags := labelFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating label %s", label.Name))
+	updated, _, err := r.client.LabelAPI.LabelUpdate(ctx).Label(*label).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update label", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillLabelModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LabelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data labelResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.LabelAPI.LabelDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete label", err.Error())
+	}
+}
+
+func (r *LabelResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *LabelResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data labelResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Regex.IsNull() || data.Regex.IsUnknown() {
+		return
+	}
+	if _, err := regexp.Compile(data.Regex.ValueString()); err != nil {
+		// Horizon evaluates the expression with Java, whose syntax is not
+		// exactly RE2's: only warn.
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("regex"),
+			"Unchecked regex",
+			fmt.Sprintf("The expression cannot be checked at plan time: %s. Labels of horizon_certificate resources will not be validated against it.", err),
+		)
+	}
+}
+
+func labelFromModel(ctx context.Context, data labelResourceModel) (*models.Label, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	label := models.NewLabelWithDefaults()
+	label.Name = data.Name.ValueString()
+	label.DisplayName = data.DisplayName.ValueStringPointer()
+	label.Description = data.Description.ValueStringPointer()
+	label.Regex = data.Regex.ValueStringPointer()
+	label.Mandatory = data.Mandatory.ValueBoolPointer()
+	if !data.AllowedValues.IsNull() {
+		diags.Append(data.AllowedValues.ElementsAs(ctx, &label.AllowedValues, false)...)
+	}
+	return label, diags
+}
+
+func fillLabelModel(ctx context.Context, data *labelResourceModel, label *models.Label) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(label.Name)
+	data.Name = types.StringValue(label.Name)
+	data.DisplayName = types.StringPointerValue(label.DisplayName)
+	data.Description = types.StringPointerValue(label.Description)
+	data.Regex = types.StringPointerValue(label.Regex)
+	data.Mandatory = types.BoolValue(label.Mandatory != nil && *label.Mandatory)
+	data.AllowedValues = emptySetValue(data.AllowedValues, types.StringType)
+	if len(label.AllowedValues) > 0 {
+		values, d := types.SetValueFrom(ctx, types.StringType, label.AllowedValues)
+		diags.Append(d...)
+		data.AllowedValues = values
+	}
+	return diags
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillLabelModel(t *testing.T) {
+	ctx := context.Background()
+	emptySet := types.SetValueMust(types.StringType, nil)
+	dev := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("dev")})
+	configured := func(allowedValues types.Set) func(t *testing.T) labelResourceModel {
+		return func(t *testing.T) labelResourceModel {
+			return labelResourceModel{Name: types.StringValue("env"), AllowedValues: allowedValues}
+		}
+	}
+	imported := func(t *testing.T) labelResourceModel {
+		var data labelResourceModel
+		if diags := importedState(t, NewLabelResource(), "env").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name              string
+		prior             func(t *testing.T) labelResourceModel
+		label             *models.Label
+		wantAllowedValues types.Set
+		wantRegex         types.String
+		wantMandatory     bool
+	}{
+		{
+			name:              "unset attributes read back as null",
+			prior:             configured(types.SetNull(types.StringType)),
+			label:             &models.Label{Name: "env"},
+			wantAllowedValues: types.SetNull(types.StringType),
+			wantRegex:         types.StringNull(),
+		},
+		{
+			name:              "empty allowed values read back as empty",
+			prior:             configured(emptySet),
+			label:             &models.Label{Name: "env", AllowedValues: []string{}},
+			wantAllowedValues: emptySet,
+			wantRegex:         types.StringNull(),
+		},
+		{
+			name:              "allowed values emptied outside of Terraform read back as null",
+			prior:             configured(dev),
+			label:             &models.Label{Name: "env"},
+			wantAllowedValues: types.SetNull(types.StringType),
+			wantRegex:         types.StringNull(),
+		},
+		{
+			name:              "imported by name",
+			prior:             imported,
+			label:             &models.Label{Name: "env", AllowedValues: []string{"dev"}, Regex: strPtr("[a-z]+"), Mandatory: boolPtr(true)},
+			wantAllowedValues: dev,
+			wantRegex:         types.StringValue("[a-z]+"),
+			wantMandatory:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.label.Name {
+				t.Fatalf("Read looks up the label %q, want %q", data.Name.ValueString(), tt.label.Name)
+			}
+			if diags := fillLabelModel(ctx, &data, tt.label); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "env" {
+				t.Errorf("id = %s, want env", data.Id)
+			}
+			if !data.AllowedValues.Equal(tt.wantAllowedValues) {
+				t.Errorf("allowed_values = %s, want %s", data.AllowedValues, tt.wantAllowedValues)
+			}
+			if !data.Regex.Equal(tt.wantRegex) {
+				t.Errorf("regex = %s, want %s", data.Regex, tt.wantRegex)
+			}
+			// Horizon leaves mandatory unset for optional labels.
+			if data.Mandatory.IsNull() || data.Mandatory.ValueBool() != tt.wantMandatory {
+				t.Errorf("mandatory = %s, want %t", data.Mandatory, tt.wantMandatory)
+			}
+		})
+	}
+}
+
+func TestLabelFromModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name              string
+		allowedValues     types.Set
+		wantAllowedValues []string
+	}{
+		{name: "null allowed values are not sent", allowedValues: types.SetNull(types.StringType)},
+		{name: "empty allowed values are not sent", allowedValues: types.SetValueMust(types.StringType, nil)},
+		{name: "allowed values are sent", allowedValues: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("dev")}), wantAllowedValues: []string{"dev"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, diags := labelFromModel(ctx, labelResourceModel{
+				Name:          types.StringValue("env"),
+				DisplayName:   types.StringValue("Environment"),
+				Regex:         types.StringNull(),
+				AllowedValues: tt.allowedValues,
+				Mandatory:     types.BoolValue(false),
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if label.Name != "env" || label.DisplayName == nil || *label.DisplayName != "Environment" || label.Regex != nil || label.Mandatory == nil || *label.Mandatory {
+				t.Errorf("unexpected label: %+v", label)
+			}
+			if !slices.Equal(label.AllowedValues, tt.wantAllowedValues) {
+				t.Errorf("allowed values = %v, want %v", label.AllowedValues, tt.wantAllowedValues)
+			}
+		})
+	}
+}
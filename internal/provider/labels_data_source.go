@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewLabelsDataSource() datasource.DataSource {
+	return &LabelsDataSource{}
+}
+
+type LabelsDataSource struct {
+	client *horizon.APIClient
+}
+
+type labelsDataSourceModel struct {
+	Labels types.List `tfsdk:"labels"`
+}
+
+var labelDefinitionObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"name":           types.StringType,
+	"display_name":   types.StringType,
+	"description":    types.StringType,
+	"regex":          types.StringType,
+	"allowed_values": types.SetType{ElemType: types.StringType},
+	"mandatory":      types.BoolType,
+}}
+
+func (d *LabelsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_labels"
+}
+
+func (d *LabelsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the label definitions of Horizon, whether managed with `horizon_label` or not.",
+		Attributes: map[string]schema.Attribute{
+			"labels": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Label definitions, sorted by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the label.",
+						},
+						"display_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the label shown in the Horizon UI.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the label.",
+						},
+						"regex": schema.StringAttribute{
+							Computed:    true,
+							Description: "Regular expression the values of the label must match.",
+						},
+						"allowed_values": schema.SetAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Values the label may take. Null when any value is allowed.",
+						},
+						"mandatory": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether every certificate must carry the label.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *LabelsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.client
+}
+
+func (d *LabelsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data labelsDataSourceModel
+
+	labels, _, err := d.client.LabelAPI.LabelGetAll(ctx).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list labels", err.Error())
+		return
+	}
+
+	list, diags := labelsList(ctx, labels)
+	resp.Diagnostics.Append(diags...)
+	data.Labels = list
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// labelsList returns the label definitions as a list value, sorted by name.
+func labelsList(ctx context.Context, labels []models.Label) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	sorted := make([]models.Label, len(labels))
+	copy(sorted, labels)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	elements := make([]attr.Value, 0, len(sorted))
+	for _, label := range sorted {
+		var data labelResourceModel
+		diags.Append(fillLabelModel(ctx, &data, &label)...)
+		elements = append(elements, types.ObjectValueMust(labelDefinitionObjectType.AttrTypes, map[string]attr.Value{
+			"name":           data.Name,
+			"display_name":   data.DisplayName,
+			"description":    data.Description,
+			"regex":          data.Regex,
+			"allowed_values": data.AllowedValues,
+			"mandatory":      data.Mandatory,
+		}))
+	}
+	return types.ListValueMust(labelDefinitionObjectType, elements), diags
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLabelsList(t *testing.T) {
+	ctx := context.Background()
+	list, diags := labelsList(ctx, []models.Label{
+		{Name: "env", AllowedValues: []string{"dev", "prod"}, Mandatory: boolPtr(true)},
+		{Name: "app", DisplayName: strPtr("Application"), Regex: strPtr("[a-z]+")},
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	type element struct {
+		Name          types.String `tfsdk:"name"`
+		DisplayName   types.String `tfsdk:"display_name"`
+		Description   types.String `tfsdk:"description"`
+		Regex         types.String `tfsdk:"regex"`
+		AllowedValues types.Set    `tfsdk:"allowed_values"`
+		Mandatory     types.Bool   `tfsdk:"mandatory"`
+	}
+	var elements []element
+	if diags := list.ElementsAs(ctx, &elements, false); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if len(elements) != 2 || elements[0].Name.ValueString() != "app" || elements[1].Name.ValueString() != "env" {
+		t.Fatalf("want app then env, got %v", elements)
+	}
+	if elements[0].DisplayName.ValueString() != "Application" || !elements[0].AllowedValues.IsNull() || elements[0].Mandatory.ValueBool() {
+		t.Fatalf("unexpected app label: %+v", elements[0])
+	}
+	if len(elements[1].AllowedValues.Elements()) != 2 || !elements[1].Mandatory.ValueBool() || !elements[1].Regex.IsNull() {
+		t.Fatalf("unexpected env label: %+v", elements[1])
+	}
+}
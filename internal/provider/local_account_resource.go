@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func NewLocalAccountResource() resource.Resource {
+	return &LocalAccountResource{}
+}
+
+// LocalAccountResource manages an account of the Horizon local identity
+// provider, such as the service accounts the provider itself logs in with.
+type LocalAccountResource struct {
+	client *horizon.APIClient
+}
+
+type localAccountResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	Identifier      types.String `tfsdk:"identifier"`
+	Name            types.String `tfsdk:"name"`
+	Email           types.String `tfsdk:"email"`
+	Password        types.String `tfsdk:"password"`
+	PasswordVersion types.Int64  `tfsdk:"password_version"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+}
+
+func (r *LocalAccountResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_local_account"
+}
+
+func (r *LocalAccountResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a local account of Horizon, such as the ones the provider logs in with through `username` and `password`. " +
+			"Grant it permissions with `horizon_role_binding`. " +
+			"The password is write-only: it is not stored in the state, so changes to it are only applied when `password_version` changes. Requires Terraform 1.11 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identifier of the account.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"identifier": schema.StringAttribute{
+				Required:    true,
+				Description: "Identifier the account logs in with. Changing it creates a new account.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Display name of the account.",
+			},
+			"email": schema.StringAttribute{
+				Optional:    true,
+				Description: "Email address of the account.",
+			},
+			"password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				WriteOnly:           true,
+				MarkdownDescription: "Password of the account. Write-only: change `password_version` to send a new one.",
+			},
+			"password_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Arbitrary number to change when the password changes. The password is only sent to Horizon on creation and when it changes.",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the account can log in. Defaults to true.",
+			},
+		},
+	}
+}
+
+func (r *LocalAccountResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *LocalAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data localAccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &data.Password)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account := localAccountFromModel(data)
+	tflog.Info(ctx, fmt.Sprintf("Creating local account %s", account.Identifier))
+	created, _, err := r.client.LocalAccountAPI.LocalAccountCreate(ctx).LocalAccount(*account).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create local account", err.Error())
+		return
+	}
+
+	fillLocalAccountModel(&data, created)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocalAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data localAccountResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account, httpResp, err := r.client.LocalAccountAPI.LocalAccountGet(ctx, data.Identifier.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Local account %s not found in horizon; removing from state", data.Identifier.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get local account", err.Error())
+		return
+	}
+
+	fillLocalAccountModel(&data, account)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocalAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data localAccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	password, diags := updatedPassword(ctx, req.Plan, req.State, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Password = password
+
+	account := localAccountFromModel(data)
+	tflog.Info(ctx, fmt.Sprintf("Updating local account %s", account.Identifier))
+	updated, _, err := r.client.LocalAccountAPI.LocalAccountUpdate(ctx).LocalAccount(*account).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update local account", err.Error())
+		return
+	}
+
+	fillLocalAccountModel(&data, updated)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *LocalAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data localAccountResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.LocalAccountAPI.LocalAccountDelete(ctx, data.Identifier.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete local account", err.Error())
+	}
+}
+
+func (r *LocalAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("identifier"), req, resp)
+}
+
+func (r *LocalAccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data localAccountResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Email.IsNull() || data.Email.IsUnknown() {
+		return
+	}
+	if _, err := mail.ParseAddress(data.Email.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("email"),
+			"Invalid email value",
+			fmt.Sprintf("%q is not an email address: %s.", data.Email.ValueString(), err),
+		)
+	}
+}
+
+// updatedPassword returns the password to send on update: the one of the
+// configuration when password_version changes, and null otherwise, in which
+// case Horizon keeps the one it has.
+func updatedPassword(ctx context.Context, plan tfsdk.Plan, state tfsdk.State, config tfsdk.Config) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var planned, prior types.Int64
+	diags.Append(plan.GetAttribute(ctx, path.Root("password_version"), &planned)...)
+	diags.Append(state.GetAttribute(ctx, path.Root("password_version"), &prior)...)
+	if planned.Equal(prior) {
+		return types.StringNull(), diags
+	}
+
+	var password types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("password"), &password)...)
+	return password, diags
+}
+
+// localAccountFromModel builds the account to send to Horizon. The password
+// is left out when null, in which case Horizon keeps the one it has.
+func localAccountFromModel(data localAccountResourceModel) *models.LocalAccount {
+	account := models.NewLocalAccountWithDefaults()
+	account.Identifier = data.Identifier.ValueString()
+	account.Name = data.Name.ValueStringPointer()
+	account.Email = data.Email.ValueStringPointer()
+	account.Password = data.Password.ValueStringPointer()
+	account.Enabled = data.Enabled.ValueBoolPointer()
+	return account
+}
+
+// fillLocalAccountModel sets the attributes Horizon returns. The password is
+// never stored, and password_version is left as planned.
+func fillLocalAccountModel(data *localAccountResourceModel, account *models.LocalAccount) {
+	data.Id = types.StringValue(account.Identifier)
+	data.Identifier = types.StringValue(account.Identifier)
+	data.Name = types.StringPointerValue(account.Name)
+	data.Email = types.StringPointerValue(account.Email)
+	data.Password = types.StringNull()
+	data.Enabled = types.BoolValue(account.Enabled == nil || *account.Enabled)
+}
@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLocalAccountPassword(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewLocalAccountResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	account := func(version types.Int64) map[string]attr.Value {
+		return map[string]attr.Value{
+			"identifier":       types.StringValue("ci-pipeline"),
+			"name":             types.StringValue("CI pipeline"),
+			"enabled":          types.BoolValue(true),
+			"password_version": version,
+		}
+	}
+	tests := []struct {
+		name         string
+		prior        types.Int64
+		planned      types.Int64
+		password     types.String
+		wantPassword types.String
+	}{
+		{name: "unchanged version", prior: types.Int64Value(1), planned: types.Int64Value(1), password: types.StringValue("s3cret"), wantPassword: types.StringNull()},
+		{name: "bumped version", prior: types.Int64Value(1), planned: types.Int64Value(2), password: types.StringValue("rotated"), wantPassword: types.StringValue("rotated")},
+		{name: "version set for the first time", prior: types.Int64Null(), planned: types.Int64Value(1), password: types.StringValue("s3cret"), wantPassword: types.StringValue("s3cret")},
+		{name: "no version", prior: types.Int64Null(), planned: types.Int64Null(), password: types.StringValue("s3cret"), wantPassword: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The write-only password is only in the configuration.
+			config := account(tt.planned)
+			config["password"] = tt.password
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, account(tt.planned))}
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, account(tt.prior))}
+
+			// As Update does.
+			var data localAccountResourceModel
+			if diags := plan.Get(ctx, &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			password, diags := updatedPassword(ctx, plan, state, tfsdk.Config{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, config)})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			data.Password = password
+
+			// Without password, Horizon keeps the one it has.
+			got := localAccountFromModel(data)
+			if !types.StringPointerValue(got.Password).Equal(tt.wantPassword) {
+				t.Errorf("password = %s, want %s", types.StringPointerValue(got.Password), tt.wantPassword)
+			}
+			if got.Identifier != "ci-pipeline" || got.Name == nil || *got.Name != "CI pipeline" || got.Email != nil {
+				t.Errorf("unexpected account: %+v", got)
+			}
+		})
+	}
+}
+
+func TestFillLocalAccountModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		prior       func(t *testing.T) localAccountResourceModel
+		account     *models.LocalAccount
+		wantVersion types.Int64
+		wantEnabled bool
+	}{
+		{
+			name: "read",
+			prior: func(t *testing.T) localAccountResourceModel {
+				return localAccountResourceModel{Identifier: types.StringValue("ci-pipeline"), PasswordVersion: types.Int64Value(2)}
+			},
+			account:     &models.LocalAccount{Identifier: "ci-pipeline", Name: strPtr("CI pipeline"), Enabled: boolPtr(false)},
+			wantVersion: types.Int64Value(2),
+			wantEnabled: false,
+		},
+		{
+			// Horizon does not know of password_version, left null until it
+			// is configured.
+			name: "imported by identifier",
+			prior: func(t *testing.T) localAccountResourceModel {
+				var data localAccountResourceModel
+				if diags := importedState(t, NewLocalAccountResource(), "ci-pipeline").Get(ctx, &data); diags.HasError() {
+					t.Fatal(diags)
+				}
+				return data
+			},
+			account:     &models.LocalAccount{Identifier: "ci-pipeline", Email: strPtr("ci@example.com")},
+			wantVersion: types.Int64Null(),
+			wantEnabled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Identifier.ValueString() != tt.account.Identifier {
+				t.Fatalf("Read looks up the account %s, want %q", data.Identifier, tt.account.Identifier)
+			}
+			fillLocalAccountModel(&data, tt.account)
+			if data.Id.ValueString() != "ci-pipeline" || !data.Name.Equal(types.StringPointerValue(tt.account.Name)) || !data.Email.Equal(types.StringPointerValue(tt.account.Email)) {
+				t.Errorf("id %s, name %s, email %s", data.Id, data.Name, data.Email)
+			}
+			// Horizon never returns the password: it must not end up in the
+			// state.
+			if !data.Password.IsNull() {
+				t.Errorf("password = %s, want null", data.Password)
+			}
+			if !data.PasswordVersion.Equal(tt.wantVersion) {
+				t.Errorf("password_version = %s, want %s", data.PasswordVersion, tt.wantVersion)
+			}
+			if data.Enabled.ValueBool() != tt.wantEnabled {
+				t.Errorf("enabled = %s, want %t", data.Enabled, tt.wantEnabled)
+			}
+		})
+	}
+}
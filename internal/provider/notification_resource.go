@@ -0,0 +1,349 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// eventExpire is the event Horizon raises ahead of the expiration of a
+// certificate. The other events are named after WebRA workflows.
+const eventExpire = "expire"
+
+// lifecycleEvents are the certificate events notifications and triggers
+// react to.
+var lifecycleEvents = []string{workflowEnroll, workflowRenew, workflowUpdate, workflowRevoke, workflowRecover, workflowMigrate, eventExpire}
+
+func NewNotificationResource() resource.Resource {
+	return &NotificationResource{}
+}
+
+// NotificationResource manages an email notification sent on certificate
+// lifecycle events.
+type NotificationResource struct {
+	client *horizon.APIClient
+}
+
+type notificationResourceModel struct {
+	Id                   types.String `tfsdk:"id"`
+	Name                 types.String `tfsdk:"name"`
+	Description          types.String `tfsdk:"description"`
+	Events               types.Set    `tfsdk:"events"`
+	Recipients           types.Set    `tfsdk:"recipients"`
+	Subject              types.String `tfsdk:"subject"`
+	Body                 types.String `tfsdk:"body"`
+	Html                 types.Bool   `tfsdk:"html"`
+	DaysBeforeExpiration types.Set    `tfsdk:"days_before_expiration"`
+	Profiles             types.Set    `tfsdk:"profiles"`
+}
+
+func (r *NotificationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification"
+}
+
+func (r *NotificationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages an email notification Horizon sends on certificate lifecycle events, such as expiry alerts, for the certificates of the attached profiles. " +
+			"See `horizon_trigger` to call a webhook instead.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the notification.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the notification. Changing it creates a new notification.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the notification.",
+			},
+			"events": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Events sending the notification. Values among `enroll`, `renew`, `update`, `revoke`, `recover`, `migrate` and `expire`.",
+			},
+			"recipients": schema.SetAttribute{
+				Required:    true,
+				ElementType: types.StringType,
+				Description: "Email addresses the notification is sent to. Horizon also accepts its dynamic recipients, such as the certificate owner or team contact.",
+			},
+			"subject": schema.StringAttribute{
+				Required:    true,
+				Description: "Template of the email subject, in the Horizon template syntax.",
+			},
+			"body": schema.StringAttribute{
+				Required:    true,
+				Description: "Template of the email body, in the Horizon template syntax.",
+			},
+			"html": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether `body` is HTML rather than plain text. Defaults to false.",
+			},
+			"days_before_expiration": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.Int64Type,
+				MarkdownDescription: "Days before the expiration of a certificate the `expire` event is raised, once for each value. Required with the `expire` event.",
+			},
+			"profiles": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Profiles whose certificates the notification applies to, such as the `name` of a `horizon_webra_profile`. The notification is attached to no profile when unset or empty.",
+			},
+		},
+	}
+}
+
+func (r *NotificationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *NotificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data notificationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notification, diags := notificationFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating notification %s", notification.Name))
+	created, _, err := r.client.NotificationAPI.NotificationCreate(ctx).Notification(*notification).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create notification", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillNotificationModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data notificationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notification, httpResp, err := r.client.NotificationAPI.NotificationGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Notification %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get notification", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillNotificationModel(ctx, &data, notification)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data notificationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notification, diags := notificationFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating notification %s", notification.Name))
+	updated, _, err := r.client.NotificationAPI.NotificationUpdate(ctx).Notification(*notification).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update notification", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillNotificationModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data notificationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.NotificationAPI.NotificationDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete notification", err.Error())
+	}
+}
+
+func (r *NotificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *NotificationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data notificationResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	events, diags := validateLifecycleEvents(ctx, data.Events)
+	resp.Diagnostics.Append(diags...)
+	if events == nil || data.DaysBeforeExpiration.IsUnknown() {
+		return
+	}
+
+	_, expire := events[eventExpire]
+	switch {
+	case expire && data.DaysBeforeExpiration.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("days_before_expiration"),
+			"Missing days_before_expiration",
+			"days_before_expiration is required with the expire event.",
+		)
+	case !expire && !data.DaysBeforeExpiration.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("days_before_expiration"),
+			"Unused days_before_expiration",
+			"days_before_expiration only applies to the expire event.",
+		)
+	}
+
+	var days []types.Int64
+	resp.Diagnostics.Append(data.DaysBeforeExpiration.ElementsAs(ctx, &days, false)...)
+	for _, day := range days {
+		if !day.IsUnknown() && day.ValueInt64() < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("days_before_expiration"),
+				"Invalid days_before_expiration value",
+				fmt.Sprintf("days_before_expiration values must be positive, got %d.", day.ValueInt64()),
+			)
+		}
+	}
+}
+
+// validateLifecycleEvents checks that events only holds lifecycle events, and
+// returns them when they are all known.
+func validateLifecycleEvents(ctx context.Context, events types.Set) (map[string]struct{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if events.IsNull() || events.IsUnknown() {
+		return nil, diags
+	}
+
+	var values []types.String
+	diags.Append(events.ElementsAs(ctx, &values, false)...)
+	known := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		if value.IsUnknown() {
+			known = nil
+			continue
+		}
+		if !containsString(lifecycleEvents, value.ValueString()) {
+			diags.AddAttributeError(
+				path.Root("events"),
+				"Invalid events value",
+				fmt.Sprintf("%q is not an event. Expected one of %s.", value.ValueString(), strings.Join(lifecycleEvents, ", ")),
+			)
+		}
+		if known != nil {
+			known[value.ValueString()] = struct{}{}
+		}
+	}
+	return known, diags
+}
+
+func notificationFromModel(ctx context.Context, data notificationResourceModel) (*models.Notification, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	notification := models.NewNotificationWithDefaults()
+	notification.Name = data.Name.ValueString()
+	notification.Description = data.Description.ValueStringPointer()
+	notification.Subject = data.Subject.ValueString()
+	notification.Body = data.Body.ValueString()
+	notification.Html = data.Html.ValueBoolPointer()
+	diags.Append(data.Events.ElementsAs(ctx, &notification.Events, false)...)
+	diags.Append(data.Recipients.ElementsAs(ctx, &notification.Recipients, false)...)
+	if !data.Profiles.IsNull() {
+		diags.Append(data.Profiles.ElementsAs(ctx, &notification.Profiles, false)...)
+	}
+	if !data.DaysBeforeExpiration.IsNull() {
+		diags.Append(data.DaysBeforeExpiration.ElementsAs(ctx, &notification.DaysBeforeExpiration, false)...)
+	}
+	return notification, diags
+}
+
+func fillNotificationModel(ctx context.Context, data *notificationResourceModel, notification *models.Notification) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	data.Id = types.StringValue(notification.Name)
+	data.Name = types.StringValue(notification.Name)
+	data.Description = types.StringPointerValue(notification.Description)
+	data.Subject = types.StringValue(notification.Subject)
+	data.Body = types.StringValue(notification.Body)
+	data.Html = types.BoolValue(notification.Html != nil && *notification.Html)
+
+	data.Events, d = types.SetValueFrom(ctx, types.StringType, notification.Events)
+	diags.Append(d...)
+	data.Recipients, d = types.SetValueFrom(ctx, types.StringType, notification.Recipients)
+	diags.Append(d...)
+
+	data.Profiles = emptySetValue(data.Profiles, types.StringType)
+	if len(notification.Profiles) > 0 {
+		data.Profiles, d = types.SetValueFrom(ctx, types.StringType, notification.Profiles)
+		diags.Append(d...)
+	}
+	data.DaysBeforeExpiration = emptySetValue(data.DaysBeforeExpiration, types.Int64Type)
+	if len(notification.DaysBeforeExpiration) > 0 {
+		data.DaysBeforeExpiration, d = types.SetValueFrom(ctx, types.Int64Type, notification.DaysBeforeExpiration)
+		diags.Append(d...)
+	}
+	return diags
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillNotificationModel(t *testing.T) {
+	ctx := context.Background()
+	emptyProfiles := types.SetValueMust(types.StringType, nil)
+	emptyDays := types.SetValueMust(types.Int64Type, nil)
+	profiles := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("WebServers")})
+	days := types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(30), types.Int64Value(7)})
+	configured := func(days, profiles types.Set) func(t *testing.T) notificationResourceModel {
+		return func(t *testing.T) notificationResourceModel {
+			return notificationResourceModel{Name: types.StringValue("expiring"), DaysBeforeExpiration: days, Profiles: profiles}
+		}
+	}
+	imported := func(t *testing.T) notificationResourceModel {
+		var data notificationResourceModel
+		if diags := importedState(t, NewNotificationResource(), "expiring").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+	bare := &models.Notification{
+		Name:       "expiring",
+		Events:     []string{"revoke"},
+		Recipients: []string{"pki@example.com"},
+		Subject:    "Certificate revoked",
+		Body:       "{{certificate.subject}} was revoked",
+	}
+
+	tests := []struct {
+		name         string
+		prior        func(t *testing.T) notificationResourceModel
+		notification *models.Notification
+		wantDays     types.Set
+		wantProfiles types.Set
+	}{
+		{
+			// Omitting days_before_expiration or profiles does not plan a
+			// change.
+			name:         "unset days and profiles read back as null",
+			prior:        configured(types.SetNull(types.Int64Type), types.SetNull(types.StringType)),
+			notification: bare,
+			wantDays:     types.SetNull(types.Int64Type),
+			wantProfiles: types.SetNull(types.StringType),
+		},
+		{
+			// Neither does configuring them as empty.
+			name:         "empty days and profiles read back as empty",
+			prior:        configured(emptyDays, emptyProfiles),
+			notification: bare,
+			wantDays:     emptyDays,
+			wantProfiles: emptyProfiles,
+		},
+		{
+			name:         "days and profiles emptied outside of Terraform read back as null",
+			prior:        configured(days, profiles),
+			notification: bare,
+			wantDays:     types.SetNull(types.Int64Type),
+			wantProfiles: types.SetNull(types.StringType),
+		},
+		{
+			name:  "imported by name",
+			prior: imported,
+			notification: &models.Notification{
+				Name:                 "expiring",
+				Events:               []string{"expire"},
+				Recipients:           []string{"pki@example.com"},
+				Subject:              "Certificate expiring",
+				Body:                 "{{certificate.subject}} expires on {{certificate.notAfter}}",
+				Html:                 boolPtr(true),
+				DaysBeforeExpiration: []int32{30, 7},
+				Profiles:             []string{"WebServers"},
+			},
+			wantDays:     days,
+			wantProfiles: profiles,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.notification.Name {
+				t.Fatalf("Read looks up the notification %q, want %q", data.Name.ValueString(), tt.notification.Name)
+			}
+			if diags := fillNotificationModel(ctx, &data, tt.notification); diags.HasError() {
+				t.Fatal(diags)
+			}
+			wantHtml := tt.notification.Html != nil && *tt.notification.Html
+			if data.Id.ValueString() != "expiring" || data.Html.ValueBool() != wantHtml {
+				t.Errorf("id %s, html %s", data.Id, data.Html)
+			}
+			if !data.DaysBeforeExpiration.Equal(tt.wantDays) {
+				t.Errorf("days_before_expiration = %s, want %s", data.DaysBeforeExpiration, tt.wantDays)
+			}
+			if !data.Profiles.Equal(tt.wantProfiles) {
+				t.Errorf("profiles = %s, want %s", data.Profiles, tt.wantProfiles)
+			}
+		})
+	}
+}
+
+func TestNotificationFromModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		days         types.Set
+		profiles     types.Set
+		wantDays     []int32
+		wantProfiles []string
+	}{
+		{
+			name:     "null days and profiles are not sent",
+			days:     types.SetNull(types.Int64Type),
+			profiles: types.SetNull(types.StringType),
+		},
+		{
+			name:     "empty days and profiles are not sent",
+			days:     types.SetValueMust(types.Int64Type, nil),
+			profiles: types.SetValueMust(types.StringType, nil),
+		},
+		{
+			name:         "days and profiles are sent",
+			days:         types.SetValueMust(types.Int64Type, []attr.Value{types.Int64Value(30)}),
+			profiles:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("WebServers")}),
+			wantDays:     []int32{30},
+			wantProfiles: []string{"WebServers"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notification, diags := notificationFromModel(ctx, notificationResourceModel{
+				Name:                 types.StringValue("expiring"),
+				Description:          types.StringNull(),
+				Events:               types.SetValueMust(types.StringType, []attr.Value{types.StringValue("expire")}),
+				Recipients:           types.SetValueMust(types.StringType, []attr.Value{types.StringValue("pki@example.com")}),
+				Subject:              types.StringValue("Certificate expiring"),
+				Body:                 types.StringValue("{{certificate.subject}} expires soon"),
+				Html:                 types.BoolValue(false),
+				DaysBeforeExpiration: tt.days,
+				Profiles:             tt.profiles,
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if notification.Name != "expiring" || notification.Description != nil || notification.Html == nil || *notification.Html {
+				t.Errorf("unexpected notification: %+v", notification)
+			}
+			if !slices.Equal(notification.DaysBeforeExpiration, tt.wantDays) {
+				t.Errorf("days_before_expiration = %v, want %v", notification.DaysBeforeExpiration, tt.wantDays)
+			}
+			if !slices.Equal(notification.Profiles, tt.wantProfiles) {
+				t.Errorf("profiles = %v, want %v", notification.Profiles, tt.wantProfiles)
+			}
+		})
+	}
+}
+
+func TestValidateLifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+
+	events, diags := validateLifecycleEvents(ctx, types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("enroll"),
+		types.StringValue("expire"),
+	}))
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if _, ok := events[eventExpire]; !ok || len(events) != 2 {
+		t.Fatalf("unexpected events %v", events)
+	}
+
+	_, diags = validateLifecycleEvents(ctx, types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("expired"),
+	}))
+	if !diags.HasError() {
+		t.Fatal("want an error for an unknown event")
+	}
+
+	// With an unknown element, the events are only known after apply.
+	events, diags = validateLifecycleEvents(ctx, types.SetValueMust(types.StringType, []attr.Value{
+		types.StringValue("renew"),
+		types.StringUnknown(),
+	}))
+	if diags.HasError() || events != nil {
+		t.Fatalf("want no events and no error, got %v %v", events, diags)
+	}
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &OrderChainFunction{}
+
+func NewOrderChainFunction() function.Function {
+	return &OrderChainFunction{}
+}
+
+// OrderChainFunction orders a certificate chain locally, the way the
+// `horizon_certificate_trust_chain` data source does through the API.
+type OrderChainFunction struct{}
+
+func (f *OrderChainFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "order_chain"
+}
+
+func (f *OrderChainFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Order a certificate chain.",
+		MarkdownDescription: "Orders PEM certificates that form a single chain, without calling Horizon. " +
+			"The leaf is the certificate that issued none of the others, and each following certificate is the issuer of the previous one. " +
+			"`order` takes the values of the `horizon_certificate_trust_chain` data source: `leaf_to_root` and `root_to_leaf` return the whole chain, " +
+			"`issuer_leaf_to_root` and `issuer_root_to_leaf` return it without the leaf. Duplicate certificates are removed.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:        "pems",
+				ElementType: types.StringType,
+				Description: "PEM-encoded certificates of the chain, in any order. An element may hold several certificates.",
+			},
+			function.StringParameter{
+				Name:                "order",
+				MarkdownDescription: "Order of the returned chain. One of `leaf_to_root`, `root_to_leaf`, `issuer_leaf_to_root`, `issuer_root_to_leaf`.",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+func (f *OrderChainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var pems []string
+	var order string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &pems, &order))
+	if resp.Error != nil {
+		return
+	}
+
+	if _, ok := orderToHorizon[order]; !ok {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("order must be one of %q, %q, %q, or %q.",
+			trustChainOrderLeafToRoot,
+			trustChainOrderRootToLeaf,
+			trustChainOrderIssuerLeafToRoot,
+			trustChainOrderIssuerRootToLeaf))
+		return
+	}
+
+	var certs []*x509.Certificate
+	for i, input := range pems {
+		decoded, err := decodePEMCertificates(input)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("element %d: %s", i, err))
+			return
+		}
+		certs = append(certs, decoded...)
+	}
+	if len(certs) == 0 {
+		resp.Error = function.NewArgumentFuncError(0, "at least one certificate is required")
+		return
+	}
+
+	chain, err := buildChain(certs)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	ordered := orderChain(chain, order)
+	elements := make([]attr.Value, 0, len(ordered))
+	for _, cert := range ordered {
+		elements = append(elements, types.StringValue(encodeCertificatePEM(cert)))
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, types.ListValueMust(types.StringType, elements)))
+}
+
+// buildChain returns certs ordered from the leaf to the root. The certificates
+// must form a single chain; duplicates are ignored.
+func buildChain(certs []*x509.Certificate) ([]*x509.Certificate, error) {
+	var unique []*x509.Certificate
+	for _, cert := range certs {
+		duplicate := false
+		for _, u := range unique {
+			if cert.Equal(u) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			unique = append(unique, cert)
+		}
+	}
+
+	issuers := make(map[*x509.Certificate]*x509.Certificate, len(unique))
+	isIssuer := make(map[*x509.Certificate]bool, len(unique))
+	for _, cert := range unique {
+		for _, candidate := range unique {
+			if candidate != cert && issuedBy(cert, candidate) {
+				issuers[cert] = candidate
+				isIssuer[candidate] = true
+				break
+			}
+		}
+	}
+
+	var leaves []*x509.Certificate
+	for _, cert := range unique {
+		if !isIssuer[cert] {
+			leaves = append(leaves, cert)
+		}
+	}
+	if len(leaves) != 1 {
+		return nil, fmt.Errorf("the certificates do not form a single chain: found %d leaves", len(leaves))
+	}
+
+	chain := []*x509.Certificate{leaves[0]}
+	for issuer := issuers[leaves[0]]; issuer != nil; issuer = issuers[issuer] {
+		if len(chain) == len(unique) {
+			return nil, fmt.Errorf("the certificates do not form a single chain: %q is part of a loop", issuer.Subject)
+		}
+		chain = append(chain, issuer)
+	}
+	if len(chain) != len(unique) {
+		return nil, fmt.Errorf("the certificates do not form a single chain: %d of them are not issuers of %q", len(unique)-len(chain), leaves[0].Subject)
+	}
+	return chain, nil
+}
+
+// issuedBy reports whether issuer signed cert. Unlike CheckSignatureFrom, the
+// issuer basic constraints are not enforced: ordering a chain is not
+// validating it.
+func issuedBy(cert, issuer *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, issuer.RawSubject) &&
+		issuer.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// orderChain reorders a leaf-to-root chain as requested by a trust chain order.
+func orderChain(chain []*x509.Certificate, order string) []*x509.Certificate {
+	if order == trustChainOrderIssuerLeafToRoot || order == trustChainOrderIssuerRootToLeaf {
+		chain = chain[1:]
+	}
+	ordered := make([]*x509.Certificate, len(chain))
+	copy(ordered, chain)
+	if order == trustChainOrderRootToLeaf || order == trustChainOrderIssuerRootToLeaf {
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	}
+	return ordered
+}
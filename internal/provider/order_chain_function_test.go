@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runOrderChain(t *testing.T, pems []string, order string) ([]string, *function.FuncError) {
+	t.Helper()
+	elements := make([]attr.Value, 0, len(pems))
+	for _, p := range pems {
+		elements = append(elements, types.StringValue(p))
+	}
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+		types.ListValueMust(types.StringType, elements), types.StringValue(order),
+	})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.ListUnknown(types.StringType))}
+	NewOrderChainFunction().Run(t.Context(), req, resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	var got []string
+	for _, v := range resp.Result.Value().(types.List).Elements() {
+		got = append(got, v.(types.String).ValueString())
+	}
+	return got, nil
+}
+
+func TestOrderChainFunction(t *testing.T) {
+	root := newTestCA(t, "Root CA", nil)
+	intermediate := newTestCA(t, "Intermediate CA", root)
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leaf.example.org"}}, intermediate)
+
+	shuffled := []string{intermediate.pem, leaf.pem + root.pem, intermediate.pem}
+	tests := map[string][]string{
+		"leaf_to_root":        {leaf.pem, intermediate.pem, root.pem},
+		"root_to_leaf":        {root.pem, intermediate.pem, leaf.pem},
+		"issuer_leaf_to_root": {intermediate.pem, root.pem},
+		"issuer_root_to_leaf": {root.pem, intermediate.pem},
+	}
+	for order, want := range tests {
+		t.Run(order, func(t *testing.T) {
+			got, err := runOrderChain(t, shuffled, order)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("got %d certificates, want %d", len(got), len(want))
+			}
+			for i := range got {
+				if got[i] != want[i] {
+					t.Errorf("certificate %d is not the expected one", i)
+				}
+			}
+		})
+	}
+
+	t.Run("chain without root", func(t *testing.T) {
+		got, err := runOrderChain(t, []string{intermediate.pem, leaf.pem}, "root_to_leaf")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 2 || got[0] != intermediate.pem {
+			t.Fatalf("got %v, want the intermediate first", got)
+		}
+	})
+
+	t.Run("single certificate", func(t *testing.T) {
+		got, err := runOrderChain(t, []string{leaf.pem}, "issuer_leaf_to_root")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("got %d certificates, want none", len(got))
+		}
+	})
+}
+
+func TestOrderChainFunction_Errors(t *testing.T) {
+	root := newTestCA(t, "Root CA", nil)
+	other := newTestCA(t, "Other Root CA", nil)
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "leaf.example.org"}}, root)
+	sibling := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "sibling.example.org"}}, root)
+
+	tests := map[string]struct {
+		pems  []string
+		order string
+	}{
+		"unknown order":     {pems: []string{leaf.pem}, order: "ltr"},
+		"no certificate":    {pems: nil, order: "leaf_to_root"},
+		"invalid pem":       {pems: []string{"not a pem"}, order: "leaf_to_root"},
+		"unrelated root":    {pems: []string{leaf.pem, root.pem, other.pem}, order: "leaf_to_root"},
+		"two leaves":        {pems: []string{leaf.pem, sibling.pem, root.pem}, order: "leaf_to_root"},
+		"two separate self": {pems: []string{root.pem, other.pem}, order: "leaf_to_root"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := runOrderChain(t, tt.pems, tt.order); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &ParseCertificateFunction{}
+
+func NewParseCertificateFunction() function.Function {
+	return &ParseCertificateFunction{}
+}
+
+// ParseCertificateFunction decodes a PEM certificate locally, without calling Horizon.
+type ParseCertificateFunction struct{}
+
+var certificateSanObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":  types.StringType,
+	"value": types.ListType{ElemType: types.StringType},
+}}
+
+var parsedCertificateAttrTypes = map[string]attr.Type{
+	"dn":                    types.StringType,
+	"issuer":                types.StringType,
+	"serial":                types.StringType,
+	"not_before":            types.Int64Type,
+	"not_after":             types.Int64Type,
+	"key_type":              types.StringType,
+	"signing_algorithm":     types.StringType,
+	"thumbprint":            types.StringType,
+	"public_key_thumbprint": types.StringType,
+	"self_signed":           types.BoolType,
+	"sans":                  types.ListType{ElemType: certificateSanObjectType},
+}
+
+func (f *ParseCertificateFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_certificate"
+}
+
+func (f *ParseCertificateFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parse a PEM certificate.",
+		MarkdownDescription: "Decodes a PEM-encoded X.509 certificate locally and returns the same computed fields as the `horizon_certificate` resource. " +
+			"Dates are in milliseconds since the epoch, `thumbprint` is the SHA-1 of the DER certificate and `public_key_thumbprint` the SHA-256 of its DER public key, both in lowercase hexadecimal, as computed by Horizon. " +
+			"`sans` has the shape of the resource `sans` attribute.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pem",
+				Description: "PEM-encoded certificate. Only the first certificate of the input is parsed.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: parsedCertificateAttrTypes,
+		},
+	}
+}
+
+func (f *ParseCertificateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var input string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &input))
+	if resp.Error != nil {
+		return
+	}
+
+	certs, err := decodePEMCertificates(input)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	value, diags := types.ObjectValue(parsedCertificateAttrTypes, parsedCertificateAttributes(certs[0]))
+	resp.Error = function.ConcatFuncErrors(resp.Error, function.FuncErrorFromDiags(ctx, diags))
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
+
+// decodePEMCertificates returns the certificates of a PEM input, in order.
+// Blocks other than certificates are skipped.
+func decodePEMCertificates(input string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	rest := []byte(input)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate #%d: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no PEM certificate found in input")
+	}
+	return certs, nil
+}
+
+func parsedCertificateAttributes(cert *x509.Certificate) map[string]attr.Value {
+	thumbprint := sha1.Sum(cert.Raw)
+	publicKeyThumbprint := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+
+	return map[string]attr.Value{
+		"dn":                    types.StringValue(cert.Subject.String()),
+		"issuer":                types.StringValue(cert.Issuer.String()),
+		"serial":                types.StringValue(fmt.Sprintf("%x", cert.SerialNumber)),
+		"not_before":            types.Int64Value(cert.NotBefore.UnixMilli()),
+		"not_after":             types.Int64Value(cert.NotAfter.UnixMilli()),
+		"key_type":              types.StringValue(certificateKeyType(cert)),
+		"signing_algorithm":     types.StringValue(signingAlgorithmName(cert.SignatureAlgorithm)),
+		"thumbprint":            types.StringValue(hex.EncodeToString(thumbprint[:])),
+		"public_key_thumbprint": types.StringValue(hex.EncodeToString(publicKeyThumbprint[:])),
+		"self_signed":           types.BoolValue(isSelfSigned(cert)),
+		"sans":                  certificateSans(cert),
+	}
+}
+
+// certificateKeyType returns the key type in Horizon notation, e.g. `rsa-2048`
+// or `ec-secp256r1`.
+func certificateKeyType(cert *x509.Certificate) string {
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("rsa-%d", key.N.BitLen())
+	case *ecdsa.PublicKey:
+		switch key.Curve.Params().Name {
+		case "P-256":
+			return "ec-secp256r1"
+		case "P-384":
+			return "ec-secp384r1"
+		case "P-521":
+			return "ec-secp521r1"
+		}
+		return "ec-" + strings.ToLower(key.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return "ed-Ed25519"
+	}
+	return strings.ToLower(cert.PublicKeyAlgorithm.String())
+}
+
+// signingAlgorithmName returns the signature algorithm in Horizon notation,
+// e.g. `SHA256WITHRSA`.
+func signingAlgorithmName(alg x509.SignatureAlgorithm) string {
+	switch alg {
+	case x509.SHA1WithRSA:
+		return "SHA1WITHRSA"
+	case x509.SHA256WithRSA:
+		return "SHA256WITHRSA"
+	case x509.SHA384WithRSA:
+		return "SHA384WITHRSA"
+	case x509.SHA512WithRSA:
+		return "SHA512WITHRSA"
+	case x509.SHA256WithRSAPSS:
+		return "SHA256WITHRSAANDMGF1"
+	case x509.SHA384WithRSAPSS:
+		return "SHA384WITHRSAANDMGF1"
+	case x509.SHA512WithRSAPSS:
+		return "SHA512WITHRSAANDMGF1"
+	case x509.ECDSAWithSHA1:
+		return "SHA1WITHECDSA"
+	case x509.ECDSAWithSHA256:
+		return "SHA256WITHECDSA"
+	case x509.ECDSAWithSHA384:
+		return "SHA384WITHECDSA"
+	case x509.ECDSAWithSHA512:
+		return "SHA512WITHECDSA"
+	case x509.PureEd25519:
+		return "ED25519"
+	}
+	return strings.ToUpper(alg.String())
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	if string(cert.RawSubject) != string(cert.RawIssuer) {
+		return false
+	}
+	// CheckSignatureFrom would reject self-signed leaves that are not CAs.
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// certificateSans groups the subject alternative names by type, using the
+// SAN types of the `horizon_certificate` resource. Types without values are
+// omitted.
+func certificateSans(cert *x509.Certificate) types.List {
+	ips := make([]string, 0, len(cert.IPAddresses))
+	for _, ip := range cert.IPAddresses {
+		ips = append(ips, ip.String())
+	}
+	uris := make([]string, 0, len(cert.URIs))
+	for _, uri := range cert.URIs {
+		uris = append(uris, uri.String())
+	}
+
+	groups := []struct {
+		sanType string
+		values  []string
+	}{
+		{sanType: "DNSNAME", values: cert.DNSNames},
+		{sanType: "RFC822NAME", values: cert.EmailAddresses},
+		{sanType: "IPADDRESS", values: ips},
+		{sanType: "URI", values: uris},
+	}
+
+	elements := make([]attr.Value, 0, len(groups))
+	for _, group := range groups {
+		if len(group.values) == 0 {
+			continue
+		}
+		values := make([]attr.Value, 0, len(group.values))
+		for _, v := range group.values {
+			values = append(values, types.StringValue(v))
+		}
+		elements = append(elements, types.ObjectValueMust(certificateSanObjectType.AttrTypes, map[string]attr.Value{
+			"type":  types.StringValue(group.sanType),
+			"value": types.ListValueMust(types.StringType, values),
+		}))
+	}
+	return types.ListValueMust(certificateSanObjectType, elements)
+}
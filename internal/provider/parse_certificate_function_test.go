@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// testCertificate is a generated certificate and its key, for tests that need
+// real X.509 material.
+type testCertificate struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+	pem  string
+}
+
+// newTestCertificate issues a certificate from template, signed by parent, or
+// self-signed when parent is nil.
+func newTestCertificate(t *testing.T, template *x509.Certificate, parent *testCertificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if template.SerialNumber == nil {
+		template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	}
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour).Truncate(time.Second)
+	}
+	if template.NotAfter.IsZero() {
+		template.NotAfter = time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	}
+
+	issuer, signer := template, crypto.Signer(key)
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificate{
+		cert: cert,
+		key:  key,
+		pem:  string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	}
+}
+
+func newTestCA(t *testing.T, cn string, parent *testCertificate) *testCertificate {
+	t.Helper()
+	return newTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: cn},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, parent)
+}
+
+func runParseCertificate(t *testing.T, input string) (map[string]attr.Value, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(input)})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(parsedCertificateAttrTypes))}
+	NewParseCertificateFunction().Run(t.Context(), req, resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return resp.Result.Value().(types.Object).Attributes(), nil
+}
+
+func TestParseCertificateFunction(t *testing.T) {
+	ca := newTestCA(t, "Test Root CA", nil)
+	leaf := newTestCertificate(t, &x509.Certificate{
+		SerialNumber:   big.NewInt(0x1f2e),
+		Subject:        pkix.Name{CommonName: "www.example.com", Organization: []string{"Example"}},
+		DNSNames:       []string{"www.example.com", "example.com"},
+		EmailAddresses: []string{"admin@example.com"},
+		IPAddresses:    []net.IP{net.ParseIP("10.0.0.1")},
+	}, ca)
+
+	got, err := runParseCertificate(t, leaf.pem+ca.pem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	thumbprint := sha1.Sum(leaf.cert.Raw)
+	want := map[string]attr.Value{
+		"dn":                types.StringValue("CN=www.example.com,O=Example"),
+		"issuer":            types.StringValue("CN=Test Root CA"),
+		"serial":            types.StringValue("1f2e"),
+		"not_before":        types.Int64Value(leaf.cert.NotBefore.UnixMilli()),
+		"not_after":         types.Int64Value(leaf.cert.NotAfter.UnixMilli()),
+		"key_type":          types.StringValue("ec-secp256r1"),
+		"signing_algorithm": types.StringValue("SHA256WITHECDSA"),
+		"thumbprint":        types.StringValue(hex.EncodeToString(thumbprint[:])),
+		"self_signed":       types.BoolValue(false),
+	}
+	for name, value := range want {
+		if !got[name].Equal(value) {
+			t.Errorf("%s = %v, want %v", name, got[name], value)
+		}
+	}
+	if len(got["public_key_thumbprint"].(types.String).ValueString()) != 64 {
+		t.Errorf("public_key_thumbprint = %v, want a SHA-256 hex digest", got["public_key_thumbprint"])
+	}
+
+	var sans []struct {
+		Type  string   `tfsdk:"type"`
+		Value []string `tfsdk:"value"`
+	}
+	if diags := got["sans"].(types.List).ElementsAs(t.Context(), &sans, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(sans) != 3 || sans[0].Type != "DNSNAME" || strings.Join(sans[0].Value, ",") != "www.example.com,example.com" ||
+		sans[1].Type != "RFC822NAME" || sans[2].Type != "IPADDRESS" || sans[2].Value[0] != "10.0.0.1" {
+		t.Errorf("sans = %+v", sans)
+	}
+
+	root, err := runParseCertificate(t, ca.pem)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !root["self_signed"].Equal(types.BoolValue(true)) {
+		t.Errorf("self_signed = %v for a root CA", root["self_signed"])
+	}
+}
+
+func TestParseCertificateFunction_SelfSignedLeaf(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "self.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ferr := runParseCertificate(t, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	if ferr != nil {
+		t.Fatalf("unexpected error: %v", ferr)
+	}
+	if !got["self_signed"].Equal(types.BoolValue(true)) {
+		t.Errorf("self_signed = %v, want true", got["self_signed"])
+	}
+	if !got["key_type"].Equal(types.StringValue("rsa-2048")) || !got["signing_algorithm"].Equal(types.StringValue("SHA256WITHRSA")) {
+		t.Errorf("key_type = %v, signing_algorithm = %v", got["key_type"], got["signing_algorithm"])
+	}
+	if sans := got["sans"].(types.List); len(sans.Elements()) != 0 {
+		t.Errorf("sans = %v, want an empty list", sans)
+	}
+}
+
+func TestParseCertificateFunction_InvalidInput(t *testing.T) {
+	for name, input := range map[string]string{
+		"empty":       "",
+		"not pem":     "hello",
+		"csr only":    "-----BEGIN CERTIFICATE REQUEST-----\nMIIB\n-----END CERTIFICATE REQUEST-----\n",
+		"garbage der": "-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := runParseCertificate(t, input); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var (
+	_ function.Function = &Pkcs12DecodeFunction{}
+	_ function.Function = &Pkcs12EncodeFunction{}
+)
+
+func NewPkcs12DecodeFunction() function.Function {
+	return &Pkcs12DecodeFunction{}
+}
+
+func NewPkcs12EncodeFunction() function.Function {
+	return &Pkcs12EncodeFunction{}
+}
+
+// Pkcs12DecodeFunction splits a base64 PKCS#12 bundle into PEM parts.
+type Pkcs12DecodeFunction struct{}
+
+// Pkcs12EncodeFunction builds a base64 PKCS#12 bundle from PEM parts.
+type Pkcs12EncodeFunction struct{}
+
+var decodedPkcs12AttrTypes = map[string]attr.Type{
+	"certificate": types.StringType,
+	"private_key": types.StringType,
+	"chain":       types.ListType{ElemType: types.StringType},
+}
+
+func (f *Pkcs12DecodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "pkcs12_decode"
+}
+
+func (f *Pkcs12DecodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Decode a PKCS#12 bundle into PEM parts.",
+		MarkdownDescription: "Decodes a base64-encoded PKCS#12 bundle, such as the `pkcs12` attribute of `horizon_certificate` or `horizon_retrieve_centralized_pkcs12`, " +
+			"and returns the `certificate` and its `private_key` (PKCS#8) in PEM format, along with the `chain` of other certificates in the bundle. " +
+			"When called with ephemeral values, the result is ephemeral as well.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "pkcs12",
+				Description: "Base64-encoded PKCS#12 bundle.",
+			},
+			function.StringParameter{
+				Name:        "password",
+				Description: "Password of the PKCS#12 bundle.",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: decodedPkcs12AttrTypes,
+		},
+	}
+}
+
+func (f *Pkcs12DecodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var bundle, password string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &bundle, &password))
+	if resp.Error != nil {
+		return
+	}
+
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(bundle), ""))
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "pkcs12 is not valid base64: "+err.Error())
+		return
+	}
+
+	key, cert, caCerts, err := pkcs12.DecodeChain(der, password)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to decode the PKCS#12 bundle: " + err.Error())
+		return
+	}
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to encode the private key as PKCS#8: " + err.Error())
+		return
+	}
+
+	chain := make([]attr.Value, 0, len(caCerts))
+	for _, caCert := range caCerts {
+		chain = append(chain, types.StringValue(encodeCertificatePEM(caCert)))
+	}
+
+	value, diags := types.ObjectValue(decodedPkcs12AttrTypes, map[string]attr.Value{
+		"certificate": types.StringValue(encodeCertificatePEM(cert)),
+		"private_key": types.StringValue(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}))),
+		"chain":       types.ListValueMust(types.StringType, chain),
+	})
+	resp.Error = function.FuncErrorFromDiags(ctx, diags)
+	if resp.Error != nil {
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, value))
+}
+
+func (f *Pkcs12EncodeFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "pkcs12_encode"
+}
+
+func (f *Pkcs12EncodeFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Encode PEM parts into a PKCS#12 bundle.",
+		MarkdownDescription: "Builds a PKCS#12 bundle from a PEM certificate, its PEM private key (PKCS#8, PKCS#1 or SEC 1) and an optional PEM chain, " +
+			"and returns it base64-encoded. The bundle is protected with AES-256 and PBKDF2, as recommended for modern consumers. " +
+			"When called with ephemeral values, the result is ephemeral as well.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "certificate",
+				Description: "PEM-encoded certificate.",
+			},
+			function.StringParameter{
+				Name:        "private_key",
+				Description: "PEM-encoded private key of the certificate.",
+			},
+			function.StringParameter{
+				Name:        "chain",
+				Description: "PEM-encoded certificates to include in the bundle alongside the certificate. May be empty.",
+			},
+			function.StringParameter{
+				Name:        "password",
+				Description: "Password protecting the bundle.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *Pkcs12EncodeFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var certPEM, keyPEM, chainPEM, password string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &certPEM, &keyPEM, &chainPEM, &password))
+	if resp.Error != nil {
+		return
+	}
+
+	certs, err := decodePEMCertificates(certPEM)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	cert := certs[0]
+
+	key, err := decodePEMPrivateKey(keyPEM)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(1, err.Error())
+		return
+	}
+	if !publicKeyMatches(key, cert) {
+		resp.Error = function.NewArgumentFuncError(1, "the private key does not match the certificate public key")
+		return
+	}
+
+	var chain []*x509.Certificate
+	if strings.TrimSpace(chainPEM) != "" {
+		chain, err = decodePEMCertificates(chainPEM)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(2, err.Error())
+			return
+		}
+	}
+
+	der, err := pkcs12.Modern2023.Encode(key, cert, chain, password)
+	if err != nil {
+		resp.Error = function.NewFuncError("Unable to encode the PKCS#12 bundle: " + err.Error())
+		return
+	}
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, base64.StdEncoding.EncodeToString(der)))
+}
+
+func encodeCertificatePEM(cert *x509.Certificate) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+// decodePEMPrivateKey parses the first private key of a PEM input, in PKCS#8,
+// PKCS#1 or SEC 1 form.
+func decodePEMPrivateKey(input string) (crypto.PrivateKey, error) {
+	rest := []byte(input)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return nil, errors.New("no PEM private key found in input")
+		}
+		switch block.Type {
+		case "PRIVATE KEY":
+			return x509.ParsePKCS8PrivateKey(block.Bytes)
+		case "RSA PRIVATE KEY":
+			return x509.ParsePKCS1PrivateKey(block.Bytes)
+		case "EC PRIVATE KEY":
+			return x509.ParseECPrivateKey(block.Bytes)
+		case "ENCRYPTED PRIVATE KEY":
+			return nil, fmt.Errorf("encrypted private keys are not supported")
+		}
+	}
+}
+
+func publicKeyMatches(key crypto.PrivateKey, cert *x509.Certificate) bool {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return false
+	}
+	public, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && public.Equal(cert.PublicKey)
+}
@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runPkcs12Encode(t *testing.T, cert, key, chain, password string) (string, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+		types.StringValue(cert), types.StringValue(key), types.StringValue(chain), types.StringValue(password),
+	})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	NewPkcs12EncodeFunction().Run(t.Context(), req, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func runPkcs12Decode(t *testing.T, bundle, password string) (map[string]attr.Value, *function.FuncError) {
+	t.Helper()
+	req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{
+		types.StringValue(bundle), types.StringValue(password),
+	})}
+	resp := &function.RunResponse{Result: function.NewResultData(types.ObjectUnknown(decodedPkcs12AttrTypes))}
+	NewPkcs12DecodeFunction().Run(t.Context(), req, resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return resp.Result.Value().(types.Object).Attributes(), nil
+}
+
+func privateKeyPEM(t *testing.T, tc *testCertificate, pkcs8 bool) string {
+	t.Helper()
+	if !pkcs8 {
+		der, err := x509.MarshalECPrivateKey(tc.key.(*ecdsa.PrivateKey))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(tc.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestPkcs12RoundTrip(t *testing.T) {
+	root := newTestCA(t, "Test Root CA", nil)
+	intermediate := newTestCA(t, "Test Intermediate CA", root)
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "www.example.com"}}, intermediate)
+
+	bundle, err := runPkcs12Encode(t, leaf.pem, privateKeyPEM(t, leaf, false), intermediate.pem+root.pem, "s3cret")
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+
+	got, err := runPkcs12Decode(t, bundle, "s3cret")
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if !got["certificate"].Equal(types.StringValue(leaf.pem)) {
+		t.Errorf("certificate = %v, want the leaf", got["certificate"])
+	}
+	if !got["private_key"].Equal(types.StringValue(privateKeyPEM(t, leaf, true))) {
+		t.Errorf("private_key must be the PKCS#8 PEM of the leaf key, got %v", got["private_key"])
+	}
+	chain := got["chain"].(types.List).Elements()
+	if len(chain) != 2 || !chain[0].Equal(types.StringValue(intermediate.pem)) || !chain[1].Equal(types.StringValue(root.pem)) {
+		t.Errorf("chain = %v, want intermediate then root", chain)
+	}
+
+	if _, err := runPkcs12Decode(t, bundle, "wrong"); err == nil {
+		t.Error("decoding with a wrong password must fail")
+	}
+}
+
+func TestPkcs12Encode_WithoutChain(t *testing.T) {
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "self.example.com"}}, nil)
+
+	bundle, err := runPkcs12Encode(t, leaf.pem, privateKeyPEM(t, leaf, true), "", "s3cret")
+	if err != nil {
+		t.Fatalf("unexpected encode error: %v", err)
+	}
+	got, err := runPkcs12Decode(t, bundle, "s3cret")
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if chain := got["chain"].(types.List); len(chain.Elements()) != 0 {
+		t.Errorf("chain = %v, want empty", chain)
+	}
+}
+
+func TestPkcs12Encode_InvalidArguments(t *testing.T) {
+	leaf := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "a.example.com"}}, nil)
+	other := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "b.example.com"}}, nil)
+
+	tests := []struct {
+		name     string
+		cert     string
+		key      string
+		chain    string
+		argument int64
+	}{
+		{name: "invalid certificate", cert: "nope", key: privateKeyPEM(t, leaf, true), argument: 0},
+		{name: "missing key", cert: leaf.pem, key: "", argument: 1},
+		{name: "mismatched key", cert: leaf.pem, key: privateKeyPEM(t, other, true), argument: 1},
+		{name: "invalid chain", cert: leaf.pem, key: privateKeyPEM(t, leaf, true), chain: "garbage", argument: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runPkcs12Encode(t, tt.cert, tt.key, tt.chain, "s3cret")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if err.FunctionArgument == nil || *err.FunctionArgument != tt.argument {
+				t.Errorf("error must point at argument %d, got %v", tt.argument, err.FunctionArgument)
+			}
+		})
+	}
+}
+
+func TestPkcs12Decode_InvalidBase64(t *testing.T) {
+	_, err := runPkcs12Decode(t, "%%%", "s3cret")
+	if err == nil || err.FunctionArgument == nil || *err.FunctionArgument != 0 {
+		t.Fatalf("expected an error on argument 0, got %v", err)
+	}
+}
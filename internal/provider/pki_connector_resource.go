@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// PKI connectors bind Horizon to the CAs issuing its certificates. Each
+// connector type is its own resource, built from a pkiConnectorKind.
+
+// pkiConnectorKind describes a Horizon PKI connector type.
+type pkiConnectorKind struct {
+	typeName    string
+	horizonType string
+	title       string
+	settings    []connectorAttribute
+	credentials []connectorAttribute
+}
+
+var (
+	ejbcaPkiConnectorKind = pkiConnectorKind{
+		typeName:    "ejbca_pki_connector",
+		horizonType: "ejbca",
+		title:       "EJBCA",
+		settings: []connectorAttribute{
+			{name: "url", key: "url", required: true, description: "URL of the EJBCA REST API, such as `https://ejbca.example.com/ejbca/ejbca-rest-api`."},
+			{name: "ca_name", key: "caName", required: true, description: "Name of the CA in EJBCA."},
+			{name: "certificate_profile", key: "certificateProfile", required: true, description: "EJBCA certificate profile of the issued certificates."},
+			{name: "end_entity_profile", key: "endEntityProfile", required: true, description: "EJBCA end entity profile of the issued certificates."},
+			{name: "client_certificate_pem", key: "clientCertificate", required: true, description: "PEM-encoded client certificate Horizon authenticates with."},
+		},
+		credentials: []connectorAttribute{
+			{name: "client_key_pem", key: "clientKey", required: true, description: "PEM-encoded private key of the client certificate."},
+		},
+	}
+	adcsPkiConnectorKind = pkiConnectorKind{
+		typeName:    "adcs_pki_connector",
+		horizonType: "adcs",
+		title:       "Microsoft AD CS",
+		settings: []connectorAttribute{
+			{name: "host", key: "host", required: true, description: "Host name of the AD CS server."},
+			{name: "ca_name", key: "caName", required: true, description: "Common name of the CA on the server."},
+			{name: "template", key: "template", required: true, description: "Certificate template of the issued certificates."},
+			{name: "username", key: "username", required: true, description: "Account Horizon authenticates as, such as `EXAMPLE\\horizon`."},
+		},
+		credentials: []connectorAttribute{
+			{name: "password", key: "password", required: true, description: "Password of the account."},
+		},
+	}
+	awsPcaPkiConnectorKind = pkiConnectorKind{
+		typeName:    "aws_pca_pki_connector",
+		horizonType: "awspca",
+		title:       "AWS Private CA",
+		settings: []connectorAttribute{
+			{name: "region", key: "region", required: true, description: "AWS region of the CA, such as `eu-west-1`."},
+			{name: "ca_arn", key: "caArn", required: true, description: "ARN of the private CA."},
+			{name: "role_arn", key: "roleArn", description: "ARN of a role to assume to issue the certificates."},
+			{name: "access_key_id", key: "accessKeyId", description: "Access key id of the IAM user Horizon authenticates as. The credentials of the Horizon host are used when unset."},
+		},
+		credentials: []connectorAttribute{
+			{name: "secret_access_key", key: "secretAccessKey", requiredWith: "access_key_id", description: "Secret access key of the IAM user. Required with `access_key_id`."},
+		},
+	}
+	streamPkiConnectorKind = pkiConnectorKind{
+		typeName:    "stream_pki_connector",
+		horizonType: "stream",
+		title:       "EverTrust Stream",
+		settings: []connectorAttribute{
+			{name: "url", key: "url", required: true, description: "URL of the Stream instance, such as `https://stream.example.com`."},
+			{name: "ca_name", key: "caName", required: true, description: "Name of the CA in Stream."},
+			{name: "template", key: "template", required: true, description: "Stream certificate template of the issued certificates."},
+			{name: "client_certificate_pem", key: "clientCertificate", required: true, description: "PEM-encoded client certificate Horizon authenticates with."},
+		},
+		credentials: []connectorAttribute{
+			{name: "client_key_pem", key: "clientKey", required: true, description: "PEM-encoded private key of the client certificate."},
+		},
+	}
+)
+
+func NewEjbcaPkiConnectorResource() resource.Resource {
+	return &PkiConnectorResource{kind: ejbcaPkiConnectorKind}
+}
+
+func NewAdcsPkiConnectorResource() resource.Resource {
+	return &PkiConnectorResource{kind: adcsPkiConnectorKind}
+}
+
+func NewAwsPcaPkiConnectorResource() resource.Resource {
+	return &PkiConnectorResource{kind: awsPcaPkiConnectorKind}
+}
+
+func NewStreamPkiConnectorResource() resource.Resource {
+	return &PkiConnectorResource{kind: streamPkiConnectorKind}
+}
+
+// PkiConnectorResource manages a PKI connector of one type.
+type PkiConnectorResource struct {
+	client *horizon.APIClient
+	kind   pkiConnectorKind
+}
+
+func (r *PkiConnectorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind.typeName
+}
+
+func (r *PkiConnectorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages a PKI connector to %s, which issues the certificates of the profiles referencing it in `pki_connector`. ", r.kind.title) +
+			"Credentials are write-only: they are not stored in the state, so changes to them are only applied when `credentials_version` changes. Requires Terraform 1.11 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the connector.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the connector. Changing it creates a new connector.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the connector.",
+			},
+			"ca": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the Horizon CA issuing the certificates, as listed by `horizon_ca_certificates`. Required to look up the CA of a profile with `horizon_ca_certificates`.",
+			},
+			"credentials_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Arbitrary number to change when the credentials change. Credentials are only sent to Horizon on creation and when it changes.",
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Health of the connection to the CA, as last checked by Horizon, such as `up` or `down`. Refreshed on every read.",
+			},
+			"status_message": schema.StringAttribute{
+				Computed:    true,
+				Description: "Details of the health check, such as the error reaching the CA.",
+			},
+		},
+	}
+
+	addConnectorAttributes(resp.Schema.Attributes, r.kind.settings, r.kind.credentials)
+}
+
+func (r *PkiConnectorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *PkiConnectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	connector, diags := r.connectorFromPlan(ctx, req.Plan, req.Config, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating %s PKI connector %s", r.kind.horizonType, connector.Name))
+	created, _, err := r.client.PkiConnectorAPI.PkiConnectorCreate(ctx).PkiConnector(*connector).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create PKI connector", err.Error())
+		return
+	}
+
+	state, diags := newConnectorState(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.fillState(ctx, &state, created)...)
+	resp.State.Raw = state.Raw
+}
+
+func (r *PkiConnectorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var name types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, httpResp, err := r.client.PkiConnectorAPI.PkiConnectorGet(ctx, name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("PKI connector %s not found in horizon; removing from state", name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get PKI connector", err.Error())
+		return
+	}
+	if connector.Type != r.kind.horizonType {
+		resp.Diagnostics.AddError(
+			"Unexpected PKI connector type",
+			fmt.Sprintf("Connector %q is of type %q, not %q.", name.ValueString(), connector.Type, r.kind.horizonType),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.fillState(ctx, &resp.State, connector)...)
+}
+
+func (r *PkiConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	withCredentials, diags := credentialsVersionChanged(ctx, req.Plan, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, diags := r.connectorFromPlan(ctx, req.Plan, req.Config, withCredentials)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating %s PKI connector %s", r.kind.horizonType, connector.Name))
+	updated, _, err := r.client.PkiConnectorAPI.PkiConnectorUpdate(ctx).PkiConnector(*connector).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update PKI connector", err.Error())
+		return
+	}
+
+	state, diags := newConnectorState(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.fillState(ctx, &state, updated)...)
+	resp.State.Raw = state.Raw
+}
+
+func (r *PkiConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var name types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.PkiConnectorAPI.PkiConnectorDelete(ctx, name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete PKI connector", err.Error())
+	}
+}
+
+func (r *PkiConnectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *PkiConnectorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConnectorCredentials(ctx, req.Config, r.kind.credentials)...)
+}
+
+// connectorFromPlan builds the connector described by the plan, with the
+// credentials of the configuration when withCredentials is set.
+func (r *PkiConnectorResource) connectorFromPlan(ctx context.Context, plan tfsdk.Plan, config tfsdk.Config, withCredentials bool) (*models.PkiConnector, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var name, description, ca types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("description"), &description)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("ca"), &ca)...)
+
+	settings, credentials, d := getConnectorAttributes(ctx, plan, config, r.kind.settings, r.kind.credentials)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if !withCredentials {
+		credentials = nil
+	}
+	connector := r.kind.connector(name.ValueString(), settings, credentials)
+	connector.Description = description.ValueStringPointer()
+	connector.Ca = ca.ValueStringPointer()
+	return connector, diags
+}
+
+// connector builds a connector of kind k from attribute values. Null values
+// are left out: Horizon keeps the credentials it has when none are sent.
+func (k pkiConnectorKind) connector(name string, settings, credentials map[string]types.String) *models.PkiConnector {
+	connector := models.NewPkiConnectorWithDefaults()
+	connector.Name = name
+	connector.Type = k.horizonType
+	connector.Settings = connectorValues(k.settings, settings)
+	connector.Credentials = connectorValues(k.credentials, credentials)
+	return connector
+}
+
+// fillState sets the attributes of state Horizon knows of, including the
+// health of the connector, to the values of connector.
+func (r *PkiConnectorResource) fillState(ctx context.Context, state *tfsdk.State, connector *models.PkiConnector) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), types.StringValue(connector.Name))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("name"), types.StringValue(connector.Name))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("description"), types.StringPointerValue(connector.Description))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("ca"), types.StringPointerValue(connector.Ca))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("status"), types.StringPointerValue(connector.Status))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("status_message"), types.StringPointerValue(connector.StatusMessage))...)
+	diags.Append(setConnectorAttributes(ctx, state, connectorAttributeValues(r.kind.settings, connector.Settings))...)
+	return diags
+}
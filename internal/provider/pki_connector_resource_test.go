@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPkiConnectorSchemas(t *testing.T) {
+	ctx := context.Background()
+	for _, newResource := range []func() resource.Resource{
+		NewEjbcaPkiConnectorResource,
+		NewAdcsPkiConnectorResource,
+		NewAwsPcaPkiConnectorResource,
+		NewStreamPkiConnectorResource,
+	} {
+		r := newResource().(*PkiConnectorResource)
+		t.Run(r.kind.typeName, func(t *testing.T) {
+			resp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, resp)
+			if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("invalid schema: %v", diags)
+			}
+
+			for _, credential := range r.kind.credentials {
+				attribute := resp.Schema.Attributes[credential.name].(schema.StringAttribute)
+				if !attribute.WriteOnly || !attribute.Sensitive {
+					t.Errorf("credential %s must be write-only and sensitive", credential.name)
+				}
+			}
+			for _, name := range []string{"status", "status_message"} {
+				if attribute := resp.Schema.Attributes[name].(schema.StringAttribute); !attribute.Computed || attribute.Optional {
+					t.Errorf("%s must be computed only", name)
+				}
+			}
+		})
+	}
+}
+
+func TestPkiConnectorCredentials(t *testing.T) {
+	ctx := context.Background()
+	r := NewAwsPcaPkiConnectorResource().(*PkiConnectorResource)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	connector := func(version types.Int64) map[string]attr.Value {
+		return map[string]attr.Value{
+			"name":                types.StringValue("pca"),
+			"ca":                  types.StringValue("IssuingCA"),
+			"region":              types.StringValue("eu-west-1"),
+			"ca_arn":              types.StringValue("arn:aws:acm-pca:eu-west-1:123456789012:certificate-authority/example"),
+			"access_key_id":       types.StringValue("AKIAEXAMPLE"),
+			"credentials_version": version,
+		}
+	}
+	tests := []struct {
+		name            string
+		prior           types.Int64
+		planned         types.Int64
+		secret          types.String
+		wantCredentials map[string]string
+	}{
+		{name: "unchanged version", prior: types.Int64Value(1), planned: types.Int64Value(1), secret: types.StringValue("secret")},
+		{name: "bumped version", prior: types.Int64Value(1), planned: types.Int64Value(2), secret: types.StringValue("rotated"), wantCredentials: map[string]string{"secretAccessKey": "rotated"}},
+		{name: "version set for the first time", prior: types.Int64Null(), planned: types.Int64Value(1), secret: types.StringValue("secret"), wantCredentials: map[string]string{"secretAccessKey": "secret"}},
+		{name: "no version", prior: types.Int64Null(), planned: types.Int64Null(), secret: types.StringValue("secret")},
+		{name: "bumped version without credentials", prior: types.Int64Value(1), planned: types.Int64Value(2), secret: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Write-only credentials are only in the configuration.
+			config := connector(tt.planned)
+			config["secret_access_key"] = tt.secret
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, connector(tt.planned))}
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, connector(tt.prior))}
+
+			// As Update does.
+			withCredentials, diags := credentialsVersionChanged(ctx, plan, state)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			got, diags := r.connectorFromPlan(ctx, plan, tfsdk.Config{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, config)}, withCredentials)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			wantSettings := map[string]string{
+				"region":      "eu-west-1",
+				"caArn":       "arn:aws:acm-pca:eu-west-1:123456789012:certificate-authority/example",
+				"accessKeyId": "AKIAEXAMPLE",
+			}
+			if got.Name != "pca" || got.Type != "awspca" || got.Ca == nil || *got.Ca != "IssuingCA" || !maps.Equal(got.Settings, wantSettings) {
+				t.Errorf("settings must always be sent, got %+v", got)
+			}
+			// Without credentials, Horizon keeps the ones it has.
+			if !maps.Equal(got.Credentials, tt.wantCredentials) || (tt.wantCredentials == nil && got.Credentials != nil) {
+				t.Errorf("credentials = %v, want %v", got.Credentials, tt.wantCredentials)
+			}
+		})
+	}
+}
+
+func TestPkiConnectorFillState(t *testing.T) {
+	ctx := context.Background()
+	r := NewEjbcaPkiConnectorResource().(*PkiConnectorResource)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	stored := &models.PkiConnector{
+		Name:          "ejbca-issuing",
+		Type:          "ejbca",
+		Status:        strPtr("down"),
+		StatusMessage: strPtr("connection refused"),
+		Settings: map[string]string{
+			"url":                "https://ejbca.example.com/ejbca/ejbca-rest-api",
+			"caName":             "IssuingCA",
+			"certificateProfile": "TLSServer",
+			"endEntityProfile":   "TLSServer",
+			"clientCertificate":  "-----BEGIN CERTIFICATE-----",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		prior       func(t *testing.T) tfsdk.State
+		wantVersion types.Int64
+	}{
+		{
+			name: "read",
+			prior: func(t *testing.T) tfsdk.State {
+				return tfsdk.State{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, map[string]attr.Value{
+					"name":                types.StringValue("ejbca-issuing"),
+					"description":         types.StringValue("Issuing CA"),
+					"ca_name":             types.StringValue("OldCA"),
+					"credentials_version": types.Int64Value(3),
+				})}
+			},
+			wantVersion: types.Int64Value(3),
+		},
+		{
+			name:        "imported by name",
+			prior:       func(t *testing.T) tfsdk.State { return importedState(t, r, "ejbca-issuing") },
+			wantVersion: types.Int64Null(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.prior(t)
+			var name types.String
+			state.GetAttribute(ctx, path.Root("name"), &name)
+			if name.ValueString() != "ejbca-issuing" {
+				t.Fatalf("Read looks up the connector %s, want ejbca-issuing", name)
+			}
+			if diags := r.fillState(ctx, &state, stored); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			want := map[string]attr.Value{
+				"id":                     types.StringValue("ejbca-issuing"),
+				"description":            types.StringNull(),
+				"ca":                     types.StringNull(),
+				"status":                 types.StringValue("down"),
+				"status_message":         types.StringValue("connection refused"),
+				"url":                    types.StringValue("https://ejbca.example.com/ejbca/ejbca-rest-api"),
+				"ca_name":                types.StringValue("IssuingCA"),
+				"certificate_profile":    types.StringValue("TLSServer"),
+				"end_entity_profile":     types.StringValue("TLSServer"),
+				"client_certificate_pem": types.StringValue("-----BEGIN CERTIFICATE-----"),
+				"client_key_pem":         types.StringNull(),
+				"credentials_version":    tt.wantVersion,
+			}
+			for attribute, wantValue := range want {
+				var got attr.Value
+				if attribute == "credentials_version" {
+					var version types.Int64
+					state.GetAttribute(ctx, path.Root(attribute), &version)
+					got = version
+				} else {
+					var value types.String
+					state.GetAttribute(ctx, path.Root(attribute), &value)
+					got = value
+				}
+				if !got.Equal(wantValue) {
+					t.Errorf("%s = %s, want %s", attribute, got, wantValue)
+				}
+			}
+		})
+	}
+}
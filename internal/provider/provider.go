@@ -4,11 +4,13 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"net/http"
 	"net/url"
 
 	horizon "github.com/evertrust/horizon-go/v2"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -19,6 +21,7 @@ import (
 // Ensure HorizonProvider satisfies various provider interfaces.
 var _ provider.Provider = &HorizonProvider{}
 var _ provider.ProviderWithEphemeralResources = &HorizonProvider{}
+var _ provider.ProviderWithFunctions = &HorizonProvider{}
 
 // HorizonProvider defines the provider implementation.
 type HorizonProvider struct {
@@ -38,6 +41,19 @@ type horizonProviderModel struct {
 	SkipTlsVerify types.Bool   `tfsdk:"skip_tls_verify"`
 	CaBundlePem   types.String `tfsdk:"ca_bundle_pem"`
 	Proxy         types.String `tfsdk:"proxy"`
+
+	DefaultMetadata *defaultMetadataModel `tfsdk:"default_metadata"`
+}
+
+// horizonProviderData is handed to resources and data sources, which need the
+// provider-level defaults or HTTP settings on top of the API client.
+type horizonProviderData struct {
+	client   *horizon.APIClient
+	defaults defaultMetadata
+	labels   *labelDefinitions
+	// httpClient reaches endpoints outside Horizon, such as OCSP responders and
+	// CRL distribution points, with the proxy and TLS settings of the provider.
+	httpClient *http.Client
 }
 
 func (p *HorizonProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -54,7 +70,7 @@ func (p *HorizonProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Required:            true,
 			},
 			"username": schema.StringAttribute{
-				MarkdownDescription: "Local account identifier. Required when password is provided.",
+				MarkdownDescription: "Local account identifier, such as the `identifier` of a `horizon_local_account`. Required when password is provided.",
 				Optional:            true,
 			},
 			"password": schema.StringAttribute{
@@ -74,7 +90,7 @@ func (p *HorizonProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 			},
 			"ca_bundle_pem": schema.StringAttribute{
-				MarkdownDescription: "PEM-encoded CA bundle to use for TLS certificate verification. Optional.",
+				MarkdownDescription: "PEM-encoded CA bundle to use for TLS certificate verification. Optional. Replaces the system roots for Horizon, and is added to them for the OCSP responders and CRL distribution points reached by `horizon_certificate_status`.",
 				Optional:            true,
 			},
 			"proxy": schema.StringAttribute{
@@ -82,6 +98,30 @@ func (p *HorizonProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true,
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"default_metadata": schema.SingleNestedBlock{
+				MarkdownDescription: "Metadata applied to every `horizon_certificate` managed by this provider. Values set on the resource take precedence over these defaults; labels are merged key by key. Values must be known when the provider is configured, and cannot reference attributes computed during apply.",
+				Attributes: map[string]schema.Attribute{
+					"labels": schema.MapAttribute{
+						MarkdownDescription: "Labels added to every certificate. A label with the same name on the resource overrides the default value.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"owner": schema.StringAttribute{
+						MarkdownDescription: "Owner used when the resource does not set `owner`.",
+						Optional:            true,
+					},
+					"team": schema.StringAttribute{
+						MarkdownDescription: "Team used when the resource does not set `team`.",
+						Optional:            true,
+					},
+					"contact_email": schema.StringAttribute{
+						MarkdownDescription: "Contact email used when the resource does not set `contact_email`.",
+						Optional:            true,
+					},
+				},
+			},
+		},
 	}
 }
 
@@ -106,8 +146,12 @@ func (p *HorizonProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 	cfg.Scheme = endpoint.Scheme
 
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{}
+
 	if data.SkipTlsVerify.ValueBool() {
 		cfg.GetTlsConfig().InsecureSkipVerify = true
+		transport.TLSClientConfig.InsecureSkipVerify = true
 	}
 
 	if !data.Proxy.IsNull() {
@@ -117,12 +161,22 @@ func (p *HorizonProvider) Configure(ctx context.Context, req provider.ConfigureR
 			return
 		}
 		cfg.SetProxyUrl(proxyUrl)
+		transport.Proxy = http.ProxyURL(proxyUrl)
 	}
 
 	if !data.CaBundlePem.IsNull() {
 		pool := x509.NewCertPool()
 		pool.AppendCertsFromPEM([]byte(data.CaBundlePem.ValueString()))
 		cfg.GetTlsConfig().RootCAs = pool
+
+		// Endpoints outside Horizon, such as public OCSP responders, are
+		// trusted with the system roots in addition to the bundle.
+		external, err := x509.SystemCertPool()
+		if err != nil {
+			external = x509.NewCertPool()
+		}
+		external.AppendCertsFromPEM([]byte(data.CaBundlePem.ValueString()))
+		transport.TLSClientConfig.RootCAs = external
 	}
 
 	if !data.Username.IsNull() {
@@ -148,22 +202,63 @@ func (p *HorizonProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	defaults, diags := data.DefaultMetadata.toDefaults(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	client := horizon.NewAPIClient(cfg)
+	providerData := &horizonProviderData{
+		client:     client,
+		defaults:   defaults,
+		labels:     newLabelDefinitions(client),
+		httpClient: &http.Client{Transport: transport, Timeout: revocationCheckTimeout},
+	}
 
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 	resp.EphemeralResourceData = client
 }
 
 func (p *HorizonProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewCertificateResource,
+		NewWebRAProfileResource,
+		NewLabelResource,
+		NewTeamResource,
+		NewAzureKeyVaultConnectorResource,
+		NewAwsAcmConnectorResource,
+		NewF5ConnectorResource,
+		NewIntuneConnectorResource,
+		NewWebhookConnectorResource,
+		NewNotificationResource,
+		NewTriggerResource,
+		NewLocalAccountResource,
+		NewRoleResource,
+		NewRoleBindingResource,
+		NewEjbcaPkiConnectorResource,
+		NewAdcsPkiConnectorResource,
+		NewAwsPcaPkiConnectorResource,
+		NewStreamPkiConnectorResource,
+		NewAcmeProfileResource,
+		NewEstProfileResource,
+		NewScepProfileResource,
+		NewDiscoveryCampaignResource,
+		NewGradingRuleResource,
+		NewGradingPolicyResource,
 	}
 }
 
 func (p *HorizonProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewCertificateTrustChainDataSource,
+		NewCaCertificatesDataSource,
+		NewCertificateStatusDataSource,
+		NewLabelsDataSource,
+		NewTeamDataSource,
+		NewThirdPartyConnectorsDataSource,
+		NewDiscoveredCertificatesDataSource,
 	}
 }
 
@@ -173,6 +268,18 @@ func (p *HorizonProvider) EphemeralResources(ctx context.Context) []func() ephem
 	}
 }
 
+func (p *HorizonProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseCertificateFunction,
+		NewPkcs12DecodeFunction,
+		NewPkcs12EncodeFunction,
+		NewHrqlFunction,
+		NewParseDnFunction,
+		NewFormatDnFunction,
+		NewOrderChainFunction,
+	}
+}
+
 func (p HorizonProvider) ValidateConfig(ctx context.Context, req provider.ValidateConfigRequest, resp *provider.ValidateConfigResponse) {
 	var data horizonProviderModel
 
@@ -8,9 +8,12 @@ import (
 	"math/big"
 	"net/http"
 	"strings"
+	"time"
 
 	horizon "github.com/evertrust/horizon-go/v2"
 	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/evertrust/terraform-provider-horizon/internal/hrql"
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/ephemeral/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
@@ -27,6 +30,8 @@ const (
 	sourceRenewalRequest         = "renew_request"
 	sourceRecoverRequest         = "recover_request"
 	sourceCreatedRecoveryRequest = "created_recovery_request"
+
+	defaultOpenTimeout = 5 * time.Minute
 )
 
 var _ ephemeral.EphemeralResource = &RetrieveCentralizedPkcs12EphemeralResource{}
@@ -50,6 +55,8 @@ type retrieveCentralizedPkcs12Model struct {
 	Source          types.String `tfsdk:"source"`
 	Pkcs12          types.String `tfsdk:"pkcs12"`
 	Password        types.String `tfsdk:"password"`
+
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
 }
 
 type pkcs12Material struct {
@@ -123,6 +130,7 @@ func (r *RetrieveCentralizedPkcs12EphemeralResource) Schema(ctx context.Context,
 				Sensitive:   true,
 				Description: "Password for decrypting `pkcs12`.",
 			},
+			"timeouts": timeouts.Attributes(ctx),
 		},
 	}
 }
@@ -169,10 +177,18 @@ func (r *RetrieveCentralizedPkcs12EphemeralResource) Open(ctx context.Context, r
 		return
 	}
 
+	openTimeout, diags := data.Timeouts.Open(ctx, defaultOpenTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, openTimeout)
+	defer cancel()
+
 	skipEscrowCheck := data.SkipEscrowCheck.ValueBool()
 
 	material, diags := resolvePkcs12(ctx, horizonRequestClient{client: r.client}, certID, skipEscrowCheck)
-	resp.Diagnostics.Append(diags...)
+	appendOperationDiagnostics(ctx, &resp.Diagnostics, "Failed to retrieve PKCS#12 material", diags, openTimeout)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -220,10 +236,18 @@ func (c horizonRequestClient) certificateHolderID(ctx context.Context, certID st
 }
 
 func (c horizonRequestClient) search(ctx context.Context, holderID, workflow string) (*models.RequestSearchResultsResponse, error) {
+	// HRQL field names are lowercase.
+	hrqlQuery, err := hrql.Build(hrql.And(
+		hrql.Equals("holderid", holderID),
+		hrql.Equals("workflow", workflow),
+	))
+	if err != nil {
+		return nil, err
+	}
+
 	query := models.NewRequestSearchQuery()
 	query.SetPageSize(100)
-	// HRQL field names are lowercase and combinators use `equals` / `and`.
-	query.SetQuery(fmt.Sprintf("holderid equals %q and workflow equals %q", holderID, workflow))
+	query.SetQuery(hrqlQuery)
 	query.SetSortedBy([]models.SortElement{*models.NewSortElement("lastModificationDate", "Desc")})
 	query.SetFields([]string{"_id", "certificateId", "workflow", "status", "holderId", "lastModificationDate", "registrationDate"})
 
@@ -380,6 +404,10 @@ func basicErrorFrom(err error) (models.BasicError, bool) {
 }
 
 func isHardFailure(err error) bool {
+	// An expired or canceled context is never a "not found" answer.
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return true
+	}
 	basicErr, ok := basicErrorFrom(err)
 	if !ok {
 		return false
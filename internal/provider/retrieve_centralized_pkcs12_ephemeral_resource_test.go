@@ -958,3 +958,31 @@ func TestGeneratePkcs12Password(t *testing.T) {
 		t.Error("expected two generated passwords to differ")
 	}
 }
+
+func TestResolvePkcs12_SkipEscrowCheck_DeadlineIsAnError(t *testing.T) {
+	// A timed-out lookup must not be mistaken for "nothing found" and turned
+	// into a silent null result.
+	deadline := fmt.Errorf("Get \"https://horizon/api\": %w", context.DeadlineExceeded)
+
+	t.Run("certificate lookup", func(t *testing.T) {
+		rc := &fakeRequestClient{holderErr: deadline}
+		material, diags := resolvePkcs12(context.Background(), rc, testCertID, true)
+		if !diags.HasError() {
+			t.Fatalf("expected an error diagnostic, got %v", diags)
+		}
+		if material != nil {
+			t.Errorf("expected nil material, got %+v", material)
+		}
+	})
+
+	t.Run("request search", func(t *testing.T) {
+		rc := &fakeRequestClient{
+			holderID:  testHolderID,
+			searchErr: map[string]error{workflowEnroll: deadline},
+		}
+		_, diags := resolvePkcs12(context.Background(), rc, testCertID, true)
+		if !diags.HasError() {
+			t.Fatalf("expected an error diagnostic, got %v", diags)
+		}
+	})
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func NewRoleBindingResource() resource.Resource {
+	return &RoleBindingResource{}
+}
+
+// RoleBindingResource manages the authorization of a principal, that is the
+// roles granted to it.
+type RoleBindingResource struct {
+	client *horizon.APIClient
+}
+
+type roleBindingResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	Principal        types.String `tfsdk:"principal"`
+	IdentityProvider types.String `tfsdk:"identity_provider"`
+	Roles            types.Set    `tfsdk:"roles"`
+}
+
+func (r *RoleBindingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_binding"
+}
+
+func (r *RoleBindingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants `horizon_role` roles to a principal, such as a `horizon_local_account` or the subject of a client certificate. " +
+			"The binding holds all the roles of the principal: roles granted outside of Terraform are removed. " +
+			"Import it with `<identity_provider>/<principal>`, or `<principal>` when it applies to all identity providers.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Identity provider and principal of the binding, as `<identity_provider>/<principal>`, or the principal alone when the binding applies to all identity providers.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"principal": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Identifier of the principal: the `identifier` of a local account, or the DN of a client certificate. Changing it creates a new binding.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"identity_provider": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the identity provider the principal authenticates with. The binding applies to all identity providers when unset. Changing it creates a new binding.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"roles": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the roles granted to the principal, such as the `name` of a `horizon_role`.",
+			},
+		},
+	}
+}
+
+func (r *RoleBindingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *RoleBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data roleBindingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authorization, diags := authorizationFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating authorization of %s", authorization.Principal))
+	created, _, err := r.client.AuthorizationAPI.AuthorizationCreate(ctx).Authorization(*authorization).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create role binding", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillRoleBindingModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data roleBindingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authorization, err := r.getAuthorization(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to get role binding", err.Error())
+		return
+	}
+	if authorization == nil {
+		tflog.Info(ctx, fmt.Sprintf("Authorization %s not found in horizon; removing from state", data.Id.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(fillRoleBindingModel(ctx, &data, authorization)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data roleBindingResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	authorization, diags := authorizationFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating authorization of %s", authorization.Principal))
+	updated, _, err := r.client.AuthorizationAPI.AuthorizationUpdate(ctx).Authorization(*authorization).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update role binding", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillRoleBindingModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data roleBindingResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Horizon keys authorizations by principal: leave alone the one of the
+	// principal with another identity provider.
+	authorization, err := r.getAuthorization(ctx, data)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to delete role binding", err.Error())
+		return
+	}
+	if authorization == nil {
+		return
+	}
+
+	httpResp, err := r.client.AuthorizationAPI.AuthorizationDelete(ctx, data.Principal.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete role binding", err.Error())
+	}
+}
+
+func (r *RoleBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	identityProvider, principal := parseRoleBindingId(req.ID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("principal"), principal)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("identity_provider"), identityProvider)...)
+}
+
+// getAuthorization returns the authorization of the principal and identity
+// provider of data, or nil when Horizon has none, or only one for another
+// identity provider.
+func (r *RoleBindingResource) getAuthorization(ctx context.Context, data roleBindingResourceModel) (*models.Authorization, error) {
+	authorization, httpResp, err := r.client.AuthorizationAPI.AuthorizationGet(ctx, data.Principal.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !types.StringPointerValue(authorization.IdentityProvider).Equal(data.IdentityProvider) {
+		return nil, nil
+	}
+	return authorization, nil
+}
+
+// roleBindingId returns the id of the binding of principal with
+// identityProvider, which is null when the binding applies to all identity
+// providers.
+func roleBindingId(identityProvider types.String, principal string) string {
+	if identityProvider.IsNull() {
+		return principal
+	}
+	return identityProvider.ValueString() + "/" + principal
+}
+
+// parseRoleBindingId returns the identity provider and principal of id. A
+// principal starting with a slash, like some DNs, has no identity provider.
+func parseRoleBindingId(id string) (types.String, string) {
+	identityProvider, principal, found := strings.Cut(id, "/")
+	if !found || identityProvider == "" {
+		return types.StringNull(), id
+	}
+	return types.StringValue(identityProvider), principal
+}
+
+func authorizationFromModel(ctx context.Context, data roleBindingResourceModel) (*models.Authorization, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	authorization := models.NewAuthorizationWithDefaults()
+	authorization.Principal = data.Principal.ValueString()
+	authorization.IdentityProvider = data.IdentityProvider.ValueStringPointer()
+	diags.Append(data.Roles.ElementsAs(ctx, &authorization.Roles, false)...)
+	return authorization, diags
+}
+
+func fillRoleBindingModel(ctx context.Context, data *roleBindingResourceModel, authorization *models.Authorization) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Principal = types.StringValue(authorization.Principal)
+	data.IdentityProvider = types.StringPointerValue(authorization.IdentityProvider)
+	data.Id = types.StringValue(roleBindingId(data.IdentityProvider, authorization.Principal))
+	data.Roles = emptySetValue(data.Roles, types.StringType)
+	if len(authorization.Roles) > 0 {
+		data.Roles, diags = types.SetValueFrom(ctx, types.StringType, authorization.Roles)
+	}
+	return diags
+}
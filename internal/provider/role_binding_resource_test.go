@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillRoleBindingModel(t *testing.T) {
+	ctx := context.Background()
+	emptyRoles := types.SetValueMust(types.StringType, nil)
+	roles := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("web-servers-pipeline")})
+	configured := func(identityProvider types.String, roles types.Set) func(t *testing.T) roleBindingResourceModel {
+		return func(t *testing.T) roleBindingResourceModel {
+			return roleBindingResourceModel{Principal: types.StringValue("ci-pipeline"), IdentityProvider: identityProvider, Roles: roles}
+		}
+	}
+	imported := func(id string) func(t *testing.T) roleBindingResourceModel {
+		return func(t *testing.T) roleBindingResourceModel {
+			var data roleBindingResourceModel
+			if diags := importedState(t, NewRoleBindingResource(), id).Get(ctx, &data); diags.HasError() {
+				t.Fatal(diags)
+			}
+			return data
+		}
+	}
+
+	tests := []struct {
+		name          string
+		prior         func(t *testing.T) roleBindingResourceModel
+		authorization *models.Authorization
+		wantId        string
+		wantRoles     types.Set
+	}{
+		{
+			// Configuring no roles does not plan a change.
+			name:          "empty roles read back as empty",
+			prior:         configured(types.StringValue("local"), emptyRoles),
+			authorization: &models.Authorization{Principal: "ci-pipeline", IdentityProvider: strPtr("local")},
+			wantId:        "local/ci-pipeline",
+			wantRoles:     emptyRoles,
+		},
+		{
+			name:          "roles revoked outside of Terraform read back as null",
+			prior:         configured(types.StringValue("local"), roles),
+			authorization: &models.Authorization{Principal: "ci-pipeline", IdentityProvider: strPtr("local")},
+			wantId:        "local/ci-pipeline",
+			wantRoles:     types.SetNull(types.StringType),
+		},
+		{
+			name:          "imported with an identity provider",
+			prior:         imported("local/ci-pipeline"),
+			authorization: &models.Authorization{Principal: "ci-pipeline", IdentityProvider: strPtr("local"), Roles: []string{"web-servers-pipeline"}},
+			wantId:        "local/ci-pipeline",
+			wantRoles:     roles,
+		},
+		{
+			name:          "imported for all identity providers",
+			prior:         imported("ci-pipeline"),
+			authorization: &models.Authorization{Principal: "ci-pipeline", Roles: []string{"web-servers-pipeline"}},
+			wantId:        "ci-pipeline",
+			wantRoles:     roles,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			// Read looks up the authorization of the principal, and checks
+			// that it is the one of the identity provider.
+			if data.Principal.ValueString() != tt.authorization.Principal || !data.IdentityProvider.Equal(types.StringPointerValue(tt.authorization.IdentityProvider)) {
+				t.Fatalf("Read looks up %s with %s, want %s", data.Principal, data.IdentityProvider, tt.wantId)
+			}
+			if diags := fillRoleBindingModel(ctx, &data, tt.authorization); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != tt.wantId {
+				t.Errorf("id = %s, want %q", data.Id, tt.wantId)
+			}
+			if !data.Roles.Equal(tt.wantRoles) {
+				t.Errorf("roles = %s, want %s", data.Roles, tt.wantRoles)
+			}
+		})
+	}
+}
+
+func TestRoleBindingId(t *testing.T) {
+	tests := []struct {
+		id               string
+		identityProvider types.String
+		principal        string
+	}{
+		{id: "local/ci-pipeline", identityProvider: types.StringValue("local"), principal: "ci-pipeline"},
+		{id: "ci-pipeline", identityProvider: types.StringNull(), principal: "ci-pipeline"},
+		{id: "x509/CN=web,O=Example", identityProvider: types.StringValue("x509"), principal: "CN=web,O=Example"},
+		{id: "/CN=web/O=Example", identityProvider: types.StringNull(), principal: "/CN=web/O=Example"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			identityProvider, principal := parseRoleBindingId(tt.id)
+			if !identityProvider.Equal(tt.identityProvider) || principal != tt.principal {
+				t.Fatalf("got %s and %q, want %s and %q", identityProvider, principal, tt.identityProvider, tt.principal)
+			}
+			if got := roleBindingId(identityProvider, principal); got != tt.id {
+				t.Fatalf("got id %q, want %q", got, tt.id)
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	permissionScopeLifecycle = "lifecycle"
+	permissionScopeRequest   = "request"
+)
+
+// permissionScopes are the parts of Horizon a permission applies to. The
+// lifecycle and request scopes are granted per profile.
+var permissionScopes = []string{permissionScopeLifecycle, permissionScopeRequest, "discovery", "configuration", "audit"}
+
+// lifecycleActions are the actions of the lifecycle scope: the workflows of
+// horizon_certificate, and searching the certificates of the profile.
+var lifecycleActions = []string{workflowEnroll, workflowRenew, workflowUpdate, workflowRevoke, workflowRecover, workflowMigrate, "search"}
+
+func NewRoleResource() resource.Resource {
+	return &RoleResource{}
+}
+
+// RoleResource manages a named set of permissions, granted to principals by
+// horizon_role_binding.
+type RoleResource struct {
+	client *horizon.APIClient
+}
+
+type roleResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Permissions types.Set    `tfsdk:"permissions"`
+}
+
+type rolePermissionModel struct {
+	Scope   types.String `tfsdk:"scope"`
+	Profile types.String `tfsdk:"profile"`
+	Actions types.Set    `tfsdk:"actions"`
+}
+
+var rolePermissionObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"scope":   types.StringType,
+	"profile": types.StringType,
+	"actions": types.SetType{ElemType: types.StringType},
+}}
+
+func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a role, a named set of permissions. Grant it to local accounts or certificate principals with `horizon_role_binding`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the role. Changing it creates a new role.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the role.",
+			},
+			"permissions": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "Permissions granted by the role.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"scope": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Part of Horizon the permission applies to, among `lifecycle`, `request`, `discovery`, `configuration` and `audit`.",
+						},
+						"profile": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Profile the permission applies to, such as the `name` of a `horizon_webra_profile`. Required by the `lifecycle` and `request` scopes, and not allowed by the others.",
+						},
+						"actions": schema.SetAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Actions allowed. For the `lifecycle` scope, among `enroll`, `renew`, `update`, `revoke`, `recover`, `migrate` and `search`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data roleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, diags := roleFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating role %s", role.Name))
+	created, _, err := r.client.RoleAPI.RoleCreate(ctx).Role(*role).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create role", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillRoleModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data roleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, httpResp, err := r.client.RoleAPI.RoleGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Role %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get role", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillRoleModel(ctx, &data, role)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data roleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, diags := roleFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating role %s", role.Name))
+	updated, _, err := r.client.RoleAPI.RoleUpdate(ctx).Role(*role).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update role", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillRoleModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data roleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.RoleAPI.RoleDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete role", err.Error())
+	}
+}
+
+func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *RoleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data roleResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() || data.Permissions.IsNull() || data.Permissions.IsUnknown() {
+		return
+	}
+
+	var permissions []rolePermissionModel
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	for _, permission := range permissions {
+		resp.Diagnostics.Append(validateRolePermission(ctx, permission)...)
+	}
+}
+
+// validateRolePermission checks the scope of a permission, that profiles are
+// set exactly on the scopes granted per profile, and the lifecycle actions.
+func validateRolePermission(ctx context.Context, permission rolePermissionModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if permission.Scope.IsUnknown() {
+		return diags
+	}
+
+	scope := permission.Scope.ValueString()
+	if !containsString(permissionScopes, scope) {
+		diags.AddAttributeError(
+			path.Root("permissions"),
+			"Invalid permission scope",
+			fmt.Sprintf("%q is not a permission scope. Expected one of %s.", scope, strings.Join(permissionScopes, ", ")),
+		)
+		return diags
+	}
+
+	perProfile := scope == permissionScopeLifecycle || scope == permissionScopeRequest
+	switch {
+	case perProfile && permission.Profile.IsNull():
+		diags.AddAttributeError(
+			path.Root("permissions"),
+			"Missing permission profile",
+			fmt.Sprintf("Permissions of the %s scope require a profile.", scope),
+		)
+	case !perProfile && !permission.Profile.IsNull():
+		diags.AddAttributeError(
+			path.Root("permissions"),
+			"Unexpected permission profile",
+			fmt.Sprintf("Permissions of the %s scope do not apply to a profile.", scope),
+		)
+	}
+
+	if scope != permissionScopeLifecycle || permission.Actions.IsUnknown() {
+		return diags
+	}
+	var actions []types.String
+	diags.Append(permission.Actions.ElementsAs(ctx, &actions, false)...)
+	for _, action := range actions {
+		if !action.IsUnknown() && !containsString(lifecycleActions, action.ValueString()) {
+			diags.AddAttributeError(
+				path.Root("permissions"),
+				"Invalid permission action",
+				fmt.Sprintf("%q is not a lifecycle action. Expected one of %s.", action.ValueString(), strings.Join(lifecycleActions, ", ")),
+			)
+		}
+	}
+	return diags
+}
+
+func roleFromModel(ctx context.Context, data roleResourceModel) (*models.Role, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	role := models.NewRoleWithDefaults()
+	role.Name = data.Name.ValueString()
+	role.Description = data.Description.ValueStringPointer()
+
+	var permissions []rolePermissionModel
+	diags.Append(data.Permissions.ElementsAs(ctx, &permissions, false)...)
+	for _, permission := range permissions {
+		p := models.Permission{
+			Scope:   permission.Scope.ValueString(),
+			Profile: permission.Profile.ValueStringPointer(),
+		}
+		diags.Append(permission.Actions.ElementsAs(ctx, &p.Actions, false)...)
+		role.Permissions = append(role.Permissions, p)
+	}
+	return role, diags
+}
+
+func fillRoleModel(ctx context.Context, data *roleResourceModel, role *models.Role) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(role.Name)
+	data.Name = types.StringValue(role.Name)
+	data.Description = types.StringPointerValue(role.Description)
+
+	permissions := make([]rolePermissionModel, 0, len(role.Permissions))
+	for _, permission := range role.Permissions {
+		actions, d := types.SetValueFrom(ctx, types.StringType, permission.Actions)
+		diags.Append(d...)
+		permissions = append(permissions, rolePermissionModel{
+			Scope:   types.StringValue(permission.Scope),
+			Profile: types.StringPointerValue(permission.Profile),
+			Actions: actions,
+		})
+	}
+	data.Permissions = emptySetValue(data.Permissions, rolePermissionObjectType)
+	if len(permissions) > 0 {
+		var d diag.Diagnostics
+		data.Permissions, d = types.SetValueFrom(ctx, rolePermissionObjectType, permissions)
+		diags.Append(d...)
+	}
+	return diags
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillRoleModel(t *testing.T) {
+	ctx := context.Background()
+	emptyPermissions := types.SetValueMust(rolePermissionObjectType, nil)
+	permissions := func(values ...rolePermissionModel) types.Set {
+		set, diags := types.SetValueFrom(ctx, rolePermissionObjectType, values)
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+		return set
+	}
+	actions := func(values ...string) types.Set {
+		set, _ := types.SetValueFrom(ctx, types.StringType, values)
+		return set
+	}
+	renew := rolePermissionModel{Scope: types.StringValue("lifecycle"), Profile: types.StringValue("WebServers"), Actions: actions("renew", "search")}
+	configured := func(permissions types.Set) func(t *testing.T) roleResourceModel {
+		return func(t *testing.T) roleResourceModel {
+			return roleResourceModel{Name: types.StringValue("web-servers-pipeline"), Permissions: permissions}
+		}
+	}
+	imported := func(t *testing.T) roleResourceModel {
+		var data roleResourceModel
+		if diags := importedState(t, NewRoleResource(), "web-servers-pipeline").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+	withoutPermissions := &models.Role{Name: "web-servers-pipeline"}
+
+	tests := []struct {
+		name            string
+		prior           func(t *testing.T) roleResourceModel
+		role            *models.Role
+		wantPermissions types.Set
+	}{
+		{
+			// Configuring no permissions does not plan a change.
+			name:            "empty permissions read back as empty",
+			prior:           configured(emptyPermissions),
+			role:            withoutPermissions,
+			wantPermissions: emptyPermissions,
+		},
+		{
+			name:            "permissions removed outside of Terraform read back as null",
+			prior:           configured(permissions(renew)),
+			role:            withoutPermissions,
+			wantPermissions: types.SetNull(rolePermissionObjectType),
+		},
+		{
+			name:  "permission without profile reads back a null profile",
+			prior: configured(permissions(renew)),
+			role: &models.Role{
+				Name:        "web-servers-pipeline",
+				Permissions: []models.Permission{{Scope: "configuration", Actions: []string{"read"}}},
+			},
+			wantPermissions: permissions(rolePermissionModel{Scope: types.StringValue("configuration"), Profile: types.StringNull(), Actions: actions("read")}),
+		},
+		{
+			name:  "imported by name",
+			prior: imported,
+			role: &models.Role{
+				Name:        "web-servers-pipeline",
+				Description: strPtr("Renews the web server certificates"),
+				Permissions: []models.Permission{{Scope: "lifecycle", Profile: strPtr("WebServers"), Actions: []string{"renew", "search"}}},
+			},
+			wantPermissions: permissions(renew),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.role.Name {
+				t.Fatalf("Read looks up the role %q, want %q", data.Name.ValueString(), tt.role.Name)
+			}
+			if diags := fillRoleModel(ctx, &data, tt.role); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "web-servers-pipeline" || !data.Description.Equal(types.StringPointerValue(tt.role.Description)) {
+				t.Errorf("id %s, description %s", data.Id, data.Description)
+			}
+			if !data.Permissions.Equal(tt.wantPermissions) {
+				t.Errorf("permissions = %s, want %s", data.Permissions, tt.wantPermissions)
+			}
+		})
+	}
+}
+
+func TestRoleFromModel(t *testing.T) {
+	ctx := context.Background()
+	permissions, diags := types.SetValueFrom(ctx, rolePermissionObjectType, []rolePermissionModel{{
+		Scope:   types.StringValue("audit"),
+		Profile: types.StringNull(),
+		Actions: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("read")}),
+	}})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	tests := []struct {
+		name            string
+		permissions     types.Set
+		wantPermissions []models.Permission
+	}{
+		{name: "empty permissions are not sent", permissions: types.SetValueMust(rolePermissionObjectType, nil)},
+		{
+			name:            "permission without profile is sent without profile",
+			permissions:     permissions,
+			wantPermissions: []models.Permission{{Scope: "audit", Actions: []string{"read"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, diags := roleFromModel(ctx, roleResourceModel{
+				Name:        types.StringValue("auditors"),
+				Description: types.StringNull(),
+				Permissions: tt.permissions,
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if role.Name != "auditors" || role.Description != nil {
+				t.Errorf("unexpected role: %+v", role)
+			}
+			equal := slices.EqualFunc(role.Permissions, tt.wantPermissions, func(got, want models.Permission) bool {
+				return got.Scope == want.Scope && types.StringPointerValue(got.Profile).Equal(types.StringPointerValue(want.Profile)) && slices.Equal(got.Actions, want.Actions)
+			})
+			if !equal {
+				t.Errorf("permissions = %+v, want %+v", role.Permissions, tt.wantPermissions)
+			}
+		})
+	}
+}
+
+func TestValidateRolePermission(t *testing.T) {
+	actions := func(values ...string) types.Set {
+		elements := make([]attr.Value, 0, len(values))
+		for _, value := range values {
+			elements = append(elements, types.StringValue(value))
+		}
+		return types.SetValueMust(types.StringType, elements)
+	}
+
+	tests := []struct {
+		name       string
+		permission rolePermissionModel
+		wantError  bool
+	}{
+		{
+			name:       "lifecycle",
+			permission: rolePermissionModel{Scope: types.StringValue("lifecycle"), Profile: types.StringValue("WebServers"), Actions: actions("enroll", "search")},
+		},
+		{
+			name:       "configuration",
+			permission: rolePermissionModel{Scope: types.StringValue("configuration"), Profile: types.StringNull(), Actions: actions("read")},
+		},
+		{
+			name:       "unknown scope",
+			permission: rolePermissionModel{Scope: types.StringValue("everything"), Profile: types.StringNull(), Actions: actions("read")},
+			wantError:  true,
+		},
+		{
+			name:       "lifecycle without profile",
+			permission: rolePermissionModel{Scope: types.StringValue("lifecycle"), Profile: types.StringNull(), Actions: actions("enroll")},
+			wantError:  true,
+		},
+		{
+			name:       "audit with profile",
+			permission: rolePermissionModel{Scope: types.StringValue("audit"), Profile: types.StringValue("WebServers"), Actions: actions("read")},
+			wantError:  true,
+		},
+		{
+			name:       "unknown lifecycle action",
+			permission: rolePermissionModel{Scope: types.StringValue("lifecycle"), Profile: types.StringValue("WebServers"), Actions: actions("delete")},
+			wantError:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := validateRolePermission(context.Background(), tt.permission)
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("want error %v, got %v", tt.wantError, diags)
+			}
+		})
+	}
+}
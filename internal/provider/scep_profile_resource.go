@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	scepAuthorizationChallenge       = "challenge"
+	scepAuthorizationStaticChallenge = "static_challenge"
+)
+
+// scepAuthorizationModes are the ways SCEP clients may authenticate: with a
+// challenge password Horizon generates for each request, or with a challenge
+// password shared by all the clients of the profile.
+var scepAuthorizationModes = []string{scepAuthorizationChallenge, scepAuthorizationStaticChallenge}
+
+func NewScepProfileResource() resource.Resource {
+	return &ScepProfileResource{}
+}
+
+// ScepProfileResource manages a SCEP enrollment profile.
+type ScepProfileResource struct {
+	client *horizon.APIClient
+}
+
+type scepProfileResourceModel struct {
+	Id                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	Enabled                types.Bool   `tfsdk:"enabled"`
+	PkiConnector           types.String `tfsdk:"pki_connector"`
+	KeyTypes               types.Set    `tfsdk:"key_types"`
+	MaxLifetime            types.String `tfsdk:"max_lifetime"`
+	AuthorizationMode      types.String `tfsdk:"authorization_mode"`
+	ChallengeLifetime      types.String `tfsdk:"challenge_lifetime"`
+	StaticChallenge        types.String `tfsdk:"static_challenge"`
+	StaticChallengeVersion types.Int64  `tfsdk:"static_challenge_version"`
+	Renewal                types.Bool   `tfsdk:"renewal"`
+}
+
+func (r *ScepProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scep_profile"
+}
+
+func (r *ScepProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a SCEP profile, which network devices and MDM-managed endpoints enroll on, at `/scep/<name>/pkiclient.exe`. " +
+			"The static challenge is write-only: it is not stored in the state, so changes to it are only applied when `static_challenge_version` changes. Requires Terraform 1.11 or later.",
+		Attributes: enrollmentProfileAttributes("SCEP"),
+	}
+
+	resp.Schema.Attributes["authorization_mode"] = schema.StringAttribute{
+		Required: true,
+		MarkdownDescription: "How clients authenticate their enrollment requests: `challenge` with a one-time password generated in Horizon for each request, " +
+			"or `static_challenge` with the `static_challenge` password shared by all the clients of the profile.",
+	}
+	resp.Schema.Attributes["challenge_lifetime"] = schema.StringAttribute{
+		Optional:            true,
+		MarkdownDescription: "How long a generated challenge password can be used, as a duration such as `1 hour` or `PT1H`. Only applies to the `challenge` authorization mode.",
+	}
+	resp.Schema.Attributes["static_challenge"] = schema.StringAttribute{
+		Optional:            true,
+		Sensitive:           true,
+		WriteOnly:           true,
+		MarkdownDescription: "Challenge password shared by the clients. Required by the `static_challenge` authorization mode, and not allowed by the other. Write-only: change `static_challenge_version` to send a new one.",
+	}
+	resp.Schema.Attributes["static_challenge_version"] = schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Arbitrary number to change when the static challenge changes. The static challenge is only sent to Horizon on creation, when it changes and when the authorization mode changes.",
+	}
+	resp.Schema.Attributes["renewal"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+		MarkdownDescription: "Whether clients may renew their certificate with a request signed by the certificate itself, without a challenge. Defaults to true.",
+	}
+}
+
+func (r *ScepProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *ScepProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data scepProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("static_challenge"), &data.StaticChallenge)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, diags := scepProfileFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating SCEP profile %s", profile.Name))
+	created, _, err := r.client.ScepProfileAPI.ScepProfileCreate(ctx).ScepProfile(*profile).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create SCEP profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillScepProfileModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScepProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data scepProfileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, httpResp, err := r.client.ScepProfileAPI.ScepProfileGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("SCEP profile %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get SCEP profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillScepProfileModel(ctx, &data, profile)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScepProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, prior scepProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	staticChallenge, diags := updatedStaticChallenge(ctx, data, prior, req.Config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.StaticChallenge = staticChallenge
+
+	profile, diags := scepProfileFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating SCEP profile %s", profile.Name))
+	updated, _, err := r.client.ScepProfileAPI.ScepProfileUpdate(ctx).ScepProfile(*profile).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update SCEP profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillScepProfileModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScepProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data scepProfileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.ScepProfileAPI.ScepProfileDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete SCEP profile", err.Error())
+	}
+}
+
+func (r *ScepProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *ScepProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data scepProfileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateScepProfile(ctx, data)...)
+}
+
+// validateScepProfile checks the known values of a SCEP profile configuration.
+func validateScepProfile(ctx context.Context, data scepProfileResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(validateDuration("max_lifetime", data.MaxLifetime)...)
+	diags.Append(validateDuration("challenge_lifetime", data.ChallengeLifetime)...)
+
+	if data.AuthorizationMode.IsUnknown() {
+		return diags
+	}
+	mode := data.AuthorizationMode.ValueString()
+	if !containsString(scepAuthorizationModes, mode) {
+		diags.AddAttributeError(
+			path.Root("authorization_mode"),
+			"Invalid authorization_mode value",
+			fmt.Sprintf("%q is not a SCEP authorization mode. Expected one of %s.", mode, strings.Join(scepAuthorizationModes, ", ")),
+		)
+		return diags
+	}
+
+	static := mode == scepAuthorizationStaticChallenge
+	switch {
+	case static && data.StaticChallenge.IsNull():
+		diags.AddAttributeError(
+			path.Root("static_challenge"),
+			"Missing static_challenge",
+			"static_challenge is required with the static_challenge authorization mode.",
+		)
+	case !static && !data.StaticChallenge.IsNull():
+		diags.AddAttributeError(
+			path.Root("static_challenge"),
+			"Unused static_challenge",
+			"static_challenge only applies to the static_challenge authorization mode.",
+		)
+	}
+	if static && !data.ChallengeLifetime.IsNull() {
+		diags.AddAttributeError(
+			path.Root("challenge_lifetime"),
+			"Unused challenge_lifetime",
+			"challenge_lifetime only applies to the challenge authorization mode.",
+		)
+	}
+	return diags
+}
+
+// scepProfileFromModel builds the profile to send to Horizon. The static
+// challenge is left out when null, in which case Horizon keeps the one it has.
+// updatedStaticChallenge returns the static challenge to send on update: the
+// one of the configuration when static_challenge_version changes, or when the
+// profile switches to it, and null otherwise, in which case Horizon keeps the
+// one it has.
+func updatedStaticChallenge(ctx context.Context, data, prior scepProfileResourceModel, config tfsdk.Config) (types.String, diag.Diagnostics) {
+	if data.StaticChallengeVersion.Equal(prior.StaticChallengeVersion) && data.AuthorizationMode.Equal(prior.AuthorizationMode) {
+		return types.StringNull(), nil
+	}
+
+	var staticChallenge types.String
+	diags := config.GetAttribute(ctx, path.Root("static_challenge"), &staticChallenge)
+	return staticChallenge, diags
+}
+
+func scepProfileFromModel(ctx context.Context, data scepProfileResourceModel) (*models.ScepProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	profile := models.NewScepProfileWithDefaults()
+	profile.Name = data.Name.ValueString()
+	profile.Enabled = data.Enabled.ValueBoolPointer()
+	profile.PkiConnector = data.PkiConnector.ValueStringPointer()
+	profile.MaxCertificateLifetime = horizonDuration(data.MaxLifetime)
+	profile.AuthorizationMode = data.AuthorizationMode.ValueString()
+	profile.ChallengeLifetime = horizonDuration(data.ChallengeLifetime)
+	profile.StaticChallenge = data.StaticChallenge.ValueStringPointer()
+	profile.Renewal = data.Renewal.ValueBoolPointer()
+	keyTypes, d := authorizedKeyTypes(ctx, data.KeyTypes)
+	diags.Append(d...)
+	if keyTypes != nil {
+		profile.CryptoPolicy = &models.ProfileCryptoPolicy{AuthorizedKeyTypes: keyTypes}
+	}
+	return profile, diags
+}
+
+// fillScepProfileModel sets data to the profile stored in Horizon. The static
+// challenge is never stored, and static_challenge_version is left as planned.
+func fillScepProfileModel(ctx context.Context, data *scepProfileResourceModel, profile *models.ScepProfile) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(profile.Name)
+	data.Name = types.StringValue(profile.Name)
+	data.Enabled = types.BoolValue(profile.Enabled == nil || *profile.Enabled)
+	data.PkiConnector = types.StringPointerValue(profile.PkiConnector)
+	data.MaxLifetime = durationValue(data.MaxLifetime, profile.MaxCertificateLifetime)
+	data.AuthorizationMode = types.StringValue(profile.AuthorizationMode)
+	data.ChallengeLifetime = durationValue(data.ChallengeLifetime, profile.ChallengeLifetime)
+	data.StaticChallenge = types.StringNull()
+	data.Renewal = types.BoolValue(profile.Renewal == nil || *profile.Renewal)
+
+	keyTypes, d := keyTypesValue(ctx, profile.CryptoPolicy, data.KeyTypes)
+	diags.Append(d...)
+	data.KeyTypes = keyTypes
+	return diags
+}
@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestScepProfileStaticChallenge(t *testing.T) {
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	NewScepProfileResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	model := func(mode string, version types.Int64) scepProfileResourceModel {
+		return scepProfileResourceModel{
+			Name:                   types.StringValue("intune"),
+			Enabled:                types.BoolValue(true),
+			PkiConnector:           types.StringValue("adcs-corp"),
+			KeyTypes:               types.SetNull(types.StringType),
+			MaxLifetime:            types.StringValue("P1Y"),
+			AuthorizationMode:      types.StringValue(mode),
+			ChallengeLifetime:      types.StringNull(),
+			StaticChallenge:        types.StringNull(),
+			StaticChallengeVersion: version,
+			Renewal:                types.BoolValue(false),
+		}
+	}
+	tests := []struct {
+		name          string
+		prior         scepProfileResourceModel
+		planned       scepProfileResourceModel
+		wantChallenge types.String
+	}{
+		{
+			name:          "unchanged version",
+			prior:         model("static_challenge", types.Int64Value(1)),
+			planned:       model("static_challenge", types.Int64Value(1)),
+			wantChallenge: types.StringNull(),
+		},
+		{
+			name:          "bumped version",
+			prior:         model("static_challenge", types.Int64Value(1)),
+			planned:       model("static_challenge", types.Int64Value(2)),
+			wantChallenge: types.StringValue("s3cret"),
+		},
+		{
+			name:          "switched to the static challenge",
+			prior:         model("challenge", types.Int64Null()),
+			planned:       model("static_challenge", types.Int64Null()),
+			wantChallenge: types.StringValue("s3cret"),
+		},
+		{
+			name:          "no version",
+			prior:         model("static_challenge", types.Int64Null()),
+			planned:       model("static_challenge", types.Int64Null()),
+			wantChallenge: types.StringNull(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The write-only static challenge is only in the configuration.
+			config := tfsdk.Config{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, map[string]attr.Value{
+				"name":               types.StringValue("intune"),
+				"authorization_mode": tt.planned.AuthorizationMode,
+				"static_challenge":   types.StringValue("s3cret"),
+			})}
+
+			// As Update does.
+			data := tt.planned
+			staticChallenge, diags := updatedStaticChallenge(ctx, data, tt.prior, config)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			data.StaticChallenge = staticChallenge
+			profile, diags := scepProfileFromModel(ctx, data)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			// Without static challenge, Horizon keeps the one it has.
+			if !types.StringPointerValue(profile.StaticChallenge).Equal(tt.wantChallenge) {
+				t.Errorf("static challenge = %v, want %s", profile.StaticChallenge, tt.wantChallenge)
+			}
+			if profile.Name != "intune" || profile.MaxCertificateLifetime == nil || *profile.MaxCertificateLifetime != "365 days" {
+				t.Errorf("unexpected profile: %+v", profile)
+			}
+		})
+	}
+}
+
+func TestFillScepProfileModel(t *testing.T) {
+	ctx := context.Background()
+	stored := &models.ScepProfile{
+		Name:                   "intune",
+		PkiConnector:           strPtr("adcs-corp"),
+		MaxCertificateLifetime: strPtr("365 days"),
+		AuthorizationMode:      "static_challenge",
+		StaticChallenge:        strPtr("s3cret"),
+		Renewal:                boolPtr(false),
+	}
+
+	tests := []struct {
+		name            string
+		prior           func(t *testing.T) scepProfileResourceModel
+		wantMaxLifetime types.String
+		wantVersion     types.Int64
+	}{
+		{
+			// Horizon stores durations in its own notation, and does not
+			// know of static_challenge_version.
+			name: "read",
+			prior: func(t *testing.T) scepProfileResourceModel {
+				return scepProfileResourceModel{
+					Name:                   types.StringValue("intune"),
+					KeyTypes:               types.SetNull(types.StringType),
+					MaxLifetime:            types.StringValue("P1Y"),
+					StaticChallengeVersion: types.Int64Value(2),
+				}
+			},
+			wantMaxLifetime: types.StringValue("P1Y"),
+			wantVersion:     types.Int64Value(2),
+		},
+		{
+			name: "imported by name",
+			prior: func(t *testing.T) scepProfileResourceModel {
+				var data scepProfileResourceModel
+				if diags := importedState(t, NewScepProfileResource(), "intune").Get(ctx, &data); diags.HasError() {
+					t.Fatal(diags)
+				}
+				return data
+			},
+			wantMaxLifetime: types.StringValue("365 days"),
+			wantVersion:     types.Int64Null(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != stored.Name {
+				t.Fatalf("Read looks up the profile %q, want %q", data.Name.ValueString(), stored.Name)
+			}
+			if diags := fillScepProfileModel(ctx, &data, stored); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "intune" || data.AuthorizationMode.ValueString() != "static_challenge" || data.Renewal.ValueBool() || !data.Enabled.ValueBool() {
+				t.Errorf("id %s, authorization_mode %s, renewal %s, enabled %s", data.Id, data.AuthorizationMode, data.Renewal, data.Enabled)
+			}
+			// The static challenge must not end up in the state.
+			if !data.StaticChallenge.IsNull() {
+				t.Errorf("static_challenge = %s, want null", data.StaticChallenge)
+			}
+			if !data.StaticChallengeVersion.Equal(tt.wantVersion) {
+				t.Errorf("static_challenge_version = %s, want %s", data.StaticChallengeVersion, tt.wantVersion)
+			}
+			if !data.MaxLifetime.Equal(tt.wantMaxLifetime) {
+				t.Errorf("max_lifetime = %s, want %s", data.MaxLifetime, tt.wantMaxLifetime)
+			}
+		})
+	}
+}
+
+func TestValidateScepProfile(t *testing.T) {
+	ctx := context.Background()
+	base := func() scepProfileResourceModel {
+		return scepProfileResourceModel{
+			Name:              types.StringValue("p"),
+			MaxLifetime:       types.StringNull(),
+			AuthorizationMode: types.StringValue("challenge"),
+			ChallengeLifetime: types.StringNull(),
+			StaticChallenge:   types.StringNull(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*scepProfileResourceModel)
+		wantErr bool
+	}{
+		{name: "challenge", mutate: func(d *scepProfileResourceModel) {
+			d.ChallengeLifetime = types.StringValue("PT1H")
+		}},
+		{name: "static challenge", mutate: func(d *scepProfileResourceModel) {
+			d.AuthorizationMode = types.StringValue("static_challenge")
+			d.StaticChallenge = types.StringValue("s3cret")
+		}},
+		{name: "static challenge missing", wantErr: true, mutate: func(d *scepProfileResourceModel) {
+			d.AuthorizationMode = types.StringValue("static_challenge")
+		}},
+		{name: "static challenge unused", wantErr: true, mutate: func(d *scepProfileResourceModel) {
+			d.StaticChallenge = types.StringValue("s3cret")
+		}},
+		{name: "challenge lifetime with static challenge", wantErr: true, mutate: func(d *scepProfileResourceModel) {
+			d.AuthorizationMode = types.StringValue("static_challenge")
+			d.StaticChallenge = types.StringValue("s3cret")
+			d.ChallengeLifetime = types.StringValue("PT1H")
+		}},
+		{name: "unknown mode", wantErr: true, mutate: func(d *scepProfileResourceModel) {
+			d.AuthorizationMode = types.StringValue("none")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base()
+			tt.mutate(&data)
+			if diags := validateScepProfile(ctx, data); diags.HasError() != tt.wantErr {
+				t.Fatalf("want error %v, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
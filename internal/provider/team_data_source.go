@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewTeamDataSource() datasource.DataSource {
+	return &TeamDataSource{}
+}
+
+type TeamDataSource struct {
+	client *horizon.APIClient
+}
+
+func (d *TeamDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team"
+}
+
+func (d *TeamDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Looks a team up by name, for instance to check that the `team` of a `horizon_certificate` exists or to reach its contact.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the team.",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the team. The read fails if Horizon does not know it.",
+			},
+			"display_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the team shown in the Horizon UI.",
+			},
+			"contact_email": schema.StringAttribute{
+				Computed:    true,
+				Description: "Email address notifications about the certificates of the team are sent to.",
+			},
+			"managers": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Identifiers of the identities managing the team.",
+			},
+			"members": schema.SetAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Identifiers of the identities belonging to the team.",
+			},
+		},
+	}
+}
+
+func (d *TeamDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.client
+}
+
+func (d *TeamDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data teamResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, httpResp, err := d.client.TeamAPI.TeamGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			resp.Diagnostics.AddAttributeError(path.Root("name"), "Team not found", fmt.Sprintf("Horizon has no team named %q.", data.Name.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get team", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillTeamModel(ctx, &data, team)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
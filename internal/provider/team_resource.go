@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+func NewTeamResource() resource.Resource {
+	return &TeamResource{}
+}
+
+// TeamResource manages a team, the group of identities owning certificates.
+type TeamResource struct {
+	client *horizon.APIClient
+}
+
+type teamResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	DisplayName  types.String `tfsdk:"display_name"`
+	ContactEmail types.String `tfsdk:"contact_email"`
+	Managers     types.Set    `tfsdk:"managers"`
+	Members      types.Set    `tfsdk:"members"`
+}
+
+func (r *TeamResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team"
+}
+
+func (r *TeamResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a team, the group of identities a certificate is assigned to with the `team` attribute of `horizon_certificate`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the team.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the team, as referenced by certificates. Changing it creates a new team.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Name of the team shown in the Horizon UI.",
+			},
+			"contact_email": schema.StringAttribute{
+				Optional:    true,
+				Description: "Email address notifications about the certificates of the team are sent to.",
+			},
+			"managers": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Identifiers of the identities managing the team.",
+			},
+			"members": schema.SetAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Identifiers of the identities belonging to the team, such as local account names or OIDC subjects.",
+			},
+		},
+	}
+}
+
+func (r *TeamResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *TeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data teamResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, diags := teamFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating team %s", team.Name))
+	created, _, err := r.client.TeamAPI.TeamCreate(ctx).Team(*team).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create team", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillTeamModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data teamResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, httpResp, err := r.client.TeamAPI.TeamGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Team %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get team", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillTeamModel(ctx, &data, team)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data teamResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, diags := teamFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating team %s", team.Name))
+	updated, _, err := r.client.TeamAPI.TeamUpdate(ctx).Team(*team).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update team", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillTeamModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data teamResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.TeamAPI.TeamDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete team", err.Error())
+	}
+}
+
+func (r *TeamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *TeamResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data teamResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ContactEmail.IsNull() || data.ContactEmail.IsUnknown() {
+		return
+	}
+	if _, err := mail.ParseAddress(data.ContactEmail.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("contact_email"),
+			"Invalid contact_email value",
+			fmt.Sprintf("%q is not an email address: %s.", data.ContactEmail.ValueString(), err),
+		)
+	}
+}
+
+func teamFromModel(ctx context.Context, data teamResourceModel) (*models.Team, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	team := models.NewTeamWithDefaults()
+	team.Name = data.Name.ValueString()
+	team.DisplayName = data.DisplayName.ValueStringPointer()
+	team.ContactEmail = data.ContactEmail.ValueStringPointer()
+	if !data.Managers.IsNull() {
+		diags.Append(data.Managers.ElementsAs(ctx, &team.Managers, false)...)
+	}
+	if !data.Members.IsNull() {
+		diags.Append(data.Members.ElementsAs(ctx, &team.Members, false)...)
+	}
+	return team, diags
+}
+
+func fillTeamModel(ctx context.Context, data *teamResourceModel, team *models.Team) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(team.Name)
+	data.Name = types.StringValue(team.Name)
+	data.DisplayName = types.StringPointerValue(team.DisplayName)
+	data.ContactEmail = types.StringPointerValue(team.ContactEmail)
+	data.Managers = emptySetValue(data.Managers, types.StringType)
+	if len(team.Managers) > 0 {
+		managers, d := types.SetValueFrom(ctx, types.StringType, team.Managers)
+		diags.Append(d...)
+		data.Managers = managers
+	}
+	data.Members = emptySetValue(data.Members, types.StringType)
+	if len(team.Members) > 0 {
+		members, d := types.SetValueFrom(ctx, types.StringType, team.Members)
+		diags.Append(d...)
+		data.Members = members
+	}
+	return diags
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillTeamModel(t *testing.T) {
+	ctx := context.Background()
+	emptySet := types.SetValueMust(types.StringType, nil)
+	users := func(names ...string) types.Set {
+		values := make([]attr.Value, 0, len(names))
+		for _, name := range names {
+			values = append(values, types.StringValue(name))
+		}
+		return types.SetValueMust(types.StringType, values)
+	}
+	configured := func(managers, members types.Set) func(t *testing.T) teamResourceModel {
+		return func(t *testing.T) teamResourceModel {
+			return teamResourceModel{Name: types.StringValue("payments"), Managers: managers, Members: members}
+		}
+	}
+	imported := func(t *testing.T) teamResourceModel {
+		var data teamResourceModel
+		if diags := importedState(t, NewTeamResource(), "payments").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+	withoutMembers := &models.Team{Name: "payments", Managers: []string{}, Members: []string{}}
+
+	tests := []struct {
+		name         string
+		prior        func(t *testing.T) teamResourceModel
+		team         *models.Team
+		wantManagers types.Set
+		wantMembers  types.Set
+	}{
+		{
+			// Omitting members or managers does not plan a change.
+			name:         "unset members read back as null",
+			prior:        configured(types.SetNull(types.StringType), types.SetNull(types.StringType)),
+			team:         withoutMembers,
+			wantManagers: types.SetNull(types.StringType),
+			wantMembers:  types.SetNull(types.StringType),
+		},
+		{
+			// Neither does configuring them as empty.
+			name:         "empty members read back as empty",
+			prior:        configured(emptySet, emptySet),
+			team:         withoutMembers,
+			wantManagers: emptySet,
+			wantMembers:  emptySet,
+		},
+		{
+			name:         "members emptied outside of Terraform read back as null",
+			prior:        configured(users("alice"), users("bob")),
+			team:         withoutMembers,
+			wantManagers: types.SetNull(types.StringType),
+			wantMembers:  types.SetNull(types.StringType),
+		},
+		{
+			name:         "members changed outside of Terraform",
+			prior:        configured(users("alice"), emptySet),
+			team:         &models.Team{Name: "payments", Managers: []string{"carol"}, Members: []string{"bob"}},
+			wantManagers: users("carol"),
+			wantMembers:  users("bob"),
+		},
+		{
+			name:         "imported by name",
+			prior:        imported,
+			team:         &models.Team{Name: "payments", DisplayName: strPtr("Payments"), Managers: []string{"alice"}},
+			wantManagers: users("alice"),
+			wantMembers:  types.SetNull(types.StringType),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.team.Name {
+				t.Fatalf("Read looks up the team %q, want %q", data.Name.ValueString(), tt.team.Name)
+			}
+			if diags := fillTeamModel(ctx, &data, tt.team); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "payments" || !data.DisplayName.Equal(types.StringPointerValue(tt.team.DisplayName)) {
+				t.Errorf("id %s, display_name %s", data.Id, data.DisplayName)
+			}
+			if !data.Managers.Equal(tt.wantManagers) {
+				t.Errorf("managers = %s, want %s", data.Managers, tt.wantManagers)
+			}
+			if !data.Members.Equal(tt.wantMembers) {
+				t.Errorf("members = %s, want %s", data.Members, tt.wantMembers)
+			}
+		})
+	}
+}
+
+func TestTeamFromModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name        string
+		members     types.Set
+		wantMembers []string
+	}{
+		{name: "null members are not sent", members: types.SetNull(types.StringType)},
+		{name: "empty members are not sent", members: types.SetValueMust(types.StringType, nil)},
+		{name: "members are sent", members: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("bob")}), wantMembers: []string{"bob"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team, diags := teamFromModel(ctx, teamResourceModel{
+				Name:         types.StringValue("payments"),
+				DisplayName:  types.StringNull(),
+				ContactEmail: types.StringValue("payments@example.com"),
+				Managers:     tt.members,
+				Members:      tt.members,
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if team.Name != "payments" || team.DisplayName != nil || team.ContactEmail == nil || *team.ContactEmail != "payments@example.com" {
+				t.Errorf("unexpected team: %+v", team)
+			}
+			if !slices.Equal(team.Managers, tt.wantMembers) || !slices.Equal(team.Members, tt.wantMembers) {
+				t.Errorf("managers %v and members %v, want %v", team.Managers, team.Members, tt.wantMembers)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// Statuses of the certificate on a third party, in `third_party_data`.
+const (
+	thirdPartyPublished = "published"
+	thirdPartyRemoved   = "removed"
+	thirdPartyFailed    = "failed"
+)
+
+// triggerFailure is the status of a failed trigger result.
+const triggerFailure = "failure"
+
+// thirdPartyDataModel describes an element of the computed `third_party_data`
+// attribute. Horizon records when the certificate was pushed to, and removed
+// from, each connector, and the outcome of the last publication in the
+// trigger result named after the connector.
+type thirdPartyDataModel struct {
+	Connector   types.String `tfsdk:"connector"`
+	Id          types.String `tfsdk:"id"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	Status      types.String `tfsdk:"status"`
+	LastSync    types.Int64  `tfsdk:"last_sync"`
+	PushDate    types.Int64  `tfsdk:"push_date"`
+	RemoveDate  types.Int64  `tfsdk:"remove_date"`
+}
+
+var thirdPartyDataObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"connector":   types.StringType,
+	"id":          types.StringType,
+	"fingerprint": types.StringType,
+	"status":      types.StringType,
+	"last_sync":   types.Int64Type,
+	"push_date":   types.Int64Type,
+	"remove_date": types.Int64Type,
+}}
+
+// thirdPartyTriggerResult returns the latest trigger result of connector, or
+// nil when Horizon has none.
+func thirdPartyTriggerResult(certificate *models.Certificate, connector string) *models.CertificateTriggerResult {
+	var latest *models.CertificateTriggerResult
+	for i, result := range certificate.TriggerResults {
+		if result.GetName() != connector {
+			continue
+		}
+		if latest == nil || result.GetLastExecutionDate() > latest.GetLastExecutionDate() {
+			latest = &certificate.TriggerResults[i]
+		}
+	}
+	return latest
+}
+
+// thirdPartyStatus returns the status of the certificate on the third party
+// of item, and the date of the last synchronization with it: the last push,
+// removal or publication attempt.
+func thirdPartyStatus(certificate *models.Certificate, item models.ThirdPartyItem) (string, types.Int64) {
+	lastSync := max(item.GetPushDate(), item.GetRemoveDate())
+	status := thirdPartyPublished
+	if item.HasRemoveDate() {
+		status = thirdPartyRemoved
+	}
+	// A failed attempt after the last push, such as the publication of a
+	// renewed certificate, supersedes it.
+	if result := thirdPartyTriggerResult(certificate, item.GetConnector()); result != nil && result.GetLastExecutionDate() > lastSync {
+		lastSync = result.GetLastExecutionDate()
+		if string(result.GetStatus()) == triggerFailure {
+			status = thirdPartyFailed
+		}
+	}
+	if lastSync == 0 {
+		return status, types.Int64Null()
+	}
+	return status, types.Int64Value(lastSync)
+}
+
+// thirdPartyDataValue converts the third-party data of a certificate to the
+// `third_party_data` list, sorted by connector so that refreshes are stable.
+func thirdPartyDataValue(certificate *models.Certificate) types.List {
+	items := make([]models.ThirdPartyItem, len(certificate.ThirdPartyData))
+	copy(items, certificate.ThirdPartyData)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].GetConnector() < items[j].GetConnector()
+	})
+
+	elements := make([]attr.Value, 0, len(items))
+	for _, item := range items {
+		pushDate := types.Int64Null()
+		if item.HasPushDate() {
+			pushDate = types.Int64Value(item.GetPushDate())
+		}
+		removeDate := types.Int64Null()
+		if item.HasRemoveDate() {
+			removeDate = types.Int64Value(item.GetRemoveDate())
+		}
+		status, lastSync := thirdPartyStatus(certificate, item)
+		elements = append(elements, types.ObjectValueMust(thirdPartyDataObjectType.AttrTypes, map[string]attr.Value{
+			"connector":   types.StringValue(item.GetConnector()),
+			"id":          types.StringValue(item.GetId()),
+			"fingerprint": types.StringPointerValue(item.Fingerprint),
+			"status":      types.StringValue(status),
+			"last_sync":   lastSync,
+			"push_date":   pushDate,
+			"remove_date": removeDate,
+		}))
+	}
+	return types.ListValueMust(thirdPartyDataObjectType, elements)
+}
+
+// pendingThirdParties returns the awaited connectors the certificate has not
+// been published to yet. A connector counts as published once it appears in
+// the third-party data without a removal date.
+func pendingThirdParties(certificate *models.Certificate, connectors []string) []string {
+	published := make(map[string]struct{}, len(certificate.ThirdPartyData))
+	for _, item := range certificate.ThirdPartyData {
+		if !item.HasRemoveDate() {
+			published[item.GetConnector()] = struct{}{}
+		}
+	}
+
+	var pending []string
+	for _, connector := range connectors {
+		if _, ok := published[connector]; !ok {
+			pending = append(pending, connector)
+		}
+	}
+	return pending
+}
+
+// thirdPartyFailure returns an error for the first of the pending connectors
+// whose last publication failed and will not be retried, or nil.
+func thirdPartyFailure(certificate *models.Certificate, pending []string) error {
+	for _, connector := range pending {
+		result := thirdPartyTriggerResult(certificate, connector)
+		if result == nil || string(result.GetStatus()) != triggerFailure || result.GetRetryable() {
+			continue
+		}
+		if detail := result.GetDetail(); detail != "" {
+			return fmt.Errorf("publication of certificate %s to third party %s failed: %s", certificate.Id, connector, detail)
+		}
+		return fmt.Errorf("publication of certificate %s to third party %s failed", certificate.Id, connector)
+	}
+	return nil
+}
+
+// waitForThirdParties polls the certificate until it is published to every
+// connector, and stops as soon as the publication to one of them fails for
+// good. It returns the last polled certificate.
+func waitForThirdParties(ctx context.Context, client *horizon.APIClient, certificateId string, connectors []string, timeout time.Duration) (*models.Certificate, error) {
+	var polled *models.Certificate
+	err := retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		tflog.Info(ctx, fmt.Sprintf("Polling certificate %s for third parties: %v", certificateId, connectors))
+
+		certResp, _, err := client.CertificateAPI.CertificateGetId(ctx, certificateId).Execute()
+		if err != nil {
+			return retry.RetryableError(fmt.Errorf("failed to poll certificate %s: %s", certificateId, err.Error()))
+		}
+		cert := certResp.GetCertificate()
+		polled = toCertificate(&cert)
+
+		if pending := pendingThirdParties(polled, connectors); len(pending) > 0 {
+			if err := thirdPartyFailure(polled, pending); err != nil {
+				return retry.NonRetryableError(err)
+			}
+			return retry.RetryableError(fmt.Errorf("certificate %s is not yet published to third parties %s", certificateId, strings.Join(pending, ", ")))
+		}
+		return nil
+	})
+	return polled, err
+}
@@ -0,0 +1,327 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Third-party connectors publish certificates to external systems. Each
+// connector type is its own resource, built from a thirdPartyConnectorKind.
+
+// thirdPartyConnectorKind describes a Horizon third-party connector type.
+type thirdPartyConnectorKind struct {
+	typeName    string
+	horizonType string
+	title       string
+	settings    []connectorAttribute
+	credentials []connectorAttribute
+}
+
+var (
+	azureKeyVaultConnectorKind = thirdPartyConnectorKind{
+		typeName:    "azure_key_vault_connector",
+		horizonType: "akv",
+		title:       "Azure Key Vault",
+		settings: []connectorAttribute{
+			{name: "vault_url", key: "vaultUrl", required: true, description: "URL of the key vault, such as `https://example.vault.azure.net`."},
+			{name: "tenant_id", key: "tenantId", required: true, description: "Microsoft Entra tenant of the application Horizon authenticates as."},
+			{name: "client_id", key: "clientId", required: true, description: "Client id of the application Horizon authenticates as."},
+		},
+		credentials: []connectorAttribute{
+			{name: "client_secret", key: "clientSecret", required: true, description: "Client secret of the application."},
+		},
+	}
+	awsAcmConnectorKind = thirdPartyConnectorKind{
+		typeName:    "aws_acm_connector",
+		horizonType: "aws",
+		title:       "AWS Certificate Manager",
+		settings: []connectorAttribute{
+			{name: "region", key: "region", required: true, description: "AWS region of the certificates, such as `eu-west-1`."},
+			{name: "role_arn", key: "roleArn", description: "ARN of a role to assume to publish the certificates."},
+			{name: "access_key_id", key: "accessKeyId", description: "Access key id of the IAM user Horizon authenticates as. The credentials of the Horizon host are used when unset."},
+		},
+		credentials: []connectorAttribute{
+			{name: "secret_access_key", key: "secretAccessKey", requiredWith: "access_key_id", description: "Secret access key of the IAM user. Required with `access_key_id`."},
+		},
+	}
+	f5ConnectorKind = thirdPartyConnectorKind{
+		typeName:    "f5_connector",
+		horizonType: "f5",
+		title:       "F5 BIG-IP",
+		settings: []connectorAttribute{
+			{name: "host", key: "host", required: true, description: "Host name or address of the BIG-IP management interface."},
+			{name: "partition", key: "partition", description: "Partition the certificates are published to. `Common` when unset."},
+			{name: "username", key: "username", required: true, description: "Account Horizon authenticates as."},
+		},
+		credentials: []connectorAttribute{
+			{name: "password", key: "password", required: true, description: "Password of the account."},
+		},
+	}
+	intuneConnectorKind = thirdPartyConnectorKind{
+		typeName:    "intune_connector",
+		horizonType: "intune",
+		title:       "Microsoft Intune",
+		settings: []connectorAttribute{
+			{name: "tenant_id", key: "tenantId", required: true, description: "Microsoft Entra tenant of the application Horizon authenticates as."},
+			{name: "client_id", key: "clientId", required: true, description: "Client id of the application Horizon authenticates as."},
+		},
+		credentials: []connectorAttribute{
+			{name: "client_secret", key: "clientSecret", required: true, description: "Client secret of the application."},
+		},
+	}
+	webhookConnectorKind = thirdPartyConnectorKind{
+		typeName:    "webhook_connector",
+		horizonType: "webhook",
+		title:       "webhook",
+		settings: []connectorAttribute{
+			{name: "url", key: "url", required: true, description: "URL the certificates are sent to."},
+		},
+		credentials: []connectorAttribute{
+			{name: "authorization", key: "authorization", description: "Value of the `Authorization` header of the requests."},
+		},
+	}
+)
+
+func NewAzureKeyVaultConnectorResource() resource.Resource {
+	return &ThirdPartyConnectorResource{kind: azureKeyVaultConnectorKind}
+}
+
+func NewAwsAcmConnectorResource() resource.Resource {
+	return &ThirdPartyConnectorResource{kind: awsAcmConnectorKind}
+}
+
+func NewF5ConnectorResource() resource.Resource {
+	return &ThirdPartyConnectorResource{kind: f5ConnectorKind}
+}
+
+func NewIntuneConnectorResource() resource.Resource {
+	return &ThirdPartyConnectorResource{kind: intuneConnectorKind}
+}
+
+func NewWebhookConnectorResource() resource.Resource {
+	return &ThirdPartyConnectorResource{kind: webhookConnectorKind}
+}
+
+// ThirdPartyConnectorResource manages a third-party connector of one type.
+type ThirdPartyConnectorResource struct {
+	client *horizon.APIClient
+	kind   thirdPartyConnectorKind
+}
+
+func (r *ThirdPartyConnectorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind.typeName
+}
+
+func (r *ThirdPartyConnectorResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: fmt.Sprintf("Manages a %s third-party connector, which Horizon publishes certificates to. ", r.kind.title) +
+			"Reference its `id` in the `wait_for_third_parties` of `horizon_certificate`. " +
+			"Credentials are write-only: they are not stored in the state, so changes to them are only applied when `credentials_version` changes. Requires Terraform 1.11 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the connector.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the connector. Changing it creates a new connector.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the connector.",
+			},
+			"credentials_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Arbitrary number to change when the credentials change. Credentials are only sent to Horizon on creation and when it changes.",
+			},
+		},
+	}
+
+	addConnectorAttributes(resp.Schema.Attributes, r.kind.settings, r.kind.credentials)
+}
+
+func (r *ThirdPartyConnectorResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *ThirdPartyConnectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	connector, diags := r.connectorFromPlan(ctx, req.Plan, req.Config, true)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating %s connector %s", r.kind.horizonType, connector.Name))
+	created, _, err := r.client.ThirdPartyConnectorAPI.ThirdPartyConnectorCreate(ctx).ThirdPartyConnector(*connector).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create third-party connector", err.Error())
+		return
+	}
+
+	state, diags := newConnectorState(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.fillState(ctx, &state, created)...)
+	resp.State.Raw = state.Raw
+}
+
+func (r *ThirdPartyConnectorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var name types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, httpResp, err := r.client.ThirdPartyConnectorAPI.ThirdPartyConnectorGet(ctx, name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Third-party connector %s not found in horizon; removing from state", name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get third-party connector", err.Error())
+		return
+	}
+	if connector.Type != r.kind.horizonType {
+		resp.Diagnostics.AddError(
+			"Unexpected third-party connector type",
+			fmt.Sprintf("Connector %q is of type %q, not %q.", name.ValueString(), connector.Type, r.kind.horizonType),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.fillState(ctx, &resp.State, connector)...)
+}
+
+func (r *ThirdPartyConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	withCredentials, diags := credentialsVersionChanged(ctx, req.Plan, req.State)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, diags := r.connectorFromPlan(ctx, req.Plan, req.Config, withCredentials)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating %s connector %s", r.kind.horizonType, connector.Name))
+	updated, _, err := r.client.ThirdPartyConnectorAPI.ThirdPartyConnectorUpdate(ctx).ThirdPartyConnector(*connector).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update third-party connector", err.Error())
+		return
+	}
+
+	state, diags := newConnectorState(ctx, req.Plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(r.fillState(ctx, &state, updated)...)
+	resp.State.Raw = state.Raw
+}
+
+func (r *ThirdPartyConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var name types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.ThirdPartyConnectorAPI.ThirdPartyConnectorDelete(ctx, name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete third-party connector", err.Error())
+	}
+}
+
+func (r *ThirdPartyConnectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *ThirdPartyConnectorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConnectorCredentials(ctx, req.Config, r.kind.credentials)...)
+}
+
+// connectorFromPlan builds the connector described by the plan, with the
+// credentials of the configuration, the only place write-only values are
+// available, when withCredentials is set.
+func (r *ThirdPartyConnectorResource) connectorFromPlan(ctx context.Context, plan tfsdk.Plan, config tfsdk.Config, withCredentials bool) (*models.ThirdPartyConnector, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var name, description types.String
+	diags.Append(plan.GetAttribute(ctx, path.Root("name"), &name)...)
+	diags.Append(plan.GetAttribute(ctx, path.Root("description"), &description)...)
+
+	settings, credentials, d := getConnectorAttributes(ctx, plan, config, r.kind.settings, r.kind.credentials)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if !withCredentials {
+		credentials = nil
+	}
+	return r.kind.connector(name.ValueString(), description, settings, credentials), diags
+}
+
+// connector builds a connector of kind k from attribute values. Null values
+// are left out: Horizon keeps the credentials it has when none are sent.
+func (k thirdPartyConnectorKind) connector(name string, description types.String, settings, credentials map[string]types.String) *models.ThirdPartyConnector {
+	connector := models.NewThirdPartyConnectorWithDefaults()
+	connector.Name = name
+	connector.Type = k.horizonType
+	connector.Description = description.ValueStringPointer()
+	connector.Settings = connectorValues(k.settings, settings)
+	connector.Credentials = connectorValues(k.credentials, credentials)
+	return connector
+}
+
+// settingValues returns the Terraform values of the settings of connector.
+func (k thirdPartyConnectorKind) settingValues(connector *models.ThirdPartyConnector) map[string]types.String {
+	return connectorAttributeValues(k.settings, connector.Settings)
+}
+
+// fillState sets the attributes of state Horizon knows of to the values of
+// connector. Credentials stay null, as write-only attributes must.
+func (r *ThirdPartyConnectorResource) fillState(ctx context.Context, state *tfsdk.State, connector *models.ThirdPartyConnector) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	diags.Append(state.SetAttribute(ctx, path.Root("id"), types.StringValue(connector.Name))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("name"), types.StringValue(connector.Name))...)
+	diags.Append(state.SetAttribute(ctx, path.Root("description"), types.StringPointerValue(connector.Description))...)
+
+	diags.Append(setConnectorAttributes(ctx, state, r.kind.settingValues(connector))...)
+	return diags
+}
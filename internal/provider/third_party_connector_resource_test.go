@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestThirdPartyConnectorSchemas(t *testing.T) {
+	ctx := context.Background()
+	for _, newResource := range []func() resource.Resource{
+		NewAzureKeyVaultConnectorResource,
+		NewAwsAcmConnectorResource,
+		NewF5ConnectorResource,
+		NewIntuneConnectorResource,
+		NewWebhookConnectorResource,
+	} {
+		r := newResource().(*ThirdPartyConnectorResource)
+		t.Run(r.kind.typeName, func(t *testing.T) {
+			resp := &resource.SchemaResponse{}
+			r.Schema(ctx, resource.SchemaRequest{}, resp)
+			if diags := resp.Schema.ValidateImplementation(ctx); diags.HasError() {
+				t.Fatalf("invalid schema: %v", diags)
+			}
+
+			for _, credential := range r.kind.credentials {
+				attribute := resp.Schema.Attributes[credential.name].(schema.StringAttribute)
+				if !attribute.WriteOnly || !attribute.Sensitive {
+					t.Errorf("credential %s must be write-only and sensitive", credential.name)
+				}
+			}
+			for _, setting := range r.kind.settings {
+				attribute := resp.Schema.Attributes[setting.name].(schema.StringAttribute)
+				if attribute.WriteOnly || attribute.Required != setting.required {
+					t.Errorf("unexpected schema for setting %s: %+v", setting.name, attribute)
+				}
+			}
+		})
+	}
+}
+
+// connectorTestValue returns the value of a connector of schema s holding
+// values, the other attributes being null.
+func connectorTestValue(t *testing.T, s schema.Schema, values map[string]attr.Value) tftypes.Value {
+	t.Helper()
+	ctx := context.Background()
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	for name, value := range values {
+		if diags := state.SetAttribute(ctx, path.Root(name), value); diags.HasError() {
+			t.Fatalf("%s: %v", name, diags)
+		}
+	}
+	return state.Raw
+}
+
+func TestThirdPartyConnectorCredentials(t *testing.T) {
+	ctx := context.Background()
+	r := NewAwsAcmConnectorResource().(*ThirdPartyConnectorResource)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	connector := func(version types.Int64) map[string]attr.Value {
+		return map[string]attr.Value{
+			"name":                types.StringValue("acm"),
+			"region":              types.StringValue("eu-west-1"),
+			"access_key_id":       types.StringValue("AKIAEXAMPLE"),
+			"credentials_version": version,
+		}
+	}
+	tests := []struct {
+		name            string
+		prior           types.Int64
+		planned         types.Int64
+		secret          types.String
+		wantCredentials map[string]string
+	}{
+		{name: "unchanged version", prior: types.Int64Value(1), planned: types.Int64Value(1), secret: types.StringValue("secret")},
+		{name: "bumped version", prior: types.Int64Value(1), planned: types.Int64Value(2), secret: types.StringValue("rotated"), wantCredentials: map[string]string{"secretAccessKey": "rotated"}},
+		{name: "version set for the first time", prior: types.Int64Null(), planned: types.Int64Value(1), secret: types.StringValue("secret"), wantCredentials: map[string]string{"secretAccessKey": "secret"}},
+		{name: "no version", prior: types.Int64Null(), planned: types.Int64Null(), secret: types.StringValue("secret")},
+		{name: "bumped version without credentials", prior: types.Int64Value(1), planned: types.Int64Value(2), secret: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Write-only credentials are only in the configuration.
+			config := connector(tt.planned)
+			config["secret_access_key"] = tt.secret
+			plan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, connector(tt.planned))}
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, connector(tt.prior))}
+
+			// As Update does.
+			withCredentials, diags := credentialsVersionChanged(ctx, plan, state)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			got, diags := r.connectorFromPlan(ctx, plan, tfsdk.Config{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, config)}, withCredentials)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if got.Name != "acm" || got.Type != "aws" || !maps.Equal(got.Settings, map[string]string{"region": "eu-west-1", "accessKeyId": "AKIAEXAMPLE"}) {
+				t.Errorf("settings must always be sent, got %+v", got)
+			}
+			// Without credentials, Horizon keeps the ones it has.
+			if !maps.Equal(got.Credentials, tt.wantCredentials) || (tt.wantCredentials == nil && got.Credentials != nil) {
+				t.Errorf("credentials = %v, want %v", got.Credentials, tt.wantCredentials)
+			}
+		})
+	}
+}
+
+func TestThirdPartyConnectorFillState(t *testing.T) {
+	ctx := context.Background()
+	r := NewAwsAcmConnectorResource().(*ThirdPartyConnectorResource)
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	stored := &models.ThirdPartyConnector{Name: "acm", Type: "aws", Settings: map[string]string{"region": "us-east-1"}}
+
+	tests := []struct {
+		name        string
+		prior       func(t *testing.T) tfsdk.State
+		wantVersion types.Int64
+	}{
+		{
+			name: "read",
+			prior: func(t *testing.T) tfsdk.State {
+				return tfsdk.State{Schema: schemaResp.Schema, Raw: connectorTestValue(t, schemaResp.Schema, map[string]attr.Value{
+					"name":                types.StringValue("acm"),
+					"region":              types.StringValue("eu-west-1"),
+					"role_arn":            types.StringValue("arn:aws:iam::123456789012:role/horizon"),
+					"credentials_version": types.Int64Value(3),
+				})}
+			},
+			wantVersion: types.Int64Value(3),
+		},
+		{
+			name:        "imported by name",
+			prior:       func(t *testing.T) tfsdk.State { return importedState(t, r, "acm") },
+			wantVersion: types.Int64Null(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tt.prior(t)
+			var name types.String
+			state.GetAttribute(ctx, path.Root("name"), &name)
+			if name.ValueString() != "acm" {
+				t.Fatalf("Read looks up the connector %s, want acm", name)
+			}
+			if diags := r.fillState(ctx, &state, stored); diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			want := map[string]attr.Value{
+				"id":                  types.StringValue("acm"),
+				"description":         types.StringNull(),
+				"region":              types.StringValue("us-east-1"),
+				"role_arn":            types.StringNull(),
+				"access_key_id":       types.StringNull(),
+				"secret_access_key":   types.StringNull(),
+				"credentials_version": tt.wantVersion,
+			}
+			for attribute, wantValue := range want {
+				var got attr.Value
+				if attribute == "credentials_version" {
+					var version types.Int64
+					state.GetAttribute(ctx, path.Root(attribute), &version)
+					got = version
+				} else {
+					var value types.String
+					state.GetAttribute(ctx, path.Root(attribute), &value)
+					got = value
+				}
+				if !got.Equal(wantValue) {
+					t.Errorf("%s = %s, want %s", attribute, got, wantValue)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectThirdPartyConnectors(t *testing.T) {
+	connectors := []models.ThirdPartyConnector{
+		{Name: "vault-prod", Type: "akv"},
+		{Name: "acm", Type: "aws"},
+		{Name: "vault-dev", Type: "akv"},
+	}
+
+	all := selectThirdPartyConnectors(connectors, "")
+	if len(all) != 3 || all[0].Name != "acm" || all[2].Name != "vault-prod" {
+		t.Fatalf("want all connectors sorted by name, got %v", all)
+	}
+	vaults := selectThirdPartyConnectors(connectors, "akv")
+	if len(vaults) != 2 || vaults[0].Name != "vault-dev" || vaults[1].Name != "vault-prod" {
+		t.Fatalf("want the Azure Key Vault connectors, got %v", vaults)
+	}
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func NewThirdPartyConnectorsDataSource() datasource.DataSource {
+	return &ThirdPartyConnectorsDataSource{}
+}
+
+type ThirdPartyConnectorsDataSource struct {
+	client *horizon.APIClient
+}
+
+type thirdPartyConnectorsDataSourceModel struct {
+	Type       types.String `tfsdk:"type"`
+	Ids        types.List   `tfsdk:"ids"`
+	Connectors types.List   `tfsdk:"connectors"`
+}
+
+var thirdPartyConnectorObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"id":          types.StringType,
+	"type":        types.StringType,
+	"description": types.StringType,
+	"settings":    types.MapType{ElemType: types.StringType},
+}}
+
+func (d *ThirdPartyConnectorsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_third_party_connectors"
+}
+
+func (d *ThirdPartyConnectorsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the third-party connectors of Horizon, whose ids `horizon_certificate` takes in `wait_for_third_parties`. Credentials are never returned.",
+		Attributes: map[string]schema.Attribute{
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return the connectors of this Horizon type, such as `akv`, `aws`, `f5`, `intune` or `webhook`.",
+			},
+			"ids": schema.ListAttribute{
+				Computed:    true,
+				ElementType: types.StringType,
+				Description: "Ids of the connectors, sorted.",
+			},
+			"connectors": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Connectors, sorted by id.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Id, or name, of the connector.",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Horizon type of the connector.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the connector.",
+						},
+						"settings": schema.MapAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+							Description: "Settings of the connector, by Horizon name.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ThirdPartyConnectorsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.client
+}
+
+func (d *ThirdPartyConnectorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data thirdPartyConnectorsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connectors, _, err := d.client.ThirdPartyConnectorAPI.ThirdPartyConnectorGetAll(ctx).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list third-party connectors", err.Error())
+		return
+	}
+
+	selected := selectThirdPartyConnectors(connectors, data.Type.ValueString())
+	ids := make([]attr.Value, 0, len(selected))
+	elements := make([]attr.Value, 0, len(selected))
+	for _, connector := range selected {
+		settings := make(map[string]attr.Value, len(connector.Settings))
+		for key, value := range connector.Settings {
+			settings[key] = types.StringValue(value)
+		}
+		ids = append(ids, types.StringValue(connector.Name))
+		elements = append(elements, types.ObjectValueMust(thirdPartyConnectorObjectType.AttrTypes, map[string]attr.Value{
+			"id":          types.StringValue(connector.Name),
+			"type":        types.StringValue(connector.Type),
+			"description": types.StringPointerValue(connector.Description),
+			"settings":    types.MapValueMust(types.StringType, settings),
+		}))
+	}
+	data.Ids = types.ListValueMust(types.StringType, ids)
+	data.Connectors = types.ListValueMust(thirdPartyConnectorObjectType, elements)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// selectThirdPartyConnectors returns the connectors of the given type, or all
+// of them when connectorType is empty, sorted by name.
+func selectThirdPartyConnectors(connectors []models.ThirdPartyConnector, connectorType string) []models.ThirdPartyConnector {
+	selected := make([]models.ThirdPartyConnector, 0, len(connectors))
+	for _, connector := range connectors {
+		if connectorType == "" || connector.Type == connectorType {
+			selected = append(selected, connector)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+	return selected
+}
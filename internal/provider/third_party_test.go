@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestPendingThirdParties(t *testing.T) {
+	tests := []struct {
+		name        string
+		cert        models.Certificate
+		connectors  []string
+		wantPending []string
+	}{
+		{
+			name:       "all connectors published",
+			connectors: []string{"aws", "azure"},
+			cert: models.Certificate{ThirdPartyData: []models.ThirdPartyItem{
+				{Connector: "aws", Id: "arn:1"},
+				{Connector: "azure", Id: "kv-1"},
+			}},
+		},
+		{
+			name:        "connector not yet published is pending",
+			connectors:  []string{"aws", "azure"},
+			cert:        models.Certificate{ThirdPartyData: []models.ThirdPartyItem{{Connector: "aws", Id: "arn:1"}}},
+			wantPending: []string{"azure"},
+		},
+		{
+			name:        "removed certificate is not published",
+			connectors:  []string{"aws"},
+			cert:        models.Certificate{ThirdPartyData: []models.ThirdPartyItem{{Connector: "aws", Id: "arn:1", RemoveDate: int64Ptr(1700000000000)}}},
+			wantPending: []string{"aws"},
+		},
+		{
+			name:       "failed trigger named after the connector is not a publication",
+			connectors: []string{"aws"},
+			cert: models.Certificate{TriggerResults: []models.CertificateTriggerResult{
+				{Name: "aws", Status: "failure", Detail: strPtr("throttled")},
+			}},
+			wantPending: []string{"aws"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending := pendingThirdParties(&tt.cert, tt.connectors)
+			if strings.Join(pending, ",") != strings.Join(tt.wantPending, ",") {
+				t.Fatalf("pending = %v, want %v", pending, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestThirdPartyDataValue(t *testing.T) {
+	cert := &models.Certificate{
+		ThirdPartyData: []models.ThirdPartyItem{
+			{Connector: "azure", Id: "kv-1", PushDate: int64Ptr(1000), RemoveDate: int64Ptr(2000)},
+			{Connector: "aws", Id: "arn:1", Fingerprint: strPtr("ab:cd"), PushDate: int64Ptr(1500)},
+			{Connector: "f5", Id: "f5-1"},
+			{Connector: "intune", Id: "in-1", PushDate: int64Ptr(1000)},
+			{Connector: "webhook", Id: "wh-1", PushDate: int64Ptr(3000)},
+		},
+		TriggerResults: []models.CertificateTriggerResult{
+			// The publication of a renewal failed after the last push.
+			{Name: "intune", Status: "success", LastExecutionDate: 1000},
+			{Name: "intune", Status: "failure", Detail: strPtr("unauthorized"), LastExecutionDate: 4000},
+			// An older failure does not supersede the last push.
+			{Name: "webhook", Status: "failure", LastExecutionDate: 2500},
+		},
+	}
+
+	got := thirdPartyDataValue(cert)
+	var items []thirdPartyDataModel
+	if diags := got.ElementsAs(t.Context(), &items, false); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	want := []thirdPartyDataModel{
+		{Connector: types.StringValue("aws"), Id: types.StringValue("arn:1"), Fingerprint: types.StringValue("ab:cd"), Status: types.StringValue("published"), LastSync: types.Int64Value(1500), PushDate: types.Int64Value(1500), RemoveDate: types.Int64Null()},
+		{Connector: types.StringValue("azure"), Id: types.StringValue("kv-1"), Fingerprint: types.StringNull(), Status: types.StringValue("removed"), LastSync: types.Int64Value(2000), PushDate: types.Int64Value(1000), RemoveDate: types.Int64Value(2000)},
+		{Connector: types.StringValue("f5"), Id: types.StringValue("f5-1"), Fingerprint: types.StringNull(), Status: types.StringValue("published"), LastSync: types.Int64Null(), PushDate: types.Int64Null(), RemoveDate: types.Int64Null()},
+		{Connector: types.StringValue("intune"), Id: types.StringValue("in-1"), Fingerprint: types.StringNull(), Status: types.StringValue("failed"), LastSync: types.Int64Value(4000), PushDate: types.Int64Value(1000), RemoveDate: types.Int64Null()},
+		{Connector: types.StringValue("webhook"), Id: types.StringValue("wh-1"), Fingerprint: types.StringNull(), Status: types.StringValue("published"), LastSync: types.Int64Value(3000), PushDate: types.Int64Value(3000), RemoveDate: types.Int64Null()},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("got %d elements, want %d", len(items), len(want))
+	}
+	for i := range want {
+		if items[i] != want[i] {
+			t.Errorf("element %d = %+v, want %+v", i, items[i], want[i])
+		}
+	}
+
+	if empty := thirdPartyDataValue(&models.Certificate{}); empty.IsNull() || len(empty.Elements()) != 0 {
+		t.Errorf("certificate without third parties must yield an empty list, got %v", empty)
+	}
+}
+
+func TestThirdPartyFailure(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []models.CertificateTriggerResult
+		pending []string
+		wantErr string
+	}{
+		{
+			name:    "no trigger result yet",
+			pending: []string{"aws"},
+		},
+		{
+			name:    "retryable failure keeps waiting",
+			results: []models.CertificateTriggerResult{{Name: "aws", Status: "failure", Retryable: true, Detail: strPtr("throttled")}},
+			pending: []string{"aws"},
+		},
+		{
+			name:    "permanent failure stops with the detail",
+			results: []models.CertificateTriggerResult{{Name: "aws", Status: "failure", Detail: strPtr("access denied")}},
+			pending: []string{"aws"},
+			wantErr: "publication of certificate 42 to third party aws failed: access denied",
+		},
+		{
+			name: "latest result wins",
+			results: []models.CertificateTriggerResult{
+				{Name: "aws", Status: "failure", Detail: strPtr("access denied"), LastExecutionDate: 1000},
+				{Name: "aws", Status: "failure", Retryable: true, LastExecutionDate: 2000},
+			},
+			pending: []string{"aws"},
+		},
+		{
+			name:    "failure of a connector not waited on",
+			results: []models.CertificateTriggerResult{{Name: "f5", Status: "failure"}},
+			pending: []string{"aws"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := thirdPartyFailure(&models.Certificate{Id: "42", TriggerResults: tt.results}, tt.pending)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Fatalf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// isDeadlineExceeded reports whether an operation was stopped by its deadline
+// rather than by a Horizon error: either err wraps the context deadline, a
+// polling loop gave up, or the operation context itself has expired.
+func isDeadlineExceeded(ctx context.Context, err error) bool {
+	var timeoutErr *retry.TimeoutError
+	return errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &timeoutErr) ||
+		errors.Is(ctx.Err(), context.DeadlineExceeded)
+}
+
+// addOperationError reports err under summary. When the operation deadline
+// stopped the call, a distinct diagnostic pointing at the configured timeout
+// is reported instead, so that it is not mistaken for an API failure.
+func addOperationError(ctx context.Context, diags *diag.Diagnostics, summary string, err error, timeout time.Duration) {
+	if isDeadlineExceeded(ctx, err) {
+		diags.AddError(
+			summary+": timeout exceeded",
+			fmt.Sprintf("The operation did not complete within %s. If Horizon needs more time, increase the corresponding value of the `timeouts` block. Last error: %s", timeout, err),
+		)
+		return
+	}
+	diags.AddError(summary, err.Error())
+}
+
+// appendOperationDiagnostics appends the diagnostics of an operation. When
+// they hold an error and the operation deadline has passed, a diagnostic
+// pointing at the configured timeout is reported next to them.
+func appendOperationDiagnostics(ctx context.Context, diags *diag.Diagnostics, summary string, operation diag.Diagnostics, timeout time.Duration) {
+	diags.Append(operation...)
+	if operation.HasError() && isDeadlineExceeded(ctx, nil) {
+		diags.AddError(
+			summary+": timeout exceeded",
+			fmt.Sprintf("The operation did not complete within %s. If Horizon needs more time, increase the corresponding value of the `timeouts` block.", timeout),
+		)
+	}
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+func TestIsDeadlineExceeded(t *testing.T) {
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+		want bool
+	}{
+		{name: "api error", ctx: context.Background(), err: errors.New("400 Bad Request"), want: false},
+		{name: "wrapped deadline", ctx: context.Background(), err: fmt.Errorf("Get \"https://horizon\": %w", context.DeadlineExceeded), want: true},
+		{name: "polling timeout", ctx: context.Background(), err: &retry.TimeoutError{LastError: errors.New("not yet published")}, want: true},
+		{name: "expired context", ctx: expired, err: errors.New("connection reset"), want: true},
+		{name: "canceled context", ctx: canceledContext(), err: context.Canceled, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDeadlineExceeded(tt.ctx, tt.err); got != tt.want {
+				t.Fatalf("isDeadlineExceeded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestAddOperationError(t *testing.T) {
+	var apiDiags diag.Diagnostics
+	addOperationError(context.Background(), &apiDiags, "Failed to renew certificate", errors.New("403 Forbidden"), time.Minute)
+	if got := apiDiags.Errors()[0].Summary(); got != "Failed to renew certificate" {
+		t.Errorf("API error summary = %q", got)
+	}
+
+	var timeoutDiags diag.Diagnostics
+	addOperationError(context.Background(), &timeoutDiags, "Failed to renew certificate", context.DeadlineExceeded, 90*time.Second)
+	d := timeoutDiags.Errors()[0]
+	if d.Summary() != "Failed to renew certificate: timeout exceeded" {
+		t.Errorf("timeout summary = %q", d.Summary())
+	}
+	if !strings.Contains(d.Detail(), "1m30s") {
+		t.Errorf("timeout detail must name the configured timeout, got %q", d.Detail())
+	}
+}
+
+func TestAppendOperationDiagnostics(t *testing.T) {
+	var operation diag.Diagnostics
+	operation.AddWarning("Escrow check skipped", "")
+	operation.AddError("Failed to search requests", "context deadline exceeded")
+
+	var apiDiags diag.Diagnostics
+	appendOperationDiagnostics(context.Background(), &apiDiags, "Failed to retrieve PKCS#12 material", operation, time.Minute)
+	if !apiDiags.Equal(operation) {
+		t.Errorf("before the deadline, want the operation diagnostics only, got %v", apiDiags)
+	}
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	var timeoutDiags diag.Diagnostics
+	appendOperationDiagnostics(expired, &timeoutDiags, "Failed to retrieve PKCS#12 material", operation, 90*time.Second)
+	if len(timeoutDiags) != 3 || !timeoutDiags[:2].Equal(operation) {
+		t.Fatalf("want the operation diagnostics kept, got %v", timeoutDiags)
+	}
+	d := timeoutDiags[2]
+	if d.Summary() != "Failed to retrieve PKCS#12 material: timeout exceeded" || !strings.Contains(d.Detail(), "1m30s") {
+		t.Errorf("timeout diagnostic = %q: %q", d.Summary(), d.Detail())
+	}
+
+	var warnings diag.Diagnostics
+	appendOperationDiagnostics(expired, &warnings, "Failed to retrieve PKCS#12 material", operation[:1], time.Minute)
+	if len(warnings) != 1 {
+		t.Errorf("without an error, want no timeout diagnostic, got %v", warnings)
+	}
+}
@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultTriggerMethod      = http.MethodPost
+	defaultTriggerContentType = "application/json"
+)
+
+func NewTriggerResource() resource.Resource {
+	return &TriggerResource{}
+}
+
+// TriggerResource manages a webhook called on certificate lifecycle events.
+type TriggerResource struct {
+	client *horizon.APIClient
+}
+
+type triggerResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Events      types.Set    `tfsdk:"events"`
+	Url         types.String `tfsdk:"url"`
+	Method      types.String `tfsdk:"method"`
+	ContentType types.String `tfsdk:"content_type"`
+	Body        types.String `tfsdk:"body"`
+	Headers     types.Map    `tfsdk:"headers"`
+	Retries     types.Int64  `tfsdk:"retries"`
+	Profiles    types.Set    `tfsdk:"profiles"`
+}
+
+func (r *TriggerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trigger"
+}
+
+func (r *TriggerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a trigger, a webhook Horizon calls on certificate lifecycle events for the certificates of the attached profiles. " +
+			"Its results show in the `trigger_results` of `horizon_certificate`. See `horizon_notification` to send emails instead.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the trigger.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the trigger. Changing it creates a new trigger.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the trigger.",
+			},
+			"events": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Events calling the webhook. Values among `enroll`, `renew`, `update`, `revoke`, `recover`, `migrate` and `expire`.",
+			},
+			"url": schema.StringAttribute{
+				Required:    true,
+				Description: "URL of the webhook.",
+			},
+			"method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultTriggerMethod),
+				MarkdownDescription: "HTTP method of the requests. Defaults to `POST`.",
+			},
+			"content_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultTriggerContentType),
+				MarkdownDescription: "Content type of the requests. Defaults to `application/json`.",
+			},
+			"body": schema.StringAttribute{
+				Optional:    true,
+				Description: "Template of the request body, in the Horizon template syntax. Horizon sends the certificate when unset.",
+			},
+			"headers": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Additional headers of the requests.",
+			},
+			"retries": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Description: "How many times Horizon retries a failed call. Defaults to 0.",
+			},
+			"profiles": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Profiles whose certificates the trigger applies to, such as the `name` of a `horizon_webra_profile`. The trigger is attached to no profile when unset or empty.",
+			},
+		},
+	}
+}
+
+func (r *TriggerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *TriggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data triggerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trigger, diags := triggerFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating trigger %s", trigger.Name))
+	created, _, err := r.client.TriggerAPI.TriggerCreate(ctx).Trigger(*trigger).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create trigger", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillTriggerModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TriggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data triggerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trigger, httpResp, err := r.client.TriggerAPI.TriggerGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("Trigger %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get trigger", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillTriggerModel(ctx, &data, trigger)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TriggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data triggerResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	trigger, diags := triggerFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating trigger %s", trigger.Name))
+	updated, _, err := r.client.TriggerAPI.TriggerUpdate(ctx).Trigger(*trigger).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update trigger", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillTriggerModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TriggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data triggerResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.TriggerAPI.TriggerDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete trigger", err.Error())
+	}
+}
+
+func (r *TriggerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *TriggerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data triggerResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, diags := validateLifecycleEvents(ctx, data.Events)
+	resp.Diagnostics.Append(diags...)
+
+	if !data.Url.IsNull() && !data.Url.IsUnknown() {
+		if u, err := url.Parse(data.Url.ValueString()); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("url"),
+				"Invalid url value",
+				fmt.Sprintf("%q is not an HTTP or HTTPS URL.", data.Url.ValueString()),
+			)
+		}
+	}
+	if !data.Retries.IsNull() && !data.Retries.IsUnknown() && data.Retries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retries"),
+			"Invalid retries value",
+			"retries must not be negative.",
+		)
+	}
+}
+
+func triggerFromModel(ctx context.Context, data triggerResourceModel) (*models.Trigger, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	trigger := models.NewTriggerWithDefaults()
+	trigger.Name = data.Name.ValueString()
+	trigger.Description = data.Description.ValueStringPointer()
+	trigger.Url = data.Url.ValueString()
+	trigger.Method = data.Method.ValueStringPointer()
+	trigger.ContentType = data.ContentType.ValueStringPointer()
+	trigger.Body = data.Body.ValueStringPointer()
+	if !data.Retries.IsNull() {
+		retries := int32(data.Retries.ValueInt64())
+		trigger.Retries = &retries
+	}
+	diags.Append(data.Events.ElementsAs(ctx, &trigger.Events, false)...)
+	if !data.Headers.IsNull() {
+		diags.Append(data.Headers.ElementsAs(ctx, &trigger.Headers, false)...)
+	}
+	if !data.Profiles.IsNull() {
+		diags.Append(data.Profiles.ElementsAs(ctx, &trigger.Profiles, false)...)
+	}
+	return trigger, diags
+}
+
+func fillTriggerModel(ctx context.Context, data *triggerResourceModel, trigger *models.Trigger) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var d diag.Diagnostics
+
+	data.Id = types.StringValue(trigger.Name)
+	data.Name = types.StringValue(trigger.Name)
+	data.Description = types.StringPointerValue(trigger.Description)
+	data.Url = types.StringValue(trigger.Url)
+	data.Method = types.StringValue(defaultTriggerMethod)
+	if trigger.Method != nil {
+		data.Method = types.StringValue(*trigger.Method)
+	}
+	data.ContentType = types.StringValue(defaultTriggerContentType)
+	if trigger.ContentType != nil {
+		data.ContentType = types.StringValue(*trigger.ContentType)
+	}
+	data.Body = types.StringPointerValue(trigger.Body)
+	data.Retries = types.Int64Value(0)
+	if trigger.Retries != nil {
+		data.Retries = types.Int64Value(int64(*trigger.Retries))
+	}
+
+	data.Events, d = types.SetValueFrom(ctx, types.StringType, trigger.Events)
+	diags.Append(d...)
+	data.Headers = emptyMapValue(data.Headers, types.StringType)
+	if len(trigger.Headers) > 0 {
+		data.Headers, d = types.MapValueFrom(ctx, types.StringType, trigger.Headers)
+		diags.Append(d...)
+	}
+	data.Profiles = emptySetValue(data.Profiles, types.StringType)
+	if len(trigger.Profiles) > 0 {
+		data.Profiles, d = types.SetValueFrom(ctx, types.StringType, trigger.Profiles)
+		diags.Append(d...)
+	}
+	return diags
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFillTriggerModel(t *testing.T) {
+	ctx := context.Background()
+	emptyMap := types.MapValueMust(types.StringType, nil)
+	emptySet := types.SetValueMust(types.StringType, nil)
+	profiles := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("WebServers")})
+	headers := types.MapValueMust(types.StringType, map[string]attr.Value{"X-Source": types.StringValue("horizon")})
+	configured := func(headers types.Map, profiles types.Set) func(t *testing.T) triggerResourceModel {
+		return func(t *testing.T) triggerResourceModel {
+			return triggerResourceModel{Name: types.StringValue("inventory"), Headers: headers, Profiles: profiles}
+		}
+	}
+	imported := func(t *testing.T) triggerResourceModel {
+		var data triggerResourceModel
+		if diags := importedState(t, NewTriggerResource(), "inventory").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+	bare := &models.Trigger{Name: "inventory", Events: []string{"enroll"}, Url: "https://inventory.example.com/hooks/horizon"}
+
+	tests := []struct {
+		name         string
+		prior        func(t *testing.T) triggerResourceModel
+		trigger      *models.Trigger
+		wantHeaders  types.Map
+		wantProfiles types.Set
+	}{
+		{
+			// Omitting headers or profiles does not plan a change.
+			name:         "unset headers and profiles read back as null",
+			prior:        configured(types.MapNull(types.StringType), types.SetNull(types.StringType)),
+			trigger:      bare,
+			wantHeaders:  types.MapNull(types.StringType),
+			wantProfiles: types.SetNull(types.StringType),
+		},
+		{
+			// Neither does configuring them as empty.
+			name:         "empty headers and profiles read back as empty",
+			prior:        configured(emptyMap, emptySet),
+			trigger:      bare,
+			wantHeaders:  emptyMap,
+			wantProfiles: emptySet,
+		},
+		{
+			name:         "headers and profiles emptied outside of Terraform read back as null",
+			prior:        configured(headers, profiles),
+			trigger:      bare,
+			wantHeaders:  types.MapNull(types.StringType),
+			wantProfiles: types.SetNull(types.StringType),
+		},
+		{
+			name:  "imported by name",
+			prior: imported,
+			trigger: &models.Trigger{
+				Name:     "inventory",
+				Events:   []string{"enroll"},
+				Url:      "https://inventory.example.com/hooks/horizon",
+				Method:   strPtr("PUT"),
+				Headers:  map[string]string{"X-Source": "horizon"},
+				Retries:  int32Ptr(3),
+				Profiles: []string{"WebServers"},
+			},
+			wantHeaders:  headers,
+			wantProfiles: profiles,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.trigger.Name {
+				t.Fatalf("Read looks up the trigger %q, want %q", data.Name.ValueString(), tt.trigger.Name)
+			}
+			if diags := fillTriggerModel(ctx, &data, tt.trigger); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "inventory" || data.Url.ValueString() != tt.trigger.Url {
+				t.Errorf("id %s, url %s", data.Id, data.Url)
+			}
+			if !data.Headers.Equal(tt.wantHeaders) {
+				t.Errorf("headers = %s, want %s", data.Headers, tt.wantHeaders)
+			}
+			if !data.Profiles.Equal(tt.wantProfiles) {
+				t.Errorf("profiles = %s, want %s", data.Profiles, tt.wantProfiles)
+			}
+		})
+	}
+}
+
+func TestTriggerFromModel(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name         string
+		headers      types.Map
+		profiles     types.Set
+		wantHeaders  map[string]string
+		wantProfiles []string
+	}{
+		{
+			name:     "null headers and profiles are not sent",
+			headers:  types.MapNull(types.StringType),
+			profiles: types.SetNull(types.StringType),
+		},
+		{
+			name:     "empty headers and profiles are not sent",
+			headers:  types.MapValueMust(types.StringType, nil),
+			profiles: types.SetValueMust(types.StringType, nil),
+		},
+		{
+			name:         "headers and profiles are sent",
+			headers:      types.MapValueMust(types.StringType, map[string]attr.Value{"X-Source": types.StringValue("horizon")}),
+			profiles:     types.SetValueMust(types.StringType, []attr.Value{types.StringValue("WebServers")}),
+			wantHeaders:  map[string]string{"X-Source": "horizon"},
+			wantProfiles: []string{"WebServers"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trigger, diags := triggerFromModel(ctx, triggerResourceModel{
+				Name:        types.StringValue("inventory"),
+				Description: types.StringNull(),
+				Events:      types.SetValueMust(types.StringType, []attr.Value{types.StringValue("enroll")}),
+				Url:         types.StringValue("https://inventory.example.com/hooks/horizon"),
+				Method:      types.StringValue("POST"),
+				ContentType: types.StringValue("application/json"),
+				Body:        types.StringNull(),
+				Headers:     tt.headers,
+				Retries:     types.Int64Value(0),
+				Profiles:    tt.profiles,
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if trigger.Name != "inventory" || trigger.Body != nil || trigger.Retries == nil || *trigger.Retries != 0 {
+				t.Errorf("unexpected trigger: %+v", trigger)
+			}
+			if !maps.Equal(trigger.Headers, tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", trigger.Headers, tt.wantHeaders)
+			}
+			if !slices.Equal(trigger.Profiles, tt.wantProfiles) {
+				t.Errorf("profiles = %v, want %v", trigger.Profiles, tt.wantProfiles)
+			}
+		})
+	}
+}
+
+func TestFillTriggerModelDefaults(t *testing.T) {
+	var data triggerResourceModel
+	trigger := &models.Trigger{Name: "minimal", Events: []string{"revoke"}, Url: "https://example.com"}
+	if diags := fillTriggerModel(context.Background(), &data, trigger); diags.HasError() {
+		t.Fatal(diags)
+	}
+	// Unset values read as the schema defaults, so that omitting them does
+	// not plan a change.
+	if data.Method.ValueString() != "POST" || data.ContentType.ValueString() != "application/json" || data.Retries.ValueInt64() != 0 {
+		t.Fatalf("want the defaults, got %+v", data)
+	}
+	if !data.Headers.IsNull() || !data.Profiles.IsNull() || !data.Body.IsNull() {
+		t.Fatalf("want null headers, profiles and body, got %+v", data)
+	}
+}
@@ -0,0 +1,511 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// workflowRecover is declared in retrieve_centralized_pkcs12_ephemeral_resource.go.
+const workflowMigrate = "migrate"
+
+// webraProfileWorkflows are the WebRA workflows that may require approval.
+var webraProfileWorkflows = []string{workflowEnroll, workflowRenew, workflowUpdate, workflowRevoke, workflowRecover, workflowMigrate}
+
+func NewWebRAProfileResource() resource.Resource {
+	return &WebRAProfileResource{}
+}
+
+// WebRAProfileResource manages a WebRA enrollment profile.
+type WebRAProfileResource struct {
+	client *horizon.APIClient
+}
+
+type webraProfileSubjectModel struct {
+	Type                types.String `tfsdk:"type"`
+	Mandatory           types.Bool   `tfsdk:"mandatory"`
+	EditableByRequester types.Bool   `tfsdk:"editable_by_requester"`
+	EditableByApprover  types.Bool   `tfsdk:"editable_by_approver"`
+	Regex               types.String `tfsdk:"regex"`
+	DefaultValue        types.String `tfsdk:"default_value"`
+}
+
+type webraProfileSanModel struct {
+	Type  types.String `tfsdk:"type"`
+	Min   types.Int64  `tfsdk:"min"`
+	Max   types.Int64  `tfsdk:"max"`
+	Regex types.String `tfsdk:"regex"`
+}
+
+var webraProfileSubjectObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":                  types.StringType,
+	"mandatory":             types.BoolType,
+	"editable_by_requester": types.BoolType,
+	"editable_by_approver":  types.BoolType,
+	"regex":                 types.StringType,
+	"default_value":         types.StringType,
+}}
+
+var webraProfileSanObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"type":  types.StringType,
+	"min":   types.Int64Type,
+	"max":   types.Int64Type,
+	"regex": types.StringType,
+}}
+
+type webraProfileResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Enabled          types.Bool   `tfsdk:"enabled"`
+	PkiConnector     types.String `tfsdk:"pki_connector"`
+	KeyTypes         types.Set    `tfsdk:"key_types"`
+	Centralized      types.Bool   `tfsdk:"centralized"`
+	Decentralized    types.Bool   `tfsdk:"decentralized"`
+	Escrow           types.Bool   `tfsdk:"escrow"`
+	Subject          types.List   `tfsdk:"subject"`
+	Sans             types.List   `tfsdk:"sans"`
+	MaxLifetime      types.String `tfsdk:"max_lifetime"`
+	ApprovalRequired types.Set    `tfsdk:"approval_required"`
+}
+
+func (r *WebRAProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_webra_profile"
+}
+
+func (r *WebRAProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a WebRA profile, the enrollment policy `horizon_certificate` resources reference with `profile`: " +
+			"the PKI connector issuing its certificates, the key types and enrollment modes it accepts, its subject and SAN policy, the certificate lifetime and the workflows requiring approval. " +
+			"Changes made outside of Terraform are detected on refresh.",
+		Attributes: enrollmentProfileAttributes("WebRA"),
+	}
+
+	resp.Schema.Attributes["centralized"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+		MarkdownDescription: "Whether Horizon may generate the key pair of the certificates, returned as a PKCS#12 file. Defaults to false.",
+	}
+	resp.Schema.Attributes["decentralized"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(true),
+		MarkdownDescription: "Whether certificates may be enrolled from a CSR. Defaults to true.",
+	}
+	resp.Schema.Attributes["escrow"] = schema.BoolAttribute{
+		Optional:            true,
+		Computed:            true,
+		Default:             booldefault.StaticBool(false),
+		MarkdownDescription: "Whether Horizon keeps the private keys it generates, so they can be recovered. Requires `centralized`. Defaults to false.",
+	}
+	resp.Schema.Attributes["subject"] = schema.ListNestedAttribute{
+		Optional:    true,
+		Description: "Subject elements allowed in the certificates of the profile, in DN order. Repeat a type to allow several elements of that type.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Subject element type, such as `CN`, `OU` or `O`.",
+				},
+				"mandatory": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Default:     booldefault.StaticBool(false),
+					Description: "Whether requests must set the element. Defaults to false.",
+				},
+				"editable_by_requester": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Default:     booldefault.StaticBool(true),
+					Description: "Whether requesters may set the element. Defaults to true.",
+				},
+				"editable_by_approver": schema.BoolAttribute{
+					Optional:    true,
+					Computed:    true,
+					Default:     booldefault.StaticBool(true),
+					Description: "Whether approvers may change the element. Defaults to true.",
+				},
+				"regex": schema.StringAttribute{
+					Optional:    true,
+					Description: "Regular expression values of the element must match.",
+				},
+				"default_value": schema.StringAttribute{
+					Optional:    true,
+					Description: "Value of the element when the request does not set it.",
+				},
+			},
+		},
+	}
+	resp.Schema.Attributes["sans"] = schema.ListNestedAttribute{
+		Optional:    true,
+		Description: "Subject alternative names allowed in the certificates of the profile.",
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "SAN type. Accepted values are: `RFC822NAME`, `DNSNAME`, `URI`, `IPADDRESS`, `OTHERNAME_UPN`, `OTHERNAME_GUID`",
+				},
+				"min": schema.Int64Attribute{
+					Optional:    true,
+					Computed:    true,
+					Default:     int64default.StaticInt64(0),
+					Description: "Minimum number of SANs of this type. Defaults to 0.",
+				},
+				"max": schema.Int64Attribute{
+					Optional:    true,
+					Description: "Maximum number of SANs of this type. Unlimited when unset.",
+				},
+				"regex": schema.StringAttribute{
+					Optional:    true,
+					Description: "Regular expression SANs of this type must match.",
+				},
+			},
+		},
+	}
+	resp.Schema.Attributes["approval_required"] = schema.SetAttribute{
+		Optional:            true,
+		ElementType:         types.StringType,
+		MarkdownDescription: "Workflows whose requests must be approved by an operator. Values among `enroll`, `renew`, `update`, `revoke`, `recover`, `migrate`. Requests are approved automatically when unset.",
+	}
+}
+
+func (r *WebRAProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*horizonProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *horizonProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+}
+
+func (r *WebRAProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data webraProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, diags := webraProfileFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Creating WebRA profile %s", profile.Name))
+	created, _, err := r.client.WebRAProfileAPI.WebRAProfileCreate(ctx).WebRAProfile(*profile).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create WebRA profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillWebRAProfileModel(ctx, &data, created)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebRAProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data webraProfileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, httpResp, err := r.client.WebRAProfileAPI.WebRAProfileGet(ctx, data.Name.ValueString()).Execute()
+	if err != nil {
+		if httpResp != nil && httpResp.StatusCode == http.StatusNotFound {
+			tflog.Info(ctx, fmt.Sprintf("WebRA profile %s not found in horizon; removing from state", data.Name.ValueString()))
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to get WebRA profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillWebRAProfileModel(ctx, &data, profile)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebRAProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data webraProfileResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, diags := webraProfileFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Updating WebRA profile %s", profile.Name))
+	updated, _, err := r.client.WebRAProfileAPI.WebRAProfileUpdate(ctx).WebRAProfile(*profile).Execute()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to update WebRA profile", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(fillWebRAProfileModel(ctx, &data, updated)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *WebRAProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data webraProfileResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	httpResp, err := r.client.WebRAProfileAPI.WebRAProfileDelete(ctx, data.Name.ValueString()).Execute()
+	if err != nil && (httpResp == nil || httpResp.StatusCode != http.StatusNotFound) {
+		resp.Diagnostics.AddError("Failed to delete WebRA profile", err.Error())
+	}
+}
+
+func (r *WebRAProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
+
+func (r *WebRAProfileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data webraProfileResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(validateWebRAProfile(ctx, data)...)
+}
+
+// validateWebRAProfile checks the known values of a profile configuration.
+// Unset booleans are their schema defaults.
+func validateWebRAProfile(ctx context.Context, data webraProfileResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	centralized := data.Centralized.ValueBool()
+	decentralized := data.Decentralized.IsNull() || data.Decentralized.ValueBool()
+	if !data.Centralized.IsUnknown() && !data.Decentralized.IsUnknown() && !centralized && !decentralized {
+		diags.AddAttributeError(
+			path.Root("decentralized"),
+			"No enrollment mode",
+			"At least one of centralized or decentralized must be true.",
+		)
+	}
+	if data.Escrow.ValueBool() && !data.Centralized.IsUnknown() && !centralized {
+		diags.AddAttributeError(
+			path.Root("escrow"),
+			"Escrow requires centralized enrollment",
+			"Only the private keys generated by Horizon can be escrowed: set centralized to true.",
+		)
+	}
+
+	diags.Append(validateDuration("max_lifetime", data.MaxLifetime)...)
+
+	if !data.ApprovalRequired.IsNull() && !data.ApprovalRequired.IsUnknown() {
+		var workflows []types.String
+		diags.Append(data.ApprovalRequired.ElementsAs(ctx, &workflows, false)...)
+		for _, workflow := range workflows {
+			if workflow.IsUnknown() || isWebRAProfileWorkflow(workflow.ValueString()) {
+				continue
+			}
+			diags.AddAttributeError(
+				path.Root("approval_required"),
+				"Invalid approval_required value",
+				fmt.Sprintf("%q is not a workflow. Expected one of %s.", workflow.ValueString(), strings.Join(webraProfileWorkflows, ", ")),
+			)
+		}
+	}
+
+	if !data.Sans.IsNull() && !data.Sans.IsUnknown() {
+		var sans []webraProfileSanModel
+		diags.Append(data.Sans.ElementsAs(ctx, &sans, false)...)
+		for i, san := range sans {
+			if san.Max.IsNull() || san.Max.IsUnknown() || san.Min.IsUnknown() {
+				continue
+			}
+			if san.Max.ValueInt64() < san.Min.ValueInt64() {
+				diags.AddAttributeError(
+					path.Root("sans").AtListIndex(i).AtName("max"),
+					"Invalid SAN bounds",
+					fmt.Sprintf("max (%d) must not be lower than min (%d).", san.Max.ValueInt64(), san.Min.ValueInt64()),
+				)
+			}
+		}
+	}
+	return diags
+}
+
+func isWebRAProfileWorkflow(workflow string) bool {
+	for _, w := range webraProfileWorkflows {
+		if w == workflow {
+			return true
+		}
+	}
+	return false
+}
+
+// webraProfileFromModel builds the Horizon profile described by data.
+func webraProfileFromModel(ctx context.Context, data webraProfileResourceModel) (*models.WebRAProfile, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	profile := models.NewWebRAProfileWithDefaults()
+	profile.Name = data.Name.ValueString()
+	profile.Enabled = data.Enabled.ValueBoolPointer()
+	profile.PkiConnector = data.PkiConnector.ValueStringPointer()
+	profile.MaxCertificateLifetime = horizonDuration(data.MaxLifetime)
+	profile.CryptoPolicy = &models.ProfileCryptoPolicy{
+		Centralized:   data.Centralized.ValueBoolPointer(),
+		Decentralized: data.Decentralized.ValueBoolPointer(),
+		Escrow:        data.Escrow.ValueBoolPointer(),
+	}
+	keyTypes, d := authorizedKeyTypes(ctx, data.KeyTypes)
+	diags.Append(d...)
+	profile.CryptoPolicy.AuthorizedKeyTypes = keyTypes
+	if !data.ApprovalRequired.IsNull() {
+		diags.Append(data.ApprovalRequired.ElementsAs(ctx, &profile.ApprovalRequired, false)...)
+	}
+
+	template := &models.CertificateTemplate{}
+	var subject []webraProfileSubjectModel
+	if !data.Subject.IsNull() {
+		diags.Append(data.Subject.ElementsAs(ctx, &subject, false)...)
+	}
+	for _, element := range subject {
+		template.Subject = append(template.Subject, models.SubjectElementTemplate{
+			Type:                element.Type.ValueString(),
+			Mandatory:           element.Mandatory.ValueBoolPointer(),
+			EditableByRequester: element.EditableByRequester.ValueBoolPointer(),
+			EditableByApprover:  element.EditableByApprover.ValueBoolPointer(),
+			Regex:               element.Regex.ValueStringPointer(),
+			DefaultValue:        element.DefaultValue.ValueStringPointer(),
+		})
+	}
+
+	var sans []webraProfileSanModel
+	if !data.Sans.IsNull() {
+		diags.Append(data.Sans.ElementsAs(ctx, &sans, false)...)
+	}
+	for _, san := range sans {
+		element := models.SanElementTemplate{
+			Type:  san.Type.ValueString(),
+			Regex: san.Regex.ValueStringPointer(),
+		}
+		if !san.Min.IsNull() {
+			minCount := int32(san.Min.ValueInt64())
+			element.Min = &minCount
+		}
+		if !san.Max.IsNull() {
+			maxCount := int32(san.Max.ValueInt64())
+			element.Max = &maxCount
+		}
+		template.Sans = append(template.Sans, element)
+	}
+	if len(template.Subject) > 0 || len(template.Sans) > 0 {
+		profile.CertificateTemplate = template
+	}
+
+	return profile, diags
+}
+
+// fillWebRAProfileModel sets data to the profile stored in Horizon, so that
+// changes made outside of Terraform show up in the plan.
+func fillWebRAProfileModel(ctx context.Context, data *webraProfileResourceModel, profile *models.WebRAProfile) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	data.Id = types.StringValue(profile.Name)
+	data.Name = types.StringValue(profile.Name)
+	data.Enabled = types.BoolValue(profile.Enabled == nil || *profile.Enabled)
+	data.PkiConnector = types.StringValue(profile.GetPkiConnector())
+	data.MaxLifetime = durationValue(data.MaxLifetime, profile.MaxCertificateLifetime)
+
+	cryptoPolicy := profile.CryptoPolicy
+	if cryptoPolicy == nil {
+		cryptoPolicy = &models.ProfileCryptoPolicy{}
+	}
+	data.Centralized = types.BoolValue(cryptoPolicy.Centralized != nil && *cryptoPolicy.Centralized)
+	data.Decentralized = types.BoolValue(cryptoPolicy.Decentralized == nil || *cryptoPolicy.Decentralized)
+	data.Escrow = types.BoolValue(cryptoPolicy.Escrow != nil && *cryptoPolicy.Escrow)
+	keyTypes, d := keyTypesValue(ctx, cryptoPolicy, data.KeyTypes)
+	diags.Append(d...)
+	data.KeyTypes = keyTypes
+	data.ApprovalRequired = emptySetValue(data.ApprovalRequired, types.StringType)
+	if len(profile.ApprovalRequired) > 0 {
+		workflows, d := types.SetValueFrom(ctx, types.StringType, profile.ApprovalRequired)
+		diags.Append(d...)
+		data.ApprovalRequired = workflows
+	}
+
+	template := profile.CertificateTemplate
+	if template == nil {
+		template = &models.CertificateTemplate{}
+	}
+	data.Subject = emptyListValue(data.Subject, webraProfileSubjectObjectType)
+	if len(template.Subject) > 0 {
+		subject := make([]webraProfileSubjectModel, 0, len(template.Subject))
+		for _, element := range template.Subject {
+			subject = append(subject, webraProfileSubjectModel{
+				Type:                types.StringValue(element.Type),
+				Mandatory:           types.BoolValue(element.Mandatory != nil && *element.Mandatory),
+				EditableByRequester: types.BoolValue(element.EditableByRequester == nil || *element.EditableByRequester),
+				EditableByApprover:  types.BoolValue(element.EditableByApprover == nil || *element.EditableByApprover),
+				Regex:               types.StringPointerValue(element.Regex),
+				DefaultValue:        types.StringPointerValue(element.DefaultValue),
+			})
+		}
+		list, d := types.ListValueFrom(ctx, webraProfileSubjectObjectType, subject)
+		diags.Append(d...)
+		data.Subject = list
+	}
+
+	data.Sans = emptyListValue(data.Sans, webraProfileSanObjectType)
+	if len(template.Sans) > 0 {
+		sans := make([]webraProfileSanModel, 0, len(template.Sans))
+		for _, element := range template.Sans {
+			san := webraProfileSanModel{
+				Type:  types.StringValue(element.Type),
+				Min:   types.Int64Value(0),
+				Max:   types.Int64Null(),
+				Regex: types.StringPointerValue(element.Regex),
+			}
+			if element.Min != nil {
+				san.Min = types.Int64Value(int64(*element.Min))
+			}
+			if element.Max != nil {
+				san.Max = types.Int64Value(int64(*element.Max))
+			}
+			sans = append(sans, san)
+		}
+		list, d := types.ListValueFrom(ctx, webraProfileSanObjectType, sans)
+		diags.Append(d...)
+		data.Sans = list
+	}
+
+	return diags
+}
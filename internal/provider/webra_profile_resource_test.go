@@ -0,0 +1,405 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+// importedState runs the ImportState of r for id on an empty state, as
+// `terraform import` does before reading the resource.
+func importedState(t *testing.T, r resource.Resource, id string) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	resp := &resource.ImportStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	r.(resource.ResourceWithImportState).ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("import: %v", resp.Diagnostics)
+	}
+	return resp.State
+}
+
+func TestFillWebRAProfileModel(t *testing.T) {
+	ctx := context.Background()
+	stringSet := func(values ...string) types.Set {
+		set, _ := types.SetValueFrom(ctx, types.StringType, values)
+		return set
+	}
+	emptySet := types.SetValueMust(types.StringType, nil)
+	emptySubject := types.ListValueMust(webraProfileSubjectObjectType, nil)
+	emptySans := types.ListValueMust(webraProfileSanObjectType, nil)
+	subject, _ := types.ListValueFrom(ctx, webraProfileSubjectObjectType, []webraProfileSubjectModel{{
+		Type:                types.StringValue("CN"),
+		Mandatory:           types.BoolValue(true),
+		EditableByRequester: types.BoolValue(true),
+		EditableByApprover:  types.BoolValue(true),
+		Regex:               types.StringValue(`^[a-z.]+\.example\.com$`),
+		DefaultValue:        types.StringNull(),
+	}})
+	sans, _ := types.ListValueFrom(ctx, webraProfileSanObjectType, []webraProfileSanModel{{
+		Type:  types.StringValue("DNSNAME"),
+		Min:   types.Int64Value(1),
+		Max:   types.Int64Value(10),
+		Regex: types.StringNull(),
+	}})
+
+	bare := &models.WebRAProfile{Name: "WebServers", PkiConnector: strPtr("internal-ca")}
+	stored := &models.WebRAProfile{
+		Name:                   "WebServers",
+		PkiConnector:           strPtr("internal-ca"),
+		CryptoPolicy:           &models.ProfileCryptoPolicy{AuthorizedKeyTypes: []string{"rsa-2048"}},
+		MaxCertificateLifetime: strPtr("365 days"),
+		ApprovalRequired:       []string{workflowEnroll},
+		CertificateTemplate: &models.CertificateTemplate{
+			Subject: []models.SubjectElementTemplate{{Type: "CN", Mandatory: boolPtr(true), Regex: strPtr(`^[a-z.]+\.example\.com$`)}},
+			Sans:    []models.SanElementTemplate{{Type: "DNSNAME", Min: int32Ptr(1), Max: int32Ptr(10)}},
+		},
+	}
+	configured := func(keyTypes, approvalRequired types.Set, subject, sans types.List, maxLifetime types.String) func(t *testing.T) webraProfileResourceModel {
+		return func(t *testing.T) webraProfileResourceModel {
+			return webraProfileResourceModel{
+				Name:             types.StringValue("WebServers"),
+				KeyTypes:         keyTypes,
+				ApprovalRequired: approvalRequired,
+				Subject:          subject,
+				Sans:             sans,
+				MaxLifetime:      maxLifetime,
+			}
+		}
+	}
+	imported := func(t *testing.T) webraProfileResourceModel {
+		var data webraProfileResourceModel
+		if diags := importedState(t, NewWebRAProfileResource(), "WebServers").Get(ctx, &data); diags.HasError() {
+			t.Fatal(diags)
+		}
+		return data
+	}
+	unset := configured(types.SetNull(types.StringType), types.SetNull(types.StringType), types.ListNull(webraProfileSubjectObjectType), types.ListNull(webraProfileSanObjectType), types.StringNull())
+
+	tests := []struct {
+		name                 string
+		prior                func(t *testing.T) webraProfileResourceModel
+		profile              *models.WebRAProfile
+		wantKeyTypes         types.Set
+		wantApprovalRequired types.Set
+		wantSubject          types.List
+		wantSans             types.List
+		wantMaxLifetime      types.String
+	}{
+		{
+			name:                 "unset attributes read back as null",
+			prior:                unset,
+			profile:              bare,
+			wantKeyTypes:         types.SetNull(types.StringType),
+			wantApprovalRequired: types.SetNull(types.StringType),
+			wantSubject:          types.ListNull(webraProfileSubjectObjectType),
+			wantSans:             types.ListNull(webraProfileSanObjectType),
+			wantMaxLifetime:      types.StringNull(),
+		},
+		{
+			name:                 "empty collections read back as empty",
+			prior:                configured(emptySet, emptySet, emptySubject, emptySans, types.StringNull()),
+			profile:              bare,
+			wantKeyTypes:         emptySet,
+			wantApprovalRequired: emptySet,
+			wantSubject:          emptySubject,
+			wantSans:             emptySans,
+			wantMaxLifetime:      types.StringNull(),
+		},
+		{
+			name:                 "collections emptied outside of Terraform read back as null",
+			prior:                configured(stringSet("rsa-2048"), stringSet(workflowEnroll), subject, sans, types.StringValue("P1Y")),
+			profile:              bare,
+			wantKeyTypes:         types.SetNull(types.StringType),
+			wantApprovalRequired: types.SetNull(types.StringType),
+			wantSubject:          types.ListNull(webraProfileSubjectObjectType),
+			wantSans:             types.ListNull(webraProfileSanObjectType),
+			wantMaxLifetime:      types.StringNull(),
+		},
+		{
+			name:                 "configured duration notation is preserved",
+			prior:                configured(emptySet, emptySet, emptySubject, emptySans, types.StringValue("P1Y")),
+			profile:              stored,
+			wantKeyTypes:         stringSet("rsa-2048"),
+			wantApprovalRequired: stringSet(workflowEnroll),
+			wantSubject:          subject,
+			wantSans:             sans,
+			wantMaxLifetime:      types.StringValue("P1Y"),
+		},
+		{
+			name:                 "imported by name",
+			prior:                imported,
+			profile:              stored,
+			wantKeyTypes:         stringSet("rsa-2048"),
+			wantApprovalRequired: stringSet(workflowEnroll),
+			wantSubject:          subject,
+			wantSans:             sans,
+			wantMaxLifetime:      types.StringValue("365 days"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := tt.prior(t)
+			if data.Name.ValueString() != tt.profile.Name {
+				t.Fatalf("Read looks up the profile %q, want %q", data.Name.ValueString(), tt.profile.Name)
+			}
+			if diags := fillWebRAProfileModel(ctx, &data, tt.profile); diags.HasError() {
+				t.Fatal(diags)
+			}
+			if data.Id.ValueString() != "WebServers" || data.PkiConnector.ValueString() != "internal-ca" {
+				t.Errorf("id %s, pki_connector %s", data.Id, data.PkiConnector)
+			}
+			for _, check := range []struct {
+				attribute string
+				got, want attr.Value
+			}{
+				{"key_types", data.KeyTypes, tt.wantKeyTypes},
+				{"approval_required", data.ApprovalRequired, tt.wantApprovalRequired},
+				{"subject", data.Subject, tt.wantSubject},
+				{"sans", data.Sans, tt.wantSans},
+				{"max_lifetime", data.MaxLifetime, tt.wantMaxLifetime},
+			} {
+				if !check.got.Equal(check.want) {
+					t.Errorf("%s = %s, want %s", check.attribute, check.got, check.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWebRAProfileFromModel(t *testing.T) {
+	ctx := context.Background()
+	subject, _ := types.ListValueFrom(ctx, webraProfileSubjectObjectType, []webraProfileSubjectModel{{
+		Type:                types.StringValue("CN"),
+		Mandatory:           types.BoolValue(true),
+		EditableByRequester: types.BoolValue(true),
+		EditableByApprover:  types.BoolValue(false),
+		Regex:               types.StringNull(),
+		DefaultValue:        types.StringNull(),
+	}})
+	model := func(keyTypes, approvalRequired types.Set, subject, sans types.List) webraProfileResourceModel {
+		return webraProfileResourceModel{
+			Name:             types.StringValue("WebServers"),
+			PkiConnector:     types.StringValue("internal-ca"),
+			Centralized:      types.BoolValue(true),
+			Decentralized:    types.BoolValue(false),
+			Escrow:           types.BoolValue(true),
+			MaxLifetime:      types.StringNull(),
+			KeyTypes:         keyTypes,
+			ApprovalRequired: approvalRequired,
+			Subject:          subject,
+			Sans:             sans,
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		data                 webraProfileResourceModel
+		wantKeyTypes         []string
+		wantApprovalRequired []string
+		wantSubjectTypes     []string
+	}{
+		{
+			name: "null collections are not sent",
+			data: model(types.SetNull(types.StringType), types.SetNull(types.StringType), types.ListNull(webraProfileSubjectObjectType), types.ListNull(webraProfileSanObjectType)),
+		},
+		{
+			name: "empty collections are not sent",
+			data: model(types.SetValueMust(types.StringType, nil), types.SetValueMust(types.StringType, nil), types.ListValueMust(webraProfileSubjectObjectType, nil), types.ListValueMust(webraProfileSanObjectType, nil)),
+		},
+		{
+			name: "configured collections are sent",
+			data: model(
+				types.SetValueMust(types.StringType, []attr.Value{types.StringValue("rsa-2048")}),
+				types.SetValueMust(types.StringType, []attr.Value{types.StringValue(workflowRevoke)}),
+				subject,
+				types.ListNull(webraProfileSanObjectType),
+			),
+			wantKeyTypes:         []string{"rsa-2048"},
+			wantApprovalRequired: []string{workflowRevoke},
+			wantSubjectTypes:     []string{"CN"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, diags := webraProfileFromModel(ctx, tt.data)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			policy := profile.CryptoPolicy
+			if profile.GetPkiConnector() != "internal-ca" || !*policy.Centralized || *policy.Decentralized || !*policy.Escrow {
+				t.Errorf("unexpected profile: %+v, crypto policy %+v", profile, policy)
+			}
+			if !slices.Equal(policy.AuthorizedKeyTypes, tt.wantKeyTypes) {
+				t.Errorf("key types = %v, want %v", policy.AuthorizedKeyTypes, tt.wantKeyTypes)
+			}
+			if !slices.Equal(profile.ApprovalRequired, tt.wantApprovalRequired) {
+				t.Errorf("approval required = %v, want %v", profile.ApprovalRequired, tt.wantApprovalRequired)
+			}
+			if len(tt.wantSubjectTypes) == 0 {
+				if profile.CertificateTemplate != nil {
+					t.Errorf("want no certificate template, got %+v", profile.CertificateTemplate)
+				}
+				return
+			}
+			if profile.CertificateTemplate == nil || len(profile.CertificateTemplate.Subject) != 1 || profile.CertificateTemplate.Subject[0].Type != tt.wantSubjectTypes[0] {
+				t.Errorf("certificate template = %+v, want subject %v", profile.CertificateTemplate, tt.wantSubjectTypes)
+			}
+		})
+	}
+}
+
+func TestFillWebRAProfileModelDefaults(t *testing.T) {
+	var data webraProfileResourceModel
+	diags := fillWebRAProfileModel(context.Background(), &data, &models.WebRAProfile{
+		Name:         "Minimal",
+		PkiConnector: strPtr("internal-ca"),
+		CertificateTemplate: &models.CertificateTemplate{
+			Sans: []models.SanElementTemplate{{Type: "DNSNAME"}},
+		},
+	})
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+
+	// Attributes Horizon leaves unset must read as their schema defaults, or
+	// every plan would show a diff.
+	if !data.Enabled.ValueBool() || data.Centralized.ValueBool() || !data.Decentralized.ValueBool() || data.Escrow.ValueBool() {
+		t.Fatalf("unexpected defaults: %+v", data)
+	}
+	if !data.KeyTypes.IsNull() || !data.Subject.IsNull() || !data.ApprovalRequired.IsNull() || !data.MaxLifetime.IsNull() {
+		t.Fatalf("want unset attributes to be null: %+v", data)
+	}
+	var sans []webraProfileSanModel
+	data.Sans.ElementsAs(context.Background(), &sans, false)
+	if sans[0].Min.ValueInt64() != 0 || !sans[0].Max.IsNull() || !sans[0].Regex.IsNull() {
+		t.Fatalf("unexpected SAN defaults: %+v", sans[0])
+	}
+}
+
+func TestWebRAProfileLifetimeNotation(t *testing.T) {
+	ctx := context.Background()
+	data := webraProfileResourceModel{
+		Name:             types.StringValue("p"),
+		MaxLifetime:      types.StringValue("P1Y"),
+		KeyTypes:         types.SetNull(types.StringType),
+		ApprovalRequired: types.SetNull(types.StringType),
+		Subject:          types.ListNull(webraProfileSubjectObjectType),
+		Sans:             types.ListNull(webraProfileSanObjectType),
+	}
+	profile, diags := webraProfileFromModel(ctx, data)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := *profile.MaxCertificateLifetime; got != "365 days" {
+		t.Fatalf("want the lifetime sent as 365 days, got %s", got)
+	}
+	if diags := fillWebRAProfileModel(ctx, &data, profile); diags.HasError() {
+		t.Fatal(diags)
+	}
+	if got := data.MaxLifetime.ValueString(); got != "P1Y" {
+		t.Fatalf("want the configured notation kept, got %s", got)
+	}
+}
+
+func TestValidateWebRAProfile(t *testing.T) {
+	ctx := context.Background()
+	base := func() webraProfileResourceModel {
+		return webraProfileResourceModel{
+			Name:             types.StringValue("p"),
+			Centralized:      types.BoolNull(),
+			Decentralized:    types.BoolNull(),
+			Escrow:           types.BoolNull(),
+			MaxLifetime:      types.StringNull(),
+			ApprovalRequired: types.SetNull(types.StringType),
+			Sans:             types.ListNull(webraProfileSanObjectType),
+		}
+	}
+	sans := func(min, max int64) types.List {
+		list, _ := types.ListValueFrom(ctx, webraProfileSanObjectType, []webraProfileSanModel{{
+			Type:  types.StringValue("DNSNAME"),
+			Min:   types.Int64Value(min),
+			Max:   types.Int64Value(max),
+			Regex: types.StringNull(),
+		}})
+		return list
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*webraProfileResourceModel)
+		wantErr bool
+	}{
+		{name: "defaults", mutate: func(d *webraProfileResourceModel) {}},
+		{name: "no enrollment mode", mutate: func(d *webraProfileResourceModel) {
+			d.Decentralized = types.BoolValue(false)
+		}, wantErr: true},
+		{name: "unknown enrollment mode", mutate: func(d *webraProfileResourceModel) {
+			d.Decentralized = types.BoolValue(false)
+			d.Centralized = types.BoolUnknown()
+		}},
+		{name: "escrow without centralized", mutate: func(d *webraProfileResourceModel) {
+			d.Escrow = types.BoolValue(true)
+		}, wantErr: true},
+		{name: "escrow with centralized", mutate: func(d *webraProfileResourceModel) {
+			d.Escrow = types.BoolValue(true)
+			d.Centralized = types.BoolValue(true)
+		}},
+		{name: "lifetime", mutate: func(d *webraProfileResourceModel) {
+			d.MaxLifetime = types.StringValue("P1Y6M")
+		}},
+		{name: "lifetime with time", mutate: func(d *webraProfileResourceModel) {
+			d.MaxLifetime = types.StringValue("P1DT12H")
+		}},
+		{name: "Horizon lifetime", mutate: func(d *webraProfileResourceModel) {
+			d.MaxLifetime = types.StringValue("365 days")
+		}},
+		{name: "invalid lifetime", mutate: func(d *webraProfileResourceModel) {
+			d.MaxLifetime = types.StringValue("one year")
+		}, wantErr: true},
+		{name: "empty lifetime", mutate: func(d *webraProfileResourceModel) {
+			d.MaxLifetime = types.StringValue("P")
+		}, wantErr: true},
+		{name: "approval workflows", mutate: func(d *webraProfileResourceModel) {
+			d.ApprovalRequired, _ = types.SetValueFrom(ctx, types.StringType, []string{"enroll", "recover"})
+		}},
+		{name: "unknown approval workflow", mutate: func(d *webraProfileResourceModel) {
+			d.ApprovalRequired, _ = types.SetValueFrom(ctx, types.StringType, []string{"enrol"})
+		}, wantErr: true},
+		{name: "SAN bounds", mutate: func(d *webraProfileResourceModel) {
+			d.Sans = sans(1, 1)
+		}},
+		{name: "SAN max below min", mutate: func(d *webraProfileResourceModel) {
+			d.Sans = sans(2, 1)
+		}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := base()
+			tt.mutate(&data)
+			diags := validateWebRAProfile(ctx, data)
+			if diags.HasError() != tt.wantErr {
+				t.Fatalf("wantErr=%v, got %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccAcmeProfileConfig(challengeTypes string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_acme_profile" "test" {
+  name          = "tf-acc-acme"
+  pki_connector = %q
+  key_types     = ["ec-secp256r1"]
+  max_lifetime  = "90 days"
+
+  challenge_types        = %s
+  authorization_validity = "30 days"
+}
+`, testAccPkiConnector(), challengeTypes)
+}
+
+func TestAccAcmeProfile(t *testing.T) {
+	if testAccPkiConnector() == "" {
+		t.Skip("HORIZON_PKI_CONNECTOR not set; skipping ACME profile test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_acme_profile", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.AcmeProfileAPI.AcmeProfileGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAcmeProfileConfig(`["http-01"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_acme_profile.test", "id", "tf-acc-acme"),
+					resource.TestCheckResourceAttr("horizon_acme_profile.test", "max_lifetime", "90 days"),
+					resource.TestCheckResourceAttr("horizon_acme_profile.test", "challenge_types.#", "1"),
+				),
+			},
+			{
+				Config: testAccAcmeProfileConfig(`["http-01", "dns-01"]`),
+				Check:  resource.TestCheckResourceAttr("horizon_acme_profile.test", "challenge_types.#", "2"),
+			},
+			{
+				ResourceName:      "horizon_acme_profile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
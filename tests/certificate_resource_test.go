@@ -156,10 +156,15 @@ func TestAccCertificate_ImportState(t *testing.T) {
 					"profile",
 					"key_type",
 					"renew_before",
+					"rotate_key_on_renew",
 					"revoke_on_delete",
 					"subject",
 					"sans",
 					"labels",
+					"effective_labels",
+					"effective_owner",
+					"effective_team",
+					"effective_contact_email",
 					"owner",
 					"team",
 					"contact_email",
@@ -0,0 +1,61 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccDiscoveryCampaignConfig builds a campaign that never ran, and lists
+// its certificates.
+func testAccDiscoveryCampaignConfig(ports string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_discovery_campaign" "test" {
+  name        = "tf-acc-discovery"
+  description = "Terraform acceptance"
+
+  hosts   = ["127.0.0.1"]
+  ports   = %s
+  timeout = "5 seconds"
+}
+
+data "horizon_discovered_certificates" "test" {
+  campaign = horizon_discovery_campaign.test.name
+}
+`, ports)
+}
+
+func TestAccDiscoveryCampaign(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_discovery_campaign", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.DiscoveryCampaignAPI.DiscoveryCampaignGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDiscoveryCampaignConfig("[443]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_discovery_campaign.test", "id", "tf-acc-discovery"),
+					resource.TestCheckResourceAttr("horizon_discovery_campaign.test", "timeout", "5 seconds"),
+					resource.TestCheckResourceAttr("horizon_discovery_campaign.test", "ports.#", "1"),
+					resource.TestCheckResourceAttr("data.horizon_discovered_certificates.test", "ids.#", "0"),
+				),
+			},
+			{
+				Config: testAccDiscoveryCampaignConfig("[443, 8443]"),
+				Check:  resource.TestCheckResourceAttr("horizon_discovery_campaign.test", "ports.#", "2"),
+			},
+			{
+				ResourceName:      "horizon_discovery_campaign.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -11,4 +11,6 @@ const (
 
 	CentralizedProfile   = "webra-centralized"
 	DecentralizedProfile = "webra-decentralized"
+
+	PkiConnector = "integrated-clientserver"
 )
@@ -108,6 +108,7 @@ func (s *E2ESuite) SetupSuite() {
 		"TF_VAR_password="+AdminPassword,
 		"TF_VAR_centralized_profile="+CentralizedProfile,
 		"TF_VAR_decentralized_profile="+DecentralizedProfile,
+		"TF_VAR_pki_connector="+PkiConnector,
 	)
 
 	var initOut bytes.Buffer
@@ -195,6 +196,46 @@ func (s *E2ESuite) TestTrustChain() {
 	s.runTftestFile("certificate_trust_chain.tftest.hcl")
 }
 
+func (s *E2ESuite) TestWebRAProfile() {
+	s.runTftestFile("webra_profile.tftest.hcl")
+}
+
+func (s *E2ESuite) TestLabel() {
+	s.runTftestFile("label.tftest.hcl")
+}
+
+func (s *E2ESuite) TestTeam() {
+	s.runTftestFile("team.tftest.hcl")
+}
+
+func (s *E2ESuite) TestThirdPartyConnector() {
+	s.runTftestFile("third_party_connector.tftest.hcl")
+}
+
+func (s *E2ESuite) TestNotificationTrigger() {
+	s.runTftestFile("notification_trigger.tftest.hcl")
+}
+
+func (s *E2ESuite) TestAccess() {
+	s.runTftestFile("access.tftest.hcl")
+}
+
+func (s *E2ESuite) TestPkiConnector() {
+	s.runTftestFile("pki_connector.tftest.hcl")
+}
+
+func (s *E2ESuite) TestProtocolProfiles() {
+	s.runTftestFile("protocol_profiles.tftest.hcl")
+}
+
+func (s *E2ESuite) TestDiscovery() {
+	s.runTftestFile("discovery.tftest.hcl")
+}
+
+func (s *E2ESuite) TestGrading() {
+	s.runTftestFile("grading.tftest.hcl")
+}
+
 func (s *E2ESuite) TestAcceptance() {
 	t := s.T()
 	acceptanceDir := filepath.Join(s.repoRoot, "tests")
@@ -210,6 +251,7 @@ func (s *E2ESuite) TestAcceptance() {
 		"HORIZON_PROFILE="+CentralizedProfile,
 		"HORIZON_DECENTRALIZED_PROFILE="+DecentralizedProfile,
 		"HORIZON_ESCROW_PROFILE="+CentralizedProfile,
+		"HORIZON_PKI_CONNECTOR="+PkiConnector,
 	)
 
 	t.Log("running acceptance tests under ./tests/ (go test -json)")
@@ -0,0 +1,115 @@
+# Access management: a local account granted a role on the centralized
+# profile through a role binding. Everything is destroyed when terraform test
+# cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+variable "centralized_profile" { type = string }
+
+run "grant_role" {
+  command = apply
+
+  module {
+    source = "./modules/access"
+  }
+
+  variables {
+    endpoint         = var.endpoint
+    username         = var.username
+    password         = var.password
+    profile          = var.centralized_profile
+    identifier       = "tf-e2e-pipeline"
+    account_password = "tf-e2e-first-password"
+    password_version = 1
+    actions          = ["search"]
+  }
+
+  assert {
+    condition     = horizon_local_account.pipeline.id == "tf-e2e-pipeline"
+    error_message = "account id must be its identifier"
+  }
+  assert {
+    condition     = horizon_local_account.pipeline.enabled
+    error_message = "account must be enabled by default"
+  }
+  assert {
+    condition     = horizon_role_binding.pipeline.id == "tf-e2e-pipeline"
+    error_message = "binding id must be the principal when it applies to all identity providers"
+  }
+  assert {
+    condition     = contains(horizon_role_binding.pipeline.roles, "tf-e2e-pipeline-role")
+    error_message = "binding must grant the role"
+  }
+}
+
+# Widening the role and renaming the account are in-place updates. The
+# password is not sent again since password_version does not change.
+run "update_in_place" {
+  command = apply
+
+  module {
+    source = "./modules/access"
+  }
+
+  variables {
+    endpoint         = var.endpoint
+    username         = var.username
+    password         = var.password
+    profile          = var.centralized_profile
+    identifier       = "tf-e2e-pipeline"
+    account_name     = "Pipeline"
+    account_password = "tf-e2e-first-password"
+    password_version = 1
+    actions          = ["enroll", "renew", "search"]
+  }
+
+  assert {
+    condition     = horizon_local_account.pipeline.id == run.grant_role.account_id
+    error_message = "renaming the account must not replace it"
+  }
+  assert {
+    condition     = horizon_role.pipeline.id == run.grant_role.role_id
+    error_message = "updating the permissions must not replace the role"
+  }
+  assert {
+    condition     = horizon_role_binding.pipeline.id == run.grant_role.binding_id
+    error_message = "updating the role must not replace the binding"
+  }
+  assert {
+    condition     = length(one(horizon_role.pipeline.permissions).actions) == 3
+    error_message = "role actions must reflect the update"
+  }
+}
+
+run "rotate_password" {
+  command = apply
+
+  module {
+    source = "./modules/access"
+  }
+
+  variables {
+    endpoint         = var.endpoint
+    username         = var.username
+    password         = var.password
+    profile          = var.centralized_profile
+    identifier       = "tf-e2e-pipeline"
+    account_name     = "Pipeline"
+    account_password = "tf-e2e-second-password"
+    password_version = 2
+    actions          = ["enroll", "renew", "search"]
+  }
+
+  assert {
+    condition     = horizon_local_account.pipeline.id == run.grant_role.account_id
+    error_message = "rotating the password must not replace the account"
+  }
+  assert {
+    condition     = horizon_local_account.pipeline.password_version == 2
+    error_message = "password_version must reflect the rotation"
+  }
+}
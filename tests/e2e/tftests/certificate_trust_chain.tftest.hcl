@@ -112,4 +112,41 @@ run "trust_chain_default_and_orders" {
     )
     error_message = "issuer_*_to_* must return the same number of certificates"
   }
+
+  # --- certificate_id input and include_root = false ---
+  assert {
+    condition = (
+      data.horizon_certificate_trust_chain.by_id_without_root.certificate_pem ==
+      horizon_certificate.test.certificate
+    )
+    error_message = "certificate_id must resolve to the certificate PEM"
+  }
+  assert {
+    condition = (
+      data.horizon_certificate_trust_chain.by_id_without_root.length ==
+      data.horizon_certificate_trust_chain.leaf_to_root.length - 1
+    )
+    error_message = "include_root = false must drop exactly the root from the chain"
+  }
+  assert {
+    condition = (
+      data.horizon_certificate_trust_chain.by_id_without_root.root_pem ==
+      data.horizon_certificate_trust_chain.root_to_leaf.chain[0]
+    )
+    error_message = "root_pem must be the first certificate of the root_to_leaf chain"
+  }
+  assert {
+    condition = (
+      data.horizon_certificate_trust_chain.by_id_without_root.leaf_pem ==
+      horizon_certificate.test.certificate
+    )
+    error_message = "leaf_pem must be the certificate"
+  }
+  assert {
+    condition = (
+      data.horizon_certificate_trust_chain.by_id_without_root.valid_until <=
+      horizon_certificate.test.not_after
+    )
+    error_message = "valid_until must not be later than the certificate expiration"
+  }
 }
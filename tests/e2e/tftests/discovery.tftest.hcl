@@ -0,0 +1,69 @@
+# Discovery campaign lifecycle. The campaign never runs, so
+# horizon_discovered_certificates finds nothing for it. The campaign is
+# destroyed when terraform test cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+run "create_campaign" {
+  command = apply
+
+  module {
+    source = "./modules/discovery"
+  }
+
+  variables {
+    endpoint     = var.endpoint
+    username     = var.username
+    password     = var.password
+    name         = "tf-e2e-discovery"
+    hosts        = ["127.0.0.1"]
+    ports        = [443]
+    scan_timeout = "5 seconds"
+  }
+
+  assert {
+    condition     = horizon_discovery_campaign.test.id == "tf-e2e-discovery"
+    error_message = "id must be the campaign name"
+  }
+  assert {
+    condition     = horizon_discovery_campaign.test.timeout == "5 seconds"
+    error_message = "timeout must keep the configured notation"
+  }
+  assert {
+    condition     = length(data.horizon_discovered_certificates.test.ids) == 0
+    error_message = "a campaign that never ran must have no discovered certificate"
+  }
+}
+
+run "update_campaign" {
+  command = apply
+
+  module {
+    source = "./modules/discovery"
+  }
+
+  variables {
+    endpoint     = var.endpoint
+    username     = var.username
+    password     = var.password
+    name         = "tf-e2e-discovery"
+    description  = "Terraform e2e"
+    hosts        = ["127.0.0.1", "localhost"]
+    ports        = [443, 8443]
+    scan_timeout = "PT10S"
+  }
+
+  assert {
+    condition     = horizon_discovery_campaign.test.id == run.create_campaign.id
+    error_message = "updating the campaign must not replace it"
+  }
+  assert {
+    condition     = length(horizon_discovery_campaign.test.hosts) == 2 && length(horizon_discovery_campaign.test.ports) == 2
+    error_message = "hosts and ports must reflect the update"
+  }
+}
@@ -0,0 +1,69 @@
+# Grading rules and the policy applying them to the WebRA certificates:
+# create, then update in place. Everything is destroyed when terraform test
+# cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+run "create_policy" {
+  command = apply
+
+  module {
+    source = "./modules/grading"
+  }
+
+  variables {
+    endpoint          = var.endpoint
+    username          = var.username
+    password          = var.password
+    name              = "tf-e2e"
+    weak_rsa_severity = "high"
+  }
+
+  assert {
+    condition     = horizon_grading_policy.test.id == "tf-e2e-policy"
+    error_message = "policy id must be its name"
+  }
+  assert {
+    condition     = length(horizon_grading_policy.test.rules) == 1
+    error_message = "policy must apply the weak RSA rule only"
+  }
+  assert {
+    condition     = horizon_grading_rule.sha1.expression != ""
+    error_message = "the rule built with provider::horizon::hrql must have an expression"
+  }
+}
+
+run "update_policy" {
+  command = apply
+
+  module {
+    source = "./modules/grading"
+  }
+
+  variables {
+    endpoint          = var.endpoint
+    username          = var.username
+    password          = var.password
+    name              = "tf-e2e"
+    weak_rsa_severity = "critical"
+    with_sha1         = true
+  }
+
+  assert {
+    condition     = horizon_grading_policy.test.id == run.create_policy.policy_id
+    error_message = "updating the policy must not replace it"
+  }
+  assert {
+    condition     = length(horizon_grading_policy.test.rules) == 2
+    error_message = "policy rules must reflect the update"
+  }
+  assert {
+    condition     = horizon_grading_rule.weak_rsa.severity == "critical"
+    error_message = "rule severity must reflect the update"
+  }
+}
@@ -0,0 +1,74 @@
+# Label definitions: create, list through horizon_labels, update in place and
+# switch from allowed values to a regex. The label is destroyed when
+# terraform test cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+run "create_label" {
+  command = apply
+
+  module {
+    source = "./modules/label"
+  }
+
+  variables {
+    endpoint       = var.endpoint
+    username       = var.username
+    password       = var.password
+    name           = "tf-e2e-env"
+    display_name   = "Environment"
+    allowed_values = ["dev", "prod"]
+  }
+
+  assert {
+    condition     = horizon_label.test.id == "tf-e2e-env"
+    error_message = "id must be the label name"
+  }
+  assert {
+    condition     = horizon_label.test.mandatory == false
+    error_message = "mandatory must default to false"
+  }
+  assert {
+    condition     = length(output.listed) == 1
+    error_message = "horizon_labels must list the new label"
+  }
+  assert {
+    condition     = output.listed[0].display_name == "Environment"
+    error_message = "horizon_labels must list the display name of the label"
+  }
+}
+
+run "switch_to_regex" {
+  command = apply
+
+  module {
+    source = "./modules/label"
+  }
+
+  variables {
+    endpoint     = var.endpoint
+    username     = var.username
+    password     = var.password
+    name         = "tf-e2e-env"
+    display_name = "Deployment environment"
+    regex        = "[a-z]+"
+  }
+
+  assert {
+    condition     = horizon_label.test.id == run.create_label.id
+    error_message = "updating the label must not replace it"
+  }
+  assert {
+    condition     = horizon_label.test.allowed_values == null
+    error_message = "allowed_values must be cleared"
+  }
+  assert {
+    condition     = horizon_label.test.regex == "[a-z]+"
+    error_message = "regex must reflect the update"
+  }
+}
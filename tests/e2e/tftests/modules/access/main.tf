@@ -0,0 +1,53 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+resource "horizon_local_account" "pipeline" {
+  identifier = var.identifier
+  name       = var.account_name
+  email      = "pipeline@example.com"
+
+  password         = var.account_password
+  password_version = var.password_version
+}
+
+resource "horizon_role" "pipeline" {
+  name        = "${var.identifier}-role"
+  description = "Enrolls the certificates of the pipeline"
+
+  permissions = [
+    {
+      scope   = "lifecycle"
+      profile = var.profile
+      actions = var.actions
+    }
+  ]
+}
+
+resource "horizon_role_binding" "pipeline" {
+  principal         = horizon_local_account.pipeline.identifier
+  identity_provider = var.identity_provider
+  roles             = [horizon_role.pipeline.name]
+}
+
+output "account_id" {
+  value = horizon_local_account.pipeline.id
+}
+
+output "role_id" {
+  value = horizon_role.pipeline.id
+}
+
+output "binding_id" {
+  value = horizon_role_binding.pipeline.id
+}
@@ -0,0 +1,45 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "profile" {
+  type = string
+}
+
+variable "identifier" {
+  type = string
+}
+
+variable "account_name" {
+  type    = string
+  default = null
+}
+
+variable "account_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+variable "password_version" {
+  type    = number
+  default = null
+}
+
+variable "actions" {
+  type = set(string)
+}
+
+variable "identity_provider" {
+  type    = string
+  default = null
+}
@@ -0,0 +1,31 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+resource "horizon_discovery_campaign" "test" {
+  name        = var.name
+  description = var.description
+
+  hosts   = var.hosts
+  ports   = var.ports
+  timeout = var.scan_timeout
+}
+
+data "horizon_discovered_certificates" "test" {
+  campaign       = horizon_discovery_campaign.test.name
+  unmanaged_only = true
+}
+
+output "id" {
+  value = horizon_discovery_campaign.test.id
+}
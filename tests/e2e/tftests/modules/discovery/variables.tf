@@ -0,0 +1,34 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "name" {
+  type = string
+}
+
+variable "description" {
+  type    = string
+  default = null
+}
+
+variable "hosts" {
+  type = set(string)
+}
+
+variable "ports" {
+  type = set(number)
+}
+
+variable "scan_timeout" {
+  type    = string
+  default = null
+}
@@ -0,0 +1,42 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+resource "horizon_grading_rule" "weak_rsa" {
+  name        = "${var.name}-weak-rsa"
+  description = "RSA keys are at least 2048 bits long"
+  expression  = "keytype in [\"rsa-512\", \"rsa-1024\"]"
+  severity    = var.weak_rsa_severity
+}
+
+resource "horizon_grading_rule" "sha1" {
+  name        = "${var.name}-sha1"
+  description = "SHA-1 signatures are forbidden"
+  expression  = provider::horizon::hrql({ field = "signingalgorithm", in = ["SHA1WithRSA", "SHA1WithECDSA"] })
+  severity    = "high"
+}
+
+resource "horizon_grading_policy" "test" {
+  name        = "${var.name}-policy"
+  description = "Terraform e2e"
+  scope       = "module equals \"webra\""
+
+  rules = var.with_sha1 ? [
+    horizon_grading_rule.weak_rsa.name,
+    horizon_grading_rule.sha1.name,
+  ] : [horizon_grading_rule.weak_rsa.name]
+}
+
+output "policy_id" {
+  value = horizon_grading_policy.test.id
+}
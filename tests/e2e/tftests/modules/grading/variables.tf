@@ -0,0 +1,25 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "name" {
+  type = string
+}
+
+variable "weak_rsa_severity" {
+  type = string
+}
+
+variable "with_sha1" {
+  type    = bool
+  default = false
+}
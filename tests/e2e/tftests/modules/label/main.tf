@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+# Never mandatory: the enrollments of the other test files would be rejected
+# while the label exists.
+resource "horizon_label" "test" {
+  name           = var.name
+  display_name   = var.display_name
+  allowed_values = var.allowed_values
+  regex          = var.regex
+}
+
+data "horizon_labels" "all" {
+  depends_on = [horizon_label.test]
+}
+
+output "id" {
+  value = horizon_label.test.id
+}
+
+output "listed" {
+  value = [for label in data.horizon_labels.all.labels : label if label.name == var.name]
+}
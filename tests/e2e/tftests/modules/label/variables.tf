@@ -0,0 +1,31 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "name" {
+  type = string
+}
+
+variable "display_name" {
+  type    = string
+  default = null
+}
+
+variable "allowed_values" {
+  type    = set(string)
+  default = null
+}
+
+variable "regex" {
+  type    = string
+  default = null
+}
@@ -0,0 +1,46 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+resource "horizon_notification" "test" {
+  name       = "${var.name}-notification"
+  events     = ["expire"]
+  recipients = var.recipients
+
+  subject = "Certificate {{certificate.subject}} expires soon"
+  body    = "The certificate {{certificate.subject}} expires on {{certificate.notAfter}}."
+
+  days_before_expiration = var.days_before_expiration
+  profiles               = [var.profile]
+}
+
+resource "horizon_trigger" "test" {
+  name   = "${var.name}-trigger"
+  events = var.trigger_events
+  url    = "https://hooks.example.com/horizon"
+
+  headers = {
+    "X-Source" = "terraform"
+  }
+  retries = var.retries
+
+  profiles = [var.profile]
+}
+
+output "notification_id" {
+  value = horizon_notification.test.id
+}
+
+output "trigger_id" {
+  value = horizon_trigger.test.id
+}
@@ -0,0 +1,37 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "profile" {
+  type = string
+}
+
+variable "name" {
+  type = string
+}
+
+variable "recipients" {
+  type = set(string)
+}
+
+variable "days_before_expiration" {
+  type = set(number)
+}
+
+variable "trigger_events" {
+  type = set(string)
+}
+
+variable "retries" {
+  type    = number
+  default = null
+}
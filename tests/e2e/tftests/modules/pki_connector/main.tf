@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+# The write-only password is only sent to Horizon when credentials_version
+# changes.
+resource "horizon_adcs_pki_connector" "test" {
+  name                = var.name
+  description         = var.description
+  host                = "adcs.example.com"
+  ca_name             = "Example Issuing CA"
+  template            = var.template
+  username            = "EXAMPLE\\horizon"
+  password            = var.adcs_password
+  credentials_version = var.credentials_version
+}
+
+output "id" {
+  value = horizon_adcs_pki_connector.test.id
+}
@@ -0,0 +1,36 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "name" {
+  type = string
+}
+
+variable "description" {
+  type    = string
+  default = null
+}
+
+variable "template" {
+  type = string
+}
+
+variable "adcs_password" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+variable "credentials_version" {
+  type    = number
+  default = null
+}
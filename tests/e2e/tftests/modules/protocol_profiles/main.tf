@@ -0,0 +1,56 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+resource "horizon_acme_profile" "test" {
+  name          = "${var.name}-acme"
+  pki_connector = var.pki_connector
+  key_types     = ["ec-secp256r1", "rsa-2048"]
+  max_lifetime  = var.max_lifetime
+
+  challenge_types = ["http-01"]
+}
+
+resource "horizon_est_profile" "test" {
+  name          = "${var.name}-est"
+  pki_connector = var.pki_connector
+  key_types     = ["rsa-2048"]
+  max_lifetime  = var.max_lifetime
+
+  authorization_mode = "challenge"
+  challenge_lifetime = "1 hour"
+}
+
+# The write-only static challenge is only sent to Horizon when
+# static_challenge_version changes.
+resource "horizon_scep_profile" "test" {
+  name          = "${var.name}-scep"
+  pki_connector = var.pki_connector
+  max_lifetime  = var.max_lifetime
+
+  authorization_mode       = "static_challenge"
+  static_challenge         = var.static_challenge
+  static_challenge_version = var.static_challenge_version
+}
+
+output "acme_id" {
+  value = horizon_acme_profile.test.id
+}
+
+output "est_id" {
+  value = horizon_est_profile.test.id
+}
+
+output "scep_id" {
+  value = horizon_scep_profile.test.id
+}
@@ -0,0 +1,35 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "pki_connector" {
+  type = string
+}
+
+variable "name" {
+  type = string
+}
+
+variable "max_lifetime" {
+  type = string
+}
+
+variable "static_challenge" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+variable "static_challenge_version" {
+  type    = number
+  default = null
+}
@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+resource "horizon_team" "test" {
+  name          = var.name
+  display_name  = var.display_name
+  contact_email = var.contact_email
+  managers      = var.managers
+  members       = var.members
+}
+
+data "horizon_team" "test" {
+  name = horizon_team.test.name
+}
+
+output "id" {
+  value = horizon_team.test.id
+}
@@ -0,0 +1,36 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "name" {
+  type = string
+}
+
+variable "display_name" {
+  type    = string
+  default = null
+}
+
+variable "contact_email" {
+  type    = string
+  default = null
+}
+
+variable "managers" {
+  type    = set(string)
+  default = null
+}
+
+variable "members" {
+  type    = set(string)
+  default = null
+}
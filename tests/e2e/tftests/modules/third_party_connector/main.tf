@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+# The write-only authorization is only sent to Horizon when
+# credentials_version changes.
+resource "horizon_webhook_connector" "test" {
+  name                = var.name
+  description         = var.description
+  url                 = var.url
+  authorization       = var.authorization
+  credentials_version = var.credentials_version
+}
+
+data "horizon_third_party_connectors" "webhooks" {
+  type       = "webhook"
+  depends_on = [horizon_webhook_connector.test]
+}
+
+output "id" {
+  value = horizon_webhook_connector.test.id
+}
@@ -49,3 +49,10 @@ data "horizon_certificate_trust_chain" "issuer_root_to_leaf" {
   certificate_pem = horizon_certificate.test.certificate
   order           = "issuer_root_to_leaf"
 }
+
+data "horizon_certificate_trust_chain" "by_id_without_root" {
+  certificate_id = horizon_certificate.test.id
+  order          = "leaf_to_root"
+  include_root   = false
+  verify         = "error"
+}
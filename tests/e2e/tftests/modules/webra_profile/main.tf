@@ -0,0 +1,47 @@
+terraform {
+  required_providers {
+    horizon = {
+      source = "registry.terraform.io/evertrust/horizon"
+    }
+  }
+}
+
+provider "horizon" {
+  endpoint = var.endpoint
+  username = var.username
+  password = var.password
+}
+
+resource "horizon_webra_profile" "test" {
+  name          = var.name
+  enabled       = var.enabled
+  pki_connector = var.pki_connector
+
+  key_types   = ["rsa-2048", "ec-secp256r1"]
+  centralized = true
+
+  subject = [
+    {
+      type      = "CN"
+      mandatory = true
+    },
+    {
+      type                  = "O"
+      editable_by_requester = false
+      default_value         = "EverTrust"
+    }
+  ]
+
+  sans = [
+    {
+      type = "DNSNAME"
+      max  = 5
+    }
+  ]
+
+  max_lifetime = var.max_lifetime
+}
+
+output "id" {
+  value = horizon_webra_profile.test.id
+}
@@ -0,0 +1,29 @@
+variable "endpoint" {
+  type = string
+}
+
+variable "username" {
+  type = string
+}
+
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "pki_connector" {
+  type = string
+}
+
+variable "name" {
+  type = string
+}
+
+variable "enabled" {
+  type    = bool
+  default = true
+}
+
+variable "max_lifetime" {
+  type = string
+}
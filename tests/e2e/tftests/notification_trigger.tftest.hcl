@@ -0,0 +1,80 @@
+# Notifications and triggers on the lifecycle events of the certificates of
+# the centralized profile: create, then update both in place. They are
+# destroyed when terraform test cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+variable "centralized_profile" { type = string }
+
+run "create_notification_and_trigger" {
+  command = apply
+
+  module {
+    source = "./modules/notification_trigger"
+  }
+
+  variables {
+    endpoint               = var.endpoint
+    username               = var.username
+    password               = var.password
+    profile                = var.centralized_profile
+    name                   = "tf-e2e"
+    recipients             = ["pki-team@example.com"]
+    days_before_expiration = [30]
+    trigger_events         = ["enroll"]
+  }
+
+  assert {
+    condition     = horizon_notification.test.id == "tf-e2e-notification"
+    error_message = "notification id must be its name"
+  }
+  assert {
+    condition     = horizon_trigger.test.id == "tf-e2e-trigger"
+    error_message = "trigger id must be its name"
+  }
+  assert {
+    condition     = contains(horizon_notification.test.profiles, var.centralized_profile)
+    error_message = "notification profiles must be persisted in state"
+  }
+}
+
+run "update_notification_and_trigger" {
+  command = apply
+
+  module {
+    source = "./modules/notification_trigger"
+  }
+
+  variables {
+    endpoint               = var.endpoint
+    username               = var.username
+    password               = var.password
+    profile                = var.centralized_profile
+    name                   = "tf-e2e"
+    recipients             = ["pki-team@example.com", "web-team@example.com"]
+    days_before_expiration = [30, 7, 1]
+    trigger_events         = ["enroll", "renew", "revoke"]
+    retries                = 3
+  }
+
+  assert {
+    condition     = horizon_notification.test.id == run.create_notification_and_trigger.notification_id
+    error_message = "updating the notification must not replace it"
+  }
+  assert {
+    condition     = length(horizon_notification.test.days_before_expiration) == 3
+    error_message = "days_before_expiration must reflect the update"
+  }
+  assert {
+    condition     = horizon_trigger.test.id == run.create_notification_and_trigger.trigger_id
+    error_message = "updating the trigger must not replace it"
+  }
+  assert {
+    condition     = length(horizon_trigger.test.events) == 3 && horizon_trigger.test.retries == 3
+    error_message = "trigger events and retries must reflect the update"
+  }
+}
@@ -0,0 +1,91 @@
+# PKI connector lifecycle on an AD CS connector, whose write-only password
+# exercises credentials_version. The connector is destroyed when terraform
+# test cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+run "create_connector" {
+  command = apply
+
+  module {
+    source = "./modules/pki_connector"
+  }
+
+  variables {
+    endpoint            = var.endpoint
+    username            = var.username
+    password            = var.password
+    name                = "tf-e2e-adcs"
+    template            = "WebServer"
+    adcs_password       = "first"
+    credentials_version = 1
+  }
+
+  assert {
+    condition     = horizon_adcs_pki_connector.test.id == "tf-e2e-adcs"
+    error_message = "id must be the connector name"
+  }
+  assert {
+    condition     = horizon_adcs_pki_connector.test.password == null
+    error_message = "the write-only password must not be stored in state"
+  }
+}
+
+# A settings change without a new credentials_version leaves the password
+# Horizon has alone.
+run "update_settings" {
+  command = apply
+
+  module {
+    source = "./modules/pki_connector"
+  }
+
+  variables {
+    endpoint            = var.endpoint
+    username            = var.username
+    password            = var.password
+    name                = "tf-e2e-adcs"
+    description         = "Terraform e2e"
+    template            = "WebServerV2"
+    adcs_password       = "first"
+    credentials_version = 1
+  }
+
+  assert {
+    condition     = horizon_adcs_pki_connector.test.id == run.create_connector.id
+    error_message = "updating the connector must not replace it"
+  }
+  assert {
+    condition     = horizon_adcs_pki_connector.test.template == "WebServerV2"
+    error_message = "template must reflect the update"
+  }
+}
+
+run "rotate_credentials" {
+  command = apply
+
+  module {
+    source = "./modules/pki_connector"
+  }
+
+  variables {
+    endpoint            = var.endpoint
+    username            = var.username
+    password            = var.password
+    name                = "tf-e2e-adcs"
+    description         = "Terraform e2e"
+    template            = "WebServerV2"
+    adcs_password       = "second"
+    credentials_version = 2
+  }
+
+  assert {
+    condition     = horizon_adcs_pki_connector.test.credentials_version == 2
+    error_message = "credentials_version must reflect the rotation"
+  }
+}
@@ -0,0 +1,75 @@
+# ACME, EST and SCEP profiles issuing from the PKI connector of the test
+# instance: create, then update in place. The profiles are destroyed when
+# terraform test cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+variable "pki_connector" { type = string }
+
+run "create_profiles" {
+  command = apply
+
+  module {
+    source = "./modules/protocol_profiles"
+  }
+
+  variables {
+    endpoint                 = var.endpoint
+    username                 = var.username
+    password                 = var.password
+    pki_connector            = var.pki_connector
+    name                     = "tf-e2e"
+    max_lifetime             = "90 days"
+    static_challenge         = "first"
+    static_challenge_version = 1
+  }
+
+  assert {
+    condition     = horizon_acme_profile.test.id == "tf-e2e-acme"
+    error_message = "ACME profile id must be its name"
+  }
+  assert {
+    condition     = horizon_est_profile.test.challenge_lifetime == "1 hour"
+    error_message = "EST challenge_lifetime must keep the configured notation"
+  }
+  assert {
+    condition     = horizon_scep_profile.test.static_challenge == null
+    error_message = "the write-only static challenge must not be stored in state"
+  }
+}
+
+run "update_profiles" {
+  command = apply
+
+  module {
+    source = "./modules/protocol_profiles"
+  }
+
+  variables {
+    endpoint                 = var.endpoint
+    username                 = var.username
+    password                 = var.password
+    pki_connector            = var.pki_connector
+    name                     = "tf-e2e"
+    max_lifetime             = "P1Y"
+    static_challenge         = "second"
+    static_challenge_version = 2
+  }
+
+  assert {
+    condition     = horizon_acme_profile.test.id == run.create_profiles.acme_id && horizon_est_profile.test.id == run.create_profiles.est_id && horizon_scep_profile.test.id == run.create_profiles.scep_id
+    error_message = "updating the profiles must not replace them"
+  }
+  assert {
+    condition     = horizon_acme_profile.test.max_lifetime == "P1Y"
+    error_message = "max_lifetime must reflect the update"
+  }
+  assert {
+    condition     = horizon_scep_profile.test.static_challenge_version == 2
+    error_message = "static_challenge_version must reflect the rotation"
+  }
+}
@@ -0,0 +1,73 @@
+# Team lifecycle, read back through the horizon_team data source. Members and
+# managers must be existing accounts: the administrator of the test instance
+# is the only one. The team is destroyed when terraform test cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+run "create_team" {
+  command = apply
+
+  module {
+    source = "./modules/team"
+  }
+
+  variables {
+    endpoint      = var.endpoint
+    username      = var.username
+    password      = var.password
+    name          = "tf-e2e-team"
+    display_name  = "Terraform e2e"
+    contact_email = "team@example.com"
+    members       = [var.username]
+  }
+
+  assert {
+    condition     = horizon_team.test.id == "tf-e2e-team"
+    error_message = "id must be the team name"
+  }
+  assert {
+    condition     = horizon_team.test.managers == null
+    error_message = "managers must be null when unset"
+  }
+  assert {
+    condition     = data.horizon_team.test.contact_email == "team@example.com"
+    error_message = "the data source must read the contact email of the team"
+  }
+  assert {
+    condition     = contains(data.horizon_team.test.members, var.username)
+    error_message = "the data source must read the members of the team"
+  }
+}
+
+run "add_manager" {
+  command = apply
+
+  module {
+    source = "./modules/team"
+  }
+
+  variables {
+    endpoint      = var.endpoint
+    username      = var.username
+    password      = var.password
+    name          = "tf-e2e-team"
+    display_name  = "Terraform e2e"
+    contact_email = "team@example.com"
+    managers      = [var.username]
+    members       = [var.username]
+  }
+
+  assert {
+    condition     = horizon_team.test.id == run.create_team.id
+    error_message = "updating the team must not replace it"
+  }
+  assert {
+    condition     = contains(data.horizon_team.test.managers, var.username)
+    error_message = "the data source must read the managers of the team"
+  }
+}
@@ -0,0 +1,94 @@
+# Third-party connector lifecycle, on a webhook connector since it needs no
+# third party to exist, and listing through horizon_third_party_connectors.
+# The connector is destroyed when terraform test cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+run "create_connector" {
+  command = apply
+
+  module {
+    source = "./modules/third_party_connector"
+  }
+
+  variables {
+    endpoint            = var.endpoint
+    username            = var.username
+    password            = var.password
+    name                = "tf-e2e-webhook"
+    url                 = "https://hooks.example.com/horizon"
+    authorization       = "Bearer first"
+    credentials_version = 1
+  }
+
+  assert {
+    condition     = horizon_webhook_connector.test.id == "tf-e2e-webhook"
+    error_message = "id must be the connector name"
+  }
+  assert {
+    condition     = horizon_webhook_connector.test.authorization == null
+    error_message = "the write-only authorization must not be stored in state"
+  }
+  assert {
+    condition     = contains(data.horizon_third_party_connectors.webhooks.ids, "tf-e2e-webhook")
+    error_message = "horizon_third_party_connectors must list the new connector"
+  }
+}
+
+# A settings change without a new credentials_version leaves the
+# authorization Horizon has alone.
+run "update_settings" {
+  command = apply
+
+  module {
+    source = "./modules/third_party_connector"
+  }
+
+  variables {
+    endpoint            = var.endpoint
+    username            = var.username
+    password            = var.password
+    name                = "tf-e2e-webhook"
+    description         = "Terraform e2e"
+    url                 = "https://hooks.example.com/horizon/v2"
+    credentials_version = 1
+  }
+
+  assert {
+    condition     = horizon_webhook_connector.test.id == run.create_connector.id
+    error_message = "updating the connector must not replace it"
+  }
+  assert {
+    condition     = horizon_webhook_connector.test.url == "https://hooks.example.com/horizon/v2"
+    error_message = "url must reflect the update"
+  }
+}
+
+run "rotate_credentials" {
+  command = apply
+
+  module {
+    source = "./modules/third_party_connector"
+  }
+
+  variables {
+    endpoint            = var.endpoint
+    username            = var.username
+    password            = var.password
+    name                = "tf-e2e-webhook"
+    description         = "Terraform e2e"
+    url                 = "https://hooks.example.com/horizon/v2"
+    authorization       = "Bearer second"
+    credentials_version = 2
+  }
+
+  assert {
+    condition     = horizon_webhook_connector.test.credentials_version == 2
+    error_message = "credentials_version must reflect the rotation"
+  }
+}
@@ -0,0 +1,98 @@
+# WebRA profile lifecycle: create, no-op re-apply, in-place updates. The
+# profile is destroyed when terraform test cleans up.
+
+variable "endpoint" { type = string }
+variable "username" { type = string }
+variable "password" {
+  type      = string
+  sensitive = true
+}
+variable "pki_connector" { type = string }
+
+run "create_profile" {
+  command = apply
+
+  module {
+    source = "./modules/webra_profile"
+  }
+
+  variables {
+    endpoint      = var.endpoint
+    username      = var.username
+    password      = var.password
+    pki_connector = var.pki_connector
+    name          = "tf-e2e-webra"
+    max_lifetime  = "30 days"
+  }
+
+  assert {
+    condition     = horizon_webra_profile.test.id == "tf-e2e-webra"
+    error_message = "id must be the profile name"
+  }
+  assert {
+    condition     = horizon_webra_profile.test.max_lifetime == "30 days"
+    error_message = "max_lifetime must keep the configured notation"
+  }
+  assert {
+    condition     = length(horizon_webra_profile.test.subject) == 2
+    error_message = "both subject elements must be persisted in state"
+  }
+}
+
+# Re-apply with identical config: must be a no-op.
+run "profile_no_drift" {
+  command = apply
+
+  module {
+    source = "./modules/webra_profile"
+  }
+
+  variables {
+    endpoint      = var.endpoint
+    username      = var.username
+    password      = var.password
+    pki_connector = var.pki_connector
+    name          = "tf-e2e-webra"
+    max_lifetime  = "30 days"
+  }
+
+  assert {
+    condition     = horizon_webra_profile.test.id == run.create_profile.id
+    error_message = "no-op apply must not change id"
+  }
+  assert {
+    condition     = horizon_webra_profile.test.max_lifetime == "30 days"
+    error_message = "no-op apply must not change max_lifetime"
+  }
+}
+
+run "update_profile" {
+  command = apply
+
+  module {
+    source = "./modules/webra_profile"
+  }
+
+  variables {
+    endpoint      = var.endpoint
+    username      = var.username
+    password      = var.password
+    pki_connector = var.pki_connector
+    name          = "tf-e2e-webra"
+    enabled       = false
+    max_lifetime  = "P1Y"
+  }
+
+  assert {
+    condition     = horizon_webra_profile.test.id == run.create_profile.id
+    error_message = "updating the profile must not replace it"
+  }
+  assert {
+    condition     = horizon_webra_profile.test.enabled == false
+    error_message = "enabled must reflect the update"
+  }
+  assert {
+    condition     = horizon_webra_profile.test.max_lifetime == "P1Y"
+    error_message = "max_lifetime must reflect the update"
+  }
+}
@@ -0,0 +1,59 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccEstProfileConfig(challengeLifetime string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_est_profile" "test" {
+  name          = "tf-acc-est"
+  pki_connector = %q
+  key_types     = ["rsa-2048"]
+  max_lifetime  = "365 days"
+
+  authorization_mode = "challenge"
+  challenge_lifetime = %q
+}
+`, testAccPkiConnector(), challengeLifetime)
+}
+
+func TestAccEstProfile(t *testing.T) {
+	if testAccPkiConnector() == "" {
+		t.Skip("HORIZON_PKI_CONNECTOR not set; skipping EST profile test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_est_profile", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.EstProfileAPI.EstProfileGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccEstProfileConfig("1 hour"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_est_profile.test", "id", "tf-acc-est"),
+					resource.TestCheckResourceAttr("horizon_est_profile.test", "authorization_mode", "challenge"),
+					resource.TestCheckResourceAttr("horizon_est_profile.test", "challenge_lifetime", "1 hour"),
+				),
+			},
+			{
+				Config: testAccEstProfileConfig("2 hours"),
+				Check:  resource.TestCheckResourceAttr("horizon_est_profile.test", "challenge_lifetime", "2 hours"),
+			},
+			{
+				ResourceName:      "horizon_est_profile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccGradingPolicyConfig builds a policy grading the WebRA certificates
+// with the given rules, among two created for the test.
+func testAccGradingPolicyConfig(rules string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_grading_rule" "weak_rsa" {
+  name       = "tf-acc-weak-rsa"
+  expression = "keytype in [\"rsa-512\", \"rsa-1024\"]"
+  severity   = "critical"
+}
+
+resource "horizon_grading_rule" "sha1" {
+  name       = "tf-acc-sha1"
+  expression = provider::horizon::hrql({ field = "signingalgorithm", in = ["SHA1WithRSA", "SHA1WithECDSA"] })
+  severity   = "high"
+}
+
+resource "horizon_grading_policy" "test" {
+  name        = "tf-acc-crypto-policy"
+  description = "Terraform acceptance"
+  scope       = "module equals \"webra\""
+  rules       = %s
+}
+`, rules)
+}
+
+func TestAccGradingPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_grading_policy", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.GradingPolicyAPI.GradingPolicyGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGradingPolicyConfig(`[horizon_grading_rule.weak_rsa.name]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_grading_policy.test", "id", "tf-acc-crypto-policy"),
+					resource.TestCheckResourceAttr("horizon_grading_policy.test", "rules.#", "1"),
+				),
+			},
+			{
+				Config: testAccGradingPolicyConfig(`[horizon_grading_rule.weak_rsa.name, horizon_grading_rule.sha1.name]`),
+				Check:  resource.TestCheckResourceAttr("horizon_grading_policy.test", "rules.#", "2"),
+			},
+			{
+				ResourceName:      "horizon_grading_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,52 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccGradingRuleConfig(severity string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_grading_rule" "test" {
+  name        = "tf-acc-no-sha1"
+  description = "SHA-1 signatures are forbidden"
+  expression  = provider::horizon::hrql({ field = "signingalgorithm", in = ["SHA1WithRSA", "SHA1WithECDSA"] })
+  severity    = %q
+}
+`, severity)
+}
+
+func TestAccGradingRule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_grading_rule", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.GradingRuleAPI.GradingRuleGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccGradingRuleConfig("high"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_grading_rule.test", "id", "tf-acc-no-sha1"),
+					resource.TestCheckResourceAttr("horizon_grading_rule.test", "severity", "high"),
+					resource.TestCheckResourceAttrSet("horizon_grading_rule.test", "expression"),
+				),
+			},
+			{
+				Config: testAccGradingRuleConfig("critical"),
+				Check:  resource.TestCheckResourceAttr("horizon_grading_rule.test", "severity", "critical"),
+			},
+			{
+				ResourceName:      "horizon_grading_rule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
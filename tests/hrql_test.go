@@ -0,0 +1,69 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/evertrust/horizon-go/v2/models"
+	"github.com/evertrust/terraform-provider-horizon/internal/hrql"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccCheckHrqlMatches searches Horizon with the query built by expr and
+// checks whether the certificate of the resource is among the results.
+func testAccCheckHrqlMatches(t *testing.T, resourceName string, expr hrql.Expr, wantMatch bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+		id := rs.Primary.ID
+
+		query, err := hrql.Build(expr)
+		if err != nil {
+			return err
+		}
+		search := models.NewCertificateSearchQuery()
+		search.SetPageSize(100)
+		search.SetQuery(query)
+		results, _, err := testAccClient(t).CertificateAPI.CertificateSearch(context.Background()).CertificateSearchQuery(*search).Execute()
+		if err != nil {
+			return fmt.Errorf("Horizon rejected the query %s: %w", query, err)
+		}
+
+		found := false
+		for _, certificate := range results.GetResults() {
+			found = found || certificate.GetId() == id
+		}
+		if found != wantMatch {
+			return fmt.Errorf("query %s: certificate %s matched = %v, want %v", query, id, found, wantMatch)
+		}
+		return nil
+	}
+}
+
+// TestAccHrql_DateLiterals checks that Horizon accepts the date literals of
+// hrql.Before and hrql.After and compares them as dates: a certificate
+// enrolled now expires after now, and not before.
+func TestAccHrql_DateLiterals(t *testing.T) {
+	now := time.Now()
+	profile := hrql.Equals("profile", testAccProfile())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCentralizedConfig("hrql-dates.tf-test.internal", false, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckHrqlMatches(t, "horizon_certificate.test", hrql.And(profile, hrql.After("notafter", now)), true),
+					testAccCheckHrqlMatches(t, "horizon_certificate.test", hrql.And(profile, hrql.Before("notafter", now)), false),
+					testAccCheckHrqlMatches(t, "horizon_certificate.test", hrql.And(profile, hrql.Before("notbefore", now.Add(time.Hour))), true),
+				),
+			},
+		},
+	})
+}
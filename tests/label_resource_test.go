@@ -0,0 +1,62 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccLabelConfig builds a label, never mandatory so that the enrollments
+// of the other tests are not rejected while it exists, and lists the labels.
+func testAccLabelConfig(displayName string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_label" "test" {
+  name           = "tf-acc-label"
+  display_name   = %q
+  allowed_values = ["dev", "prod"]
+}
+
+data "horizon_labels" "all" {
+  depends_on = [horizon_label.test]
+}
+`, displayName)
+}
+
+func TestAccLabel(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_label", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.LabelAPI.LabelGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLabelConfig("Terraform"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_label.test", "id", "tf-acc-label"),
+					resource.TestCheckResourceAttr("horizon_label.test", "display_name", "Terraform"),
+					resource.TestCheckResourceAttr("horizon_label.test", "allowed_values.#", "2"),
+					resource.TestCheckResourceAttr("horizon_label.test", "mandatory", "false"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.horizon_labels.all", "labels.*", map[string]string{
+						"name":         "tf-acc-label",
+						"display_name": "Terraform",
+					}),
+				),
+			},
+			{
+				Config: testAccLabelConfig("Terraform acceptance"),
+				Check:  resource.TestCheckResourceAttr("horizon_label.test", "display_name", "Terraform acceptance"),
+			},
+			{
+				ResourceName:      "horizon_label.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
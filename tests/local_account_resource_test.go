@@ -0,0 +1,63 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+func testAccLocalAccountConfig(name string, passwordVersion int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_local_account" "test" {
+  identifier = "tf-acc-account"
+  name       = %q
+  email      = "tf-acc@example.com"
+
+  password         = "tf-acc-password-%[2]d"
+  password_version = %[2]d
+}
+`, name, passwordVersion)
+}
+
+func TestAccLocalAccount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_local_account", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.LocalAccountAPI.LocalAccountGet(context.Background(), attributes["identifier"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLocalAccountConfig("Terraform", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_local_account.test", "id", "tf-acc-account"),
+					resource.TestCheckResourceAttr("horizon_local_account.test", "enabled", "true"),
+					resource.TestCheckNoResourceAttr("horizon_local_account.test", "password"),
+				),
+			},
+			{
+				Config: testAccLocalAccountConfig("Terraform acceptance", 1),
+				Check:  resource.TestCheckResourceAttr("horizon_local_account.test", "name", "Terraform acceptance"),
+			},
+			{
+				Config: testAccLocalAccountConfig("Terraform acceptance", 2),
+				Check:  resource.TestCheckResourceAttr("horizon_local_account.test", "password_version", "2"),
+			},
+			{
+				ResourceName:            "horizon_local_account.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"password_version"},
+			},
+		},
+	})
+}
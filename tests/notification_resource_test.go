@@ -0,0 +1,58 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccNotificationConfig(daysBeforeExpiration string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_notification" "test" {
+  name       = "tf-acc-notification"
+  events     = ["expire"]
+  recipients = ["pki-team@example.com"]
+
+  subject = "Certificate {{certificate.subject}} expires soon"
+  body    = "The certificate {{certificate.subject}} expires on {{certificate.notAfter}}."
+
+  days_before_expiration = %s
+  profiles               = [%q]
+}
+`, daysBeforeExpiration, testAccProfile())
+}
+
+func TestAccNotification(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_notification", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.NotificationAPI.NotificationGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationConfig("[30]"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_notification.test", "id", "tf-acc-notification"),
+					resource.TestCheckResourceAttr("horizon_notification.test", "events.#", "1"),
+					resource.TestCheckResourceAttr("horizon_notification.test", "days_before_expiration.#", "1"),
+					resource.TestCheckTypeSetElemAttr("horizon_notification.test", "profiles.*", testAccProfile()),
+				),
+			},
+			{
+				Config: testAccNotificationConfig("[30, 7, 1]"),
+				Check:  resource.TestCheckResourceAttr("horizon_notification.test", "days_before_expiration.#", "3"),
+			},
+			{
+				ResourceName:      "horizon_notification.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccAwsPcaPkiConnectorConfig builds an AWS Private CA connector
+// authenticating with the credentials of the Horizon host, so that it has no
+// write-only credential.
+func testAccAwsPcaPkiConnectorConfig(region string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_aws_pca_pki_connector" "test" {
+  name        = "tf-acc-aws-pca"
+  description = "Terraform acceptance"
+  region      = %q
+  ca_arn      = "arn:aws:acm-pca:%[1]s:123456789012:certificate-authority/11111111-2222-3333-4444-555555555555"
+}
+`, region)
+}
+
+func TestAccAwsPcaPkiConnector(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_aws_pca_pki_connector", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.PkiConnectorAPI.PkiConnectorGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAwsPcaPkiConnectorConfig("eu-west-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_aws_pca_pki_connector.test", "id", "tf-acc-aws-pca"),
+					resource.TestCheckResourceAttr("horizon_aws_pca_pki_connector.test", "region", "eu-west-1"),
+					resource.TestCheckNoResourceAttr("horizon_aws_pca_pki_connector.test", "access_key_id"),
+				),
+			},
+			{
+				Config: testAccAwsPcaPkiConnectorConfig("eu-west-3"),
+				Check:  resource.TestCheckResourceAttr("horizon_aws_pca_pki_connector.test", "region", "eu-west-3"),
+			},
+			{
+				ResourceName:      "horizon_aws_pca_pki_connector.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -1,12 +1,18 @@
 package provider_test
 
 import (
+	"fmt"
+	"net/http"
+	"net/url"
 	"os"
 	"testing"
 
+	horizon "github.com/evertrust/horizon-go/v2"
 	"github.com/evertrust/terraform-provider-horizon/internal/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 )
 
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
@@ -40,3 +46,42 @@ provider "horizon" {
 }
 `
 }
+
+// testAccClient returns a Horizon client authenticated like the provider of
+// testAccProviderConfig, for checks that query Horizon directly.
+func testAccClient(t *testing.T) *horizon.APIClient {
+	t.Helper()
+	endpoint, err := url.Parse(os.Getenv("HORIZON_ENDPOINT"))
+	if err != nil {
+		t.Fatalf("invalid HORIZON_ENDPOINT: %s", err)
+	}
+
+	cfg := horizon.NewConfiguration()
+	cfg.Servers = horizon.ServerConfigurations{
+		horizon.ServerConfiguration{URL: endpoint.String()},
+	}
+	cfg.Scheme = endpoint.Scheme
+	cfg.SetPasswordAuth(os.Getenv("HORIZON_USERNAME"), os.Getenv("HORIZON_PASSWORD"))
+	return horizon.NewAPIClient(cfg)
+}
+
+// testAccCheckDestroyed checks that Horizon no longer has any of the
+// resources of resourceType, which get looks up from their state attributes.
+func testAccCheckDestroyed(t *testing.T, resourceType string, get func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error)) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccClient(t)
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != resourceType {
+				continue
+			}
+			httpResp, err := get(client, rs.Primary.Attributes)
+			if err == nil {
+				return fmt.Errorf("%s %s still exists in Horizon", resourceType, rs.Primary.ID)
+			}
+			if httpResp == nil || httpResp.StatusCode != http.StatusNotFound {
+				return fmt.Errorf("failed to check that %s %s was destroyed: %w", resourceType, rs.Primary.ID, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,85 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccRoleBindingConfig grants roles to a local account created for the
+// test.
+func testAccRoleBindingConfig(roles string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_local_account" "test" {
+  identifier = "tf-acc-bound-account"
+  password   = "tf-acc-password"
+}
+
+resource "horizon_role" "search" {
+  name = "tf-acc-search"
+
+  permissions = [
+    {
+      scope   = "lifecycle"
+      profile = %q
+      actions = ["search"]
+    }
+  ]
+}
+
+resource "horizon_role" "enroll" {
+  name = "tf-acc-enroll"
+
+  permissions = [
+    {
+      scope   = "lifecycle"
+      profile = %[1]q
+      actions = ["enroll"]
+    }
+  ]
+}
+
+resource "horizon_role_binding" "test" {
+  principal = horizon_local_account.test.identifier
+  roles     = %[2]s
+}
+`, testAccProfile(), roles)
+}
+
+func TestAccRoleBinding(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_role_binding", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.AuthorizationAPI.AuthorizationGet(context.Background(), attributes["principal"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleBindingConfig(`[horizon_role.search.name]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_role_binding.test", "id", "tf-acc-bound-account"),
+					resource.TestCheckNoResourceAttr("horizon_role_binding.test", "identity_provider"),
+					resource.TestCheckTypeSetElemAttr("horizon_role_binding.test", "roles.*", "tf-acc-search"),
+				),
+			},
+			{
+				Config: testAccRoleBindingConfig(`[horizon_role.search.name, horizon_role.enroll.name]`),
+				Check:  resource.TestCheckResourceAttr("horizon_role_binding.test", "roles.#", "2"),
+			},
+			{
+				ResourceName:      "horizon_role_binding.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
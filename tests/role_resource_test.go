@@ -0,0 +1,58 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccRoleConfig(actions string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_role" "test" {
+  name        = "tf-acc-role"
+  description = "Terraform acceptance"
+
+  permissions = [
+    {
+      scope   = "lifecycle"
+      profile = %q
+      actions = %s
+    }
+  ]
+}
+`, testAccProfile(), actions)
+}
+
+func TestAccRole(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_role", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.RoleAPI.RoleGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleConfig(`["search"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_role.test", "id", "tf-acc-role"),
+					resource.TestCheckResourceAttr("horizon_role.test", "permissions.#", "1"),
+					resource.TestCheckResourceAttr("horizon_role.test", "permissions.0.actions.#", "1"),
+				),
+			},
+			{
+				Config: testAccRoleConfig(`["enroll", "renew", "search"]`),
+				Check:  resource.TestCheckResourceAttr("horizon_role.test", "permissions.0.actions.#", "3"),
+			},
+			{
+				ResourceName:      "horizon_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
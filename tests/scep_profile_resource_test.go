@@ -0,0 +1,70 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccScepProfileConfig builds a SCEP profile with a write-only static
+// challenge, only sent when staticChallengeVersion changes.
+func testAccScepProfileConfig(maxLifetime string, staticChallengeVersion int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_scep_profile" "test" {
+  name          = "tf-acc-scep"
+  pki_connector = %q
+  max_lifetime  = %q
+
+  authorization_mode       = "static_challenge"
+  static_challenge         = "tf-acc-challenge-%[3]d"
+  static_challenge_version = %[3]d
+}
+`, testAccPkiConnector(), maxLifetime, staticChallengeVersion)
+}
+
+func TestAccScepProfile(t *testing.T) {
+	if testAccPkiConnector() == "" {
+		t.Skip("HORIZON_PKI_CONNECTOR not set; skipping SCEP profile test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_scep_profile", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.ScepProfileAPI.ScepProfileGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScepProfileConfig("365 days", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_scep_profile.test", "id", "tf-acc-scep"),
+					resource.TestCheckResourceAttr("horizon_scep_profile.test", "authorization_mode", "static_challenge"),
+					resource.TestCheckNoResourceAttr("horizon_scep_profile.test", "static_challenge"),
+				),
+			},
+			{
+				Config: testAccScepProfileConfig("730 days", 1),
+				Check:  resource.TestCheckResourceAttr("horizon_scep_profile.test", "max_lifetime", "730 days"),
+			},
+			{
+				Config: testAccScepProfileConfig("730 days", 2),
+				Check:  resource.TestCheckResourceAttr("horizon_scep_profile.test", "static_challenge_version", "2"),
+			},
+			{
+				ResourceName:            "horizon_scep_profile.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"static_challenge_version"},
+			},
+		},
+	})
+}
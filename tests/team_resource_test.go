@@ -0,0 +1,65 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccTeamConfig builds a team managed by the account of the provider,
+// and reads it back through the data source.
+func testAccTeamConfig(contactEmail string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_team" "test" {
+  name          = "tf-acc-team"
+  display_name  = "Terraform acceptance"
+  contact_email = %q
+  managers      = [%[2]q]
+  members       = [%[2]q]
+}
+
+data "horizon_team" "test" {
+  name = horizon_team.test.name
+}
+`, contactEmail, os.Getenv("HORIZON_USERNAME"))
+}
+
+func TestAccTeam(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_team", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.TeamAPI.TeamGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamConfig("team@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_team.test", "id", "tf-acc-team"),
+					resource.TestCheckResourceAttr("horizon_team.test", "managers.#", "1"),
+					resource.TestCheckResourceAttr("horizon_team.test", "members.#", "1"),
+					resource.TestCheckResourceAttr("data.horizon_team.test", "contact_email", "team@example.com"),
+					resource.TestCheckResourceAttr("data.horizon_team.test", "managers.#", "1"),
+				),
+			},
+			{
+				Config: testAccTeamConfig("ops@example.com"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_team.test", "contact_email", "ops@example.com"),
+					resource.TestCheckResourceAttr("data.horizon_team.test", "contact_email", "ops@example.com"),
+				),
+			},
+			{
+				ResourceName:      "horizon_team.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
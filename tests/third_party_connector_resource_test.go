@@ -0,0 +1,76 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testAccWebhookConnectorConfig builds a webhook connector, whose write-only
+// authorization is only sent when credentialsVersion changes, and lists the
+// webhook connectors.
+func testAccWebhookConnectorConfig(description string, credentialsVersion int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_webhook_connector" "test" {
+  name                = "tf-acc-webhook"
+  description         = %q
+  url                 = "https://hooks.example.com/horizon"
+  authorization       = "Bearer tf-acc-%[2]d"
+  credentials_version = %[2]d
+}
+
+data "horizon_third_party_connectors" "webhooks" {
+  type       = "webhook"
+  depends_on = [horizon_webhook_connector.test]
+}
+`, description, credentialsVersion)
+}
+
+func TestAccWebhookConnector(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_11_0),
+		},
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_webhook_connector", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.ThirdPartyConnectorAPI.ThirdPartyConnectorGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebhookConnectorConfig("Terraform", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_webhook_connector.test", "id", "tf-acc-webhook"),
+					resource.TestCheckResourceAttr("horizon_webhook_connector.test", "url", "https://hooks.example.com/horizon"),
+					resource.TestCheckResourceAttr("horizon_webhook_connector.test", "credentials_version", "1"),
+					resource.TestCheckNoResourceAttr("horizon_webhook_connector.test", "authorization"),
+					resource.TestCheckTypeSetElemAttr("data.horizon_third_party_connectors.webhooks", "ids.*", "tf-acc-webhook"),
+				),
+			},
+			// Without a new credentials_version, only the settings are sent.
+			{
+				Config: testAccWebhookConnectorConfig("Terraform acceptance", 1),
+				Check:  resource.TestCheckResourceAttr("horizon_webhook_connector.test", "description", "Terraform acceptance"),
+			},
+			{
+				Config: testAccWebhookConnectorConfig("Terraform acceptance", 2),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_webhook_connector.test", "credentials_version", "2"),
+					resource.TestCheckNoResourceAttr("horizon_webhook_connector.test", "authorization"),
+				),
+			},
+			{
+				ResourceName:            "horizon_webhook_connector.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"credentials_version"},
+			},
+		},
+	})
+}
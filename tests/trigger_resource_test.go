@@ -0,0 +1,59 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func testAccTriggerConfig(retries int) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_trigger" "test" {
+  name   = "tf-acc-trigger"
+  events = ["enroll", "revoke"]
+  url    = "https://hooks.example.com/horizon"
+
+  headers = {
+    "X-Source" = "terraform"
+  }
+  retries = %d
+
+  profiles = [%q]
+}
+`, retries, testAccProfile())
+}
+
+func TestAccTrigger(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_trigger", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.TriggerAPI.TriggerGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTriggerConfig(1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_trigger.test", "id", "tf-acc-trigger"),
+					resource.TestCheckResourceAttr("horizon_trigger.test", "events.#", "2"),
+					resource.TestCheckResourceAttr("horizon_trigger.test", "headers.X-Source", "terraform"),
+					resource.TestCheckResourceAttr("horizon_trigger.test", "retries", "1"),
+				),
+			},
+			{
+				Config: testAccTriggerConfig(3),
+				Check:  resource.TestCheckResourceAttr("horizon_trigger.test", "retries", "3"),
+			},
+			{
+				ResourceName:      "horizon_trigger.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
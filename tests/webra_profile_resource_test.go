@@ -0,0 +1,79 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	horizon "github.com/evertrust/horizon-go/v2"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// testAccPkiConnector returns the PKI connector the profiles of the
+// configuration tests issue from. When unset, those tests are skipped.
+func testAccPkiConnector() string {
+	return os.Getenv("HORIZON_PKI_CONNECTOR")
+}
+
+func testAccWebRAProfileConfig(maxLifetime string) string {
+	return testAccProviderConfig() + fmt.Sprintf(`
+resource "horizon_webra_profile" "test" {
+  name          = "tf-acc-webra"
+  pki_connector = %q
+
+  key_types   = ["rsa-2048"]
+  centralized = true
+
+  subject = [
+    {
+      type      = "CN"
+      mandatory = true
+    }
+  ]
+
+  max_lifetime = %q
+}
+`, testAccPkiConnector(), maxLifetime)
+}
+
+func TestAccWebRAProfile(t *testing.T) {
+	if testAccPkiConnector() == "" {
+		t.Skip("HORIZON_PKI_CONNECTOR not set; skipping WebRA profile test")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: testAccCheckDestroyed(t, "horizon_webra_profile", func(client *horizon.APIClient, attributes map[string]string) (*http.Response, error) {
+			_, httpResp, err := client.WebRAProfileAPI.WebRAProfileGet(context.Background(), attributes["name"]).Execute()
+			return httpResp, err
+		}),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebRAProfileConfig("30 days"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("horizon_webra_profile.test", "id", "tf-acc-webra"),
+					resource.TestCheckResourceAttr("horizon_webra_profile.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("horizon_webra_profile.test", "max_lifetime", "30 days"),
+					resource.TestCheckResourceAttr("horizon_webra_profile.test", "subject.0.type", "CN"),
+				),
+			},
+			{
+				Config:             testAccWebRAProfileConfig("30 days"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: testAccWebRAProfileConfig("365 days"),
+				Check:  resource.TestCheckResourceAttr("horizon_webra_profile.test", "max_lifetime", "365 days"),
+			},
+			{
+				ResourceName:      "horizon_webra_profile.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}